@@ -16,6 +16,7 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/sync"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 func TestParseFlagValidators(t *testing.T) {
@@ -326,3 +327,54 @@ func TestParseGenesisOption(t *testing.T) {
 		require.Equal(t, errors.NotPublicKey.Code, err.(*errors.Error).Code)
 	}
 }
+
+func TestParseFeeSchedule(t *testing.T) {
+	{ // empty
+		fs, err := parseFeeSchedule("")
+		require.NoError(t, err)
+		require.Equal(t, operation.NewFeeSchedule(), fs)
+	}
+
+	{ // base, frozen and operation type
+		fs, err := parseFeeSchedule("base=20000, frozen=1,payment=30000")
+		require.NoError(t, err)
+		require.Equal(t, common.Amount(20000), fs.Base)
+		require.Equal(t, common.Amount(1), fs.Frozen)
+		require.Equal(t, map[operation.OperationType]common.Amount{operation.TypePayment: 30000}, fs.Operations)
+	}
+
+	{ // unknown operation type
+		_, err := parseFeeSchedule("unknown=1")
+		require.Error(t, err)
+	}
+
+	{ // operation of proposer transaction
+		_, err := parseFeeSchedule("collect-tx-fee=1")
+		require.Error(t, err)
+	}
+
+	{ // invalid fee
+		_, err := parseFeeSchedule("payment=a1")
+		require.Error(t, err)
+	}
+}
+
+func TestParseGenesisValidators(t *testing.T) {
+	{ // empty
+		validators, err := parseGenesisValidators("")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(validators))
+	}
+
+	{ // addresses
+		kp0, kp1 := keypair.Random(), keypair.Random()
+		validators, err := parseGenesisValidators(kp0.Address() + ", " + kp1.Address())
+		require.NoError(t, err)
+		require.Equal(t, []string{kp0.Address(), kp1.Address()}, validators)
+	}
+
+	{ // invalid address
+		_, err := parseGenesisValidators("invalid")
+		require.Error(t, err)
+	}
+}
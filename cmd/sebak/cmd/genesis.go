@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	logging "github.com/inconshreveable/log15"
 	"github.com/spf13/cobra"
@@ -13,6 +15,7 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/node/runner"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 const (
@@ -20,7 +23,12 @@ const (
 )
 
 var (
-	flagBalance string = common.GetENVValue("SEBAK_GENESIS_BALANCE", initialBalance)
+	flagBalance                     string = common.GetENVValue("SEBAK_GENESIS_BALANCE", initialBalance)
+	flagFeeSchedule                 string = common.GetENVValue("SEBAK_GENESIS_FEE_SCHEDULE", "")
+	flagTxV2ActivationHeight        string = common.GetENVValue("SEBAK_GENESIS_TX_V2_ACTIVATION_HEIGHT", strconv.FormatUint(common.DefaultTxV2ActivationHeight, 10))
+	flagCertificateActivationHeight string = common.GetENVValue("SEBAK_GENESIS_CERTIFICATE_ACTIVATION_HEIGHT", strconv.FormatUint(common.DefaultCertificateActivationHeight, 10))
+	flagProposerMissLimit           string = common.GetENVValue("SEBAK_GENESIS_PROPOSER_MISS_LIMIT", strconv.FormatUint(common.DefaultProposerMissLimit, 10))
+	flagGenesisValidators           string = common.GetENVValue("SEBAK_GENESIS_VALIDATORS", "")
 )
 
 func init() {
@@ -36,7 +44,12 @@ func init() {
 				cmdcommon.PrintError(c, err)
 			}
 
-			flagName, err := makeGenesisBlock(genesisKP, commonKP, flagNetworkID, balance, flagStorageConfigString, log)
+			params, flagName, err := parseNetworkParameters("--")
+			if err != nil {
+				cmdcommon.PrintFlagsError(c, flagName, err)
+			}
+
+			flagName, err = makeGenesisBlock(genesisKP, commonKP, flagNetworkID, balance, params, flagStorageConfigString, log)
 			if len(flagName) != 0 || err != nil {
 				cmdcommon.PrintFlagsError(c, flagName, err)
 			}
@@ -46,6 +59,11 @@ func init() {
 	}
 
 	genesisCmd.Flags().StringVar(&flagBalance, "balance", flagBalance, "initial balance of genesis block")
+	genesisCmd.Flags().StringVar(&flagFeeSchedule, "fee-schedule", flagFeeSchedule, "fee of operations in GON. Syntax: base=<fee>,frozen=<fee>,<operation type>=<fee>,...")
+	genesisCmd.Flags().StringVar(&flagTxV2ActivationHeight, "tx-v2-activation-height", flagTxV2ActivationHeight, "block height, from which the transaction version 2 is accepted (0= not activated)")
+	genesisCmd.Flags().StringVar(&flagCertificateActivationHeight, "certificate-activation-height", flagCertificateActivationHeight, "block height, from which the block must have the certificate (0= not required)")
+	genesisCmd.Flags().StringVar(&flagProposerMissLimit, "proposer-miss-limit", flagProposerMissLimit, "the validator, which missed the last proposals of this number, is not selected as proposer (0= no limit)")
+	genesisCmd.Flags().StringVar(&flagGenesisValidators, "validators", flagGenesisValidators, "public addresses of validators of genesis. Syntax: <public address>,<public address>,...")
 	genesisCmd.Flags().StringVar(&flagStorageConfigString, "storage", flagStorageConfigString, "storage uri")
 	genesisCmd.Flags().StringVar(&flagNetworkID, "network-id", flagNetworkID, "network id")
 
@@ -64,6 +82,8 @@ func init() {
 //   balanceStr = Amount of coins to put in the genesis account
 //                If not provided, `flagBalance`, which is the value set in the env
 //                when called from another module, will be used
+//   params = Parameters of network, like the fee of each type of operation,
+//            which are included in genesis block
 //   storageUri = URI to include storage path("file://path")
 //                If not provided, a default value will be used
 //
@@ -72,7 +92,7 @@ func init() {
 //   The string argument represent the name of the flag which errored,
 //   and error is the more detailed error.
 //   Note that only one needs be non-`nil` for it to be considered an error.
-func makeGenesisBlock(genesisKP, commonKP keypair.KP, networkID string, balance common.Amount, storageUri string, log logging.Logger) (string, error) {
+func makeGenesisBlock(genesisKP, commonKP keypair.KP, networkID string, balance common.Amount, params operation.NetworkParameters, storageUri string, log logging.Logger) (string, error) {
 	var err error
 
 	if len(networkID) == 0 {
@@ -94,7 +114,7 @@ func makeGenesisBlock(genesisKP, commonKP keypair.KP, networkID string, balance
 	}
 	defer st.Close()
 
-	created, err := checkExistingAccounts(st, flagNetworkID, genesisKP.Address(), commonKP.Address(), balance)
+	created, err := checkExistingAccounts(st, flagNetworkID, genesisKP.Address(), commonKP.Address(), balance, params)
 	if err != nil {
 		if created {
 			return "--storage", fmt.Errorf("genesis block is already created, but: %v", err)
@@ -133,7 +153,7 @@ func makeGenesisBlock(genesisKP, commonKP keypair.KP, networkID string, balance
 		return "<public key>", fmt.Errorf("failed to create common account: %v", err)
 	}
 
-	b, err := block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, []byte(flagNetworkID))
+	b, err := block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, params, []byte(flagNetworkID))
 	if err != nil {
 		return "<public key>", fmt.Errorf("failed to create genesis block: %v", err)
 	}
@@ -150,7 +170,7 @@ func makeGenesisBlock(genesisKP, commonKP keypair.KP, networkID string, balance
 	return "", nil
 }
 
-func checkExistingAccounts(st *storage.LevelDBBackend, networkID, genesisAddress, commonAddress string, balance common.Amount) (created bool, err error) {
+func checkExistingAccounts(st *storage.LevelDBBackend, networkID, genesisAddress, commonAddress string, balance common.Amount, params operation.NetworkParameters) (created bool, err error) {
 	// check network id
 	var bt block.BlockTransaction
 	if bt, err = runner.GetGenesisTransaction(st); err != nil {
@@ -192,6 +212,15 @@ func checkExistingAccounts(st *storage.LevelDBBackend, networkID, genesisAddress
 		return
 	}
 
+	var genesisParams operation.NetworkParameters
+	if genesisParams, err = block.GetNetworkParameters(st); err != nil {
+		return
+	}
+	if common.MustMakeObjectHashString(genesisParams) != common.MustMakeObjectHashString(params) {
+		err = fmt.Errorf("different network parameters")
+		return
+	}
+
 	var tp block.TransactionPool
 	if tp, err = block.GetTransactionPool(st, bt.Hash); err != nil {
 		return
@@ -209,3 +238,98 @@ func checkExistingAccounts(st *storage.LevelDBBackend, networkID, genesisAddress
 
 	return
 }
+
+// parseNetworkParameters parses the flags of `operation.NetworkParameters`,
+// which are included in the genesis block. The name of flag is prefixed by
+// `prefix`, and it is returned with the error.
+func parseNetworkParameters(prefix string) (params operation.NetworkParameters, flagName string, err error) {
+	params = operation.NewNetworkParameters()
+
+	if params.FeeSchedule, err = parseFeeSchedule(flagFeeSchedule); err != nil {
+		flagName = prefix + "fee-schedule"
+		return
+	}
+	if params.TxV2ActivationHeight, err = strconv.ParseUint(flagTxV2ActivationHeight, 10, 64); err != nil {
+		flagName = prefix + "tx-v2-activation-height"
+		return
+	}
+	if params.CertificateActivationHeight, err = strconv.ParseUint(flagCertificateActivationHeight, 10, 64); err != nil {
+		flagName = prefix + "certificate-activation-height"
+		return
+	}
+	if params.ProposerMissLimit, err = strconv.ParseUint(flagProposerMissLimit, 10, 64); err != nil {
+		flagName = prefix + "proposer-miss-limit"
+		return
+	}
+	if params.Validators, err = parseGenesisValidators(flagGenesisValidators); err != nil {
+		flagName = prefix + "validators"
+		return
+	}
+
+	return
+}
+
+// parseGenesisValidators parses the comma separated public addresses of
+// validators.
+func parseGenesisValidators(s string) (validators []string, err error) {
+	validators = []string{}
+	if len(strings.TrimSpace(s)) < 1 {
+		return
+	}
+
+	for _, address := range strings.Split(s, ",") {
+		address = strings.TrimSpace(address)
+		if _, err = keypair.Parse(address); err != nil {
+			err = fmt.Errorf("invalid validator address: %q", address)
+			return
+		}
+		validators = append(validators, address)
+	}
+
+	return
+}
+
+// parseFeeSchedule parses the fee schedule from the comma separated
+// `<name>=<fee in GON>` list. The name is `base`, `frozen` or the name of
+// operation type. The fee, which is not given, is the default of
+// `operation.NewFeeSchedule`.
+func parseFeeSchedule(s string) (fs operation.FeeSchedule, err error) {
+	fs = operation.NewFeeSchedule()
+	if len(strings.TrimSpace(s)) < 1 {
+		return
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			err = fmt.Errorf("invalid fee: %q", item)
+			return
+		}
+
+		var fee common.Amount
+		if fee, err = common.AmountFromString(strings.TrimSpace(kv[1])); err != nil {
+			return
+		}
+
+		switch name := strings.TrimSpace(kv[0]); name {
+		case "base":
+			fs.Base = fee
+		case "frozen":
+			fs.Frozen = fee
+		default:
+			var t operation.OperationType
+			if err = t.UnmarshalText([]byte(name)); err != nil {
+				err = fmt.Errorf("unknown operation type: %q", name)
+				return
+			}
+			if fs.Operations == nil {
+				fs.Operations = map[operation.OperationType]common.Amount{}
+			}
+			fs.Operations[t] = fee
+		}
+	}
+
+	err = fs.IsWellFormed()
+
+	return
+}
@@ -78,6 +78,13 @@ var (
 	flagTransactionsLimit       string = common.GetENVValue("SEBAK_TRANSACTIONS_LIMIT", strconv.Itoa(common.DefaultTransactionsInBallotLimit))
 	flagOperationsInBallotLimit string = common.GetENVValue("SEBAK_OPERATIONS_IN_BALLOT_LIMIT", strconv.Itoa(common.DefaultOperationsInBallotLimit))
 	flagTxPoolLimit             string = common.GetENVValue("SEBAK_TX_POOL_LIMIT", strconv.Itoa(common.DefaultTxPoolLimit))
+	flagTxPoolSourceLimit       string = common.GetENVValue("SEBAK_TX_POOL_SOURCE_LIMIT", strconv.Itoa(common.DefaultTxPoolSourceLimit))
+	flagTxPoolTTL               string = common.GetENVValue("SEBAK_TX_POOL_TTL", common.DefaultTxPoolTTL.String())
+	flagProposerSelector        string = common.GetENVValue("SEBAK_PROPOSER_SELECTOR", common.ProposerSelectorSequential)
+	flagTxV2UpgradeHeight       string = common.GetENVValue("SEBAK_TX_V2_ACTIVATION_HEIGHT", "0")
+	flagCertUpgradeHeight       string = common.GetENVValue("SEBAK_CERTIFICATE_ACTIVATION_HEIGHT", "0")
+	flagMissLimitUpgrade        string = common.GetENVValue("SEBAK_PROPOSER_MISS_LIMIT", "0")
+	flagMissLimitUpgradeHeight  string = common.GetENVValue("SEBAK_PROPOSER_MISS_LIMIT_ACTIVATION_HEIGHT", "0")
 
 	flagWatcherMode   bool   = common.GetENVValue("SEBAK_WATCHER_MODE", "0") == "1"
 	flagWatchInterval string = common.GetENVValue("SEBAK_WATCH_INTERVAL", "5s")
@@ -116,6 +123,12 @@ var (
 	operationsInBallotLimit uint64
 	txPoolClientLimit       uint64
 	txPoolNodeLimit         uint64
+	txPoolSourceLimit       uint64
+	txPoolTTL               time.Duration
+	txV2UpgradeHeight       uint64
+	certUpgradeHeight       uint64
+	missLimitUpgrade        uint64
+	missLimitUpgradeHeight  uint64
 	syncCheckPrevBlock      time.Duration
 	jsonrpcbindEndpoint     *common.Endpoint
 	watchInterval           time.Duration
@@ -141,11 +154,17 @@ func init() {
 					cmdcommon.PrintFlagsError(nodeCmd, "--genesis", err)
 				}
 
-				flagName, err := makeGenesisBlock(
+				params, flagName, err := parseNetworkParameters("--genesis-")
+				if err != nil {
+					cmdcommon.PrintFlagsError(nodeCmd, flagName, err)
+				}
+
+				flagName, err = makeGenesisBlock(
 					genesisKP,
 					commonKP,
 					flagNetworkID,
 					balance,
+					params,
 					flagStorageConfigString,
 					log,
 				)
@@ -170,6 +189,11 @@ func init() {
 	flagStorageConfigString = common.GetENVValue("SEBAK_STORAGE", cmdcommon.GetDefaultStoragePath(nodeCmd))
 
 	nodeCmd.Flags().StringVar(&flagGenesis, "genesis", flagGenesis, "performs the 'genesis' command before running node. Syntax: key[,balance]")
+	nodeCmd.Flags().StringVar(&flagFeeSchedule, "genesis-fee-schedule", flagFeeSchedule, "fee schedule for --genesis; see 'genesis --fee-schedule'")
+	nodeCmd.Flags().StringVar(&flagTxV2ActivationHeight, "genesis-tx-v2-activation-height", flagTxV2ActivationHeight, "transaction version 2 activation height for --genesis; see 'genesis --tx-v2-activation-height'")
+	nodeCmd.Flags().StringVar(&flagCertificateActivationHeight, "genesis-certificate-activation-height", flagCertificateActivationHeight, "certificate activation height for --genesis; see 'genesis --certificate-activation-height'")
+	nodeCmd.Flags().StringVar(&flagProposerMissLimit, "genesis-proposer-miss-limit", flagProposerMissLimit, "proposer miss limit for --genesis; see 'genesis --proposer-miss-limit'")
+	nodeCmd.Flags().StringVar(&flagGenesisValidators, "genesis-validators", flagGenesisValidators, "validators for --genesis; see 'genesis --validators'")
 	nodeCmd.Flags().StringVar(&flagKPSecretSeed, "secret-seed", flagKPSecretSeed, "secret seed of this node")
 	nodeCmd.Flags().StringVar(&flagNetworkID, "network-id", flagNetworkID, "network id")
 	nodeCmd.Flags().StringVar(&flagLogLevel, "log-level", flagLogLevel, "log level, {crit, error, warn, info, debug}")
@@ -195,7 +219,14 @@ func init() {
 	nodeCmd.Flags().StringVar(&flagOperationsLimit, "operations-limit", flagOperationsLimit, "operations limit in a transaction")
 	nodeCmd.Flags().StringVar(&flagTransactionsLimit, "transactions-limit", flagTransactionsLimit, "transactions limit in a ballot")
 	nodeCmd.Flags().StringVar(&flagOperationsInBallotLimit, "operations-in-ballot-limit", flagOperationsInBallotLimit, "operations limit in a ballot")
+	nodeCmd.Flags().StringVar(&flagProposerSelector, "proposer-selector", flagProposerSelector, "how to select the proposer, {sequential, random}; every validator must use the same one")
+	nodeCmd.Flags().StringVar(&flagTxV2UpgradeHeight, "tx-v2-activation-height", flagTxV2UpgradeHeight, "block height, from which the transaction version 2 is accepted, if the genesis does not have it (0= not activated); every validator must use the same one")
+	nodeCmd.Flags().StringVar(&flagCertUpgradeHeight, "certificate-activation-height", flagCertUpgradeHeight, "block height, from which the block must have the certificate, if the genesis does not have it (0= not required); every validator must use the same one")
+	nodeCmd.Flags().StringVar(&flagMissLimitUpgrade, "proposer-miss-limit", flagMissLimitUpgrade, "the validator, which missed the last proposals of this number, is not selected as proposer, if the genesis does not have it (0= no limit); every validator must use the same one")
+	nodeCmd.Flags().StringVar(&flagMissLimitUpgradeHeight, "proposer-miss-limit-activation-height", flagMissLimitUpgradeHeight, "block height, from which --proposer-miss-limit is applied (0= from the first block); every validator must use the same one")
 	nodeCmd.Flags().StringVar(&flagTxPoolLimit, "txpool-limit", flagTxPoolLimit, "transaction pool limit: <client-side>[,<node-side>] (0= no limit)")
+	nodeCmd.Flags().StringVar(&flagTxPoolSourceLimit, "txpool-source-limit", flagTxPoolSourceLimit, "maximum number of transactions of one source in transaction pool (0= no limit)")
+	nodeCmd.Flags().StringVar(&flagTxPoolTTL, "txpool-ttl", flagTxPoolTTL, "how long the transaction can stay in transaction pool (0= no limit)")
 	nodeCmd.Flags().Var(
 		&flagRateLimitAPI,
 		"rate-limit-api",
@@ -469,6 +500,11 @@ func parseFlagsNode() {
 		}
 	}
 
+	if txPoolSourceLimit, err = strconv.ParseUint(flagTxPoolSourceLimit, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--txpool-source-limit", err)
+	}
+	txPoolTTL = getTimeDuration(flagTxPoolTTL, common.DefaultTxPoolTTL, "--txpool-ttl")
+
 	if common.UnfreezingPeriod, err = strconv.ParseUint(flagUnfreezingPeriod, 10, 64); err != nil {
 		cmdcommon.PrintFlagsError(nodeCmd, "--unfreezing-period", err)
 	}
@@ -483,6 +519,32 @@ func parseFlagsNode() {
 	syncCheckPrevBlock = getTimeDuration(flagSyncCheckPrevBlockInterval, sync.CheckPrevBlockInterval, "--sync-check-prevblock")
 	watchInterval = getTimeDuration(flagWatchInterval, sync.WatchInterval, "--watch-interval")
 
+	if ok := common.ProposerSelectorNames[flagProposerSelector]; !ok {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-selector", fmt.Errorf("'%s'", flagProposerSelector))
+	}
+
+	if txV2UpgradeHeight, err = strconv.ParseUint(flagTxV2UpgradeHeight, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--tx-v2-activation-height", err)
+	} else if txV2UpgradeHeight != 0 && txV2UpgradeHeight < common.FirstProposedBlockHeight {
+		cmdcommon.PrintFlagsError(nodeCmd, "--tx-v2-activation-height", errors.InvalidActivationHeight)
+	}
+
+	if certUpgradeHeight, err = strconv.ParseUint(flagCertUpgradeHeight, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--certificate-activation-height", err)
+	} else if certUpgradeHeight != 0 && certUpgradeHeight < common.FirstProposedBlockHeight {
+		cmdcommon.PrintFlagsError(nodeCmd, "--certificate-activation-height", errors.InvalidActivationHeight)
+	}
+
+	if missLimitUpgrade, err = strconv.ParseUint(flagMissLimitUpgrade, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-miss-limit", err)
+	}
+
+	if missLimitUpgradeHeight, err = strconv.ParseUint(flagMissLimitUpgradeHeight, 10, 64); err != nil {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-miss-limit-activation-height", err)
+	} else if missLimitUpgradeHeight != 0 && missLimitUpgradeHeight < common.FirstProposedBlockHeight {
+		cmdcommon.PrintFlagsError(nodeCmd, "--proposer-miss-limit-activation-height", errors.InvalidActivationHeight)
+	}
+
 	{
 		if ok := common.HTTPCacheAdapterNames[flagHTTPCacheAdapter]; !ok {
 			cmdcommon.PrintFlagsError(nodeCmd, "--http-cache-adapter", err)
@@ -652,6 +714,13 @@ func parseFlagsNode() {
 	parsedFlags = append(parsedFlags, "\n\toperations-limit", flagOperationsLimit)
 	parsedFlags = append(parsedFlags, "\n\toperations-in-ballot-limit", flagOperationsInBallotLimit)
 	parsedFlags = append(parsedFlags, "\n\ttxpool-limit", flagTxPoolLimit)
+	parsedFlags = append(parsedFlags, "\n\ttxpool-source-limit", flagTxPoolSourceLimit)
+	parsedFlags = append(parsedFlags, "\n\ttxpool-ttl", flagTxPoolTTL)
+	parsedFlags = append(parsedFlags, "\n\tproposer-selector", flagProposerSelector)
+	parsedFlags = append(parsedFlags, "\n\ttx-v2-activation-height", flagTxV2UpgradeHeight)
+	parsedFlags = append(parsedFlags, "\n\tcertificate-activation-height", flagCertUpgradeHeight)
+	parsedFlags = append(parsedFlags, "\n\tproposer-miss-limit", flagMissLimitUpgrade)
+	parsedFlags = append(parsedFlags, "\n\tproposer-miss-limit-activation-height", flagMissLimitUpgradeHeight)
 	parsedFlags = append(parsedFlags, "\n\trate-limit-api", rateLimitRuleAPI)
 	parsedFlags = append(parsedFlags, "\n\trate-limit-node", rateLimitRuleNode)
 	parsedFlags = append(parsedFlags, "\n\thttp-cache-adapter", httpCacheAdapter)
@@ -763,34 +832,41 @@ func runNode() error {
 	initialBalance.Invariant()
 
 	conf := common.Config{
-		TimeoutINIT:            timeoutINIT,
-		TimeoutSIGN:            timeoutSIGN,
-		TimeoutACCEPT:          timeoutACCEPT,
-		TimeoutALLCONFIRM:      timeoutALLCONFIRM,
-		NetworkID:              []byte(flagNetworkID),
-		InitialBalance:         initialBalance,
-		BlockTime:              blockTime,
-		BlockTimeDelta:         blockTimeDelta,
-		TxsLimit:               int(transactionsLimit),
-		OpsLimit:               int(operationsLimit),
-		OpsInBallotLimit:       int(operationsInBallotLimit),
-		RateLimitRuleAPI:       rateLimitRuleAPI,
-		RateLimitRuleNode:      rateLimitRuleNode,
-		HTTPCacheAdapter:       httpCacheAdapter,
-		HTTPCachePoolSize:      httpCachePoolSize,
-		HTTPCacheRedisAddrs:    httpCacheRedisAddrs,
-		CongressAccountAddress: flagCongressAddress,
-		TxPoolClientLimit:      int(txPoolClientLimit),
-		TxPoolNodeLimit:        int(txPoolNodeLimit),
-		JSONRPCEndpoint:        jsonrpcbindEndpoint,
-		WatcherMode:            flagWatcherMode,
-		DiscoveryEndpoints:     discoveryEndpoints,
+		TimeoutINIT:                       timeoutINIT,
+		TimeoutSIGN:                       timeoutSIGN,
+		TimeoutACCEPT:                     timeoutACCEPT,
+		TimeoutALLCONFIRM:                 timeoutALLCONFIRM,
+		NetworkID:                         []byte(flagNetworkID),
+		InitialBalance:                    initialBalance,
+		BlockTime:                         blockTime,
+		BlockTimeDelta:                    blockTimeDelta,
+		TxsLimit:                          int(transactionsLimit),
+		OpsLimit:                          int(operationsLimit),
+		OpsInBallotLimit:                  int(operationsInBallotLimit),
+		ProposerSelector:                  flagProposerSelector,
+		TxV2ActivationHeight:              txV2UpgradeHeight,
+		CertificateActivationHeight:       certUpgradeHeight,
+		ProposerMissLimit:                 missLimitUpgrade,
+		ProposerMissLimitActivationHeight: missLimitUpgradeHeight,
+		RateLimitRuleAPI:                  rateLimitRuleAPI,
+		RateLimitRuleNode:                 rateLimitRuleNode,
+		HTTPCacheAdapter:                  httpCacheAdapter,
+		HTTPCachePoolSize:                 httpCachePoolSize,
+		HTTPCacheRedisAddrs:               httpCacheRedisAddrs,
+		CongressAccountAddress:            flagCongressAddress,
+		TxPoolClientLimit:                 int(txPoolClientLimit),
+		TxPoolNodeLimit:                   int(txPoolNodeLimit),
+		TxPoolSourceLimit:                 int(txPoolSourceLimit),
+		TxPoolTTL:                         txPoolTTL,
+		JSONRPCEndpoint:                   jsonrpcbindEndpoint,
+		WatcherMode:                       flagWatcherMode,
+		DiscoveryEndpoints:                discoveryEndpoints,
 	}
 	connectionManager := network.NewValidatorConnectionManager(localNode, nt, policy, conf)
 
 	tp := transaction.NewPool(conf)
 
-	c, err := sync.NewConfig(localNode, st, nt, connectionManager, tp, conf)
+	c, err := sync.NewConfig(localNode, st, nt, connectionManager, tp, policy, conf)
 	if err != nil {
 		return err
 	}
@@ -17,6 +17,7 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/transaction"
 	"boscoin.io/sebak/lib/transaction/operation"
 )
@@ -29,6 +30,9 @@ var (
 	flagDry           bool
 	flagFreeze        bool
 	flagVerbose       bool
+	flagMemo          string
+	flagMemoType      string = string(transaction.MemoText)
+	flagAsset         string
 )
 
 func init() {
@@ -75,6 +79,29 @@ func init() {
 				cmdcommon.PrintFlagsError(c, "--endpoint", err)
 			}
 
+			// Memo
+			var memo *transaction.Memo
+			if len(flagMemo) > 0 {
+				m := transaction.NewMemo(transaction.MemoType(flagMemoType), flagMemo)
+				if err = m.IsWellFormed(); err != nil {
+					cmdcommon.PrintFlagsError(c, "--memo", err)
+				}
+				memo = &m
+			}
+
+			// Asset
+			var asset *common.Asset
+			if len(flagAsset) > 0 {
+				if flagCreateAccount || flagFreeze {
+					cmdcommon.PrintFlagsError(c, "--asset", fmt.Errorf("--asset can not be used with --create or --freeze"))
+				}
+				a, err := common.ParseAsset(flagAsset)
+				if err != nil {
+					cmdcommon.PrintFlagsError(c, "--asset", err)
+				}
+				asset = &a
+			}
+
 			// TODO: Validate input transaction (does the sender have enough money?)
 
 			// At the moment this is a rather crude implementation: There is no support for pooling of transaction,
@@ -99,28 +126,40 @@ func init() {
 				fmt.Println("Account before transaction: ", senderAccount)
 			}
 
-			// Check that account's balance is enough before sending the transaction
-			{
-				fee := common.BaseFee
-				if flagFreeze {
-					fee = common.FrozenFee
-				}
-				_, err = senderAccount.GetBalance().Sub(amount + fee)
-				if err != nil {
-					fmt.Printf("Attempting to draft %v GON (+ %v fees), but sender account only have %v GON\n",
-						amount, fee, senderAccount.GetBalance())
-					os.Exit(1)
-				}
+			var nodeInfo node.NodeInfo
+			if nodeInfo, err = getNodeInfo(client); err != nil {
+				log.Fatal("Could not fetch node info: ", err)
+				os.Exit(1)
 			}
+			feeSchedule := nodeInfo.Policy.FeeSchedule
 
 			// TODO: Validate that the account doesn't already exists
 			if flagFreeze {
 				tx = MakeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, true)
 			} else if flagCreateAccount {
 				tx = MakeTransactionCreateAccount(sender, receiver, amount, senderAccount.SequenceID, false)
+			} else if asset != nil {
+				tx = MakeTransactionAssetPayment(sender, receiver, *asset, amount, senderAccount.SequenceID)
 			} else {
 				tx = MakeTransactionPayment(sender, receiver, amount, senderAccount.SequenceID)
 			}
+			tx.H.Version = negotiateTransactionVersion(nodeInfo)
+			if memo != nil && tx.IsValidVersion(common.TransactionVersionV1) {
+				fmt.Println("The node does not accept the transaction version 2 yet, which is required by --memo")
+				os.Exit(1)
+			}
+			tx.B.Memo = memo
+			tx.B.Fee = tx.MinimumFee(feeSchedule)
+
+			// Check that account's balance is enough before sending the transaction
+			{
+				_, err = senderAccount.GetBalance().Sub(tx.TotalAmount(true))
+				if err != nil {
+					fmt.Printf("Attempting to draft %v GON (+ %v fees), but sender account only have %v GON\n",
+						amount, tx.B.Fee, senderAccount.GetBalance())
+					os.Exit(1)
+				}
+			}
 
 			tx.Sign(sender, []byte(flagNetworkID))
 
@@ -151,6 +190,9 @@ func init() {
 	PaymentCmd.Flags().BoolVar(&flagFreeze, "freeze", flagFreeze, "When present, the payment is a frozen account creation. Imply --create.")
 	PaymentCmd.Flags().BoolVar(&flagDry, "dry-run", flagDry, "Print the transaction instead of sending it")
 	PaymentCmd.Flags().BoolVar(&flagVerbose, "verbose", flagVerbose, "Print extra data (transaction sent, before/after balance...)")
+	PaymentCmd.Flags().StringVar(&flagMemo, "memo", flagMemo, "Memo of the transaction, like the reference of deposit")
+	PaymentCmd.Flags().StringVar(&flagMemoType, "memo-type", flagMemoType, "Type of --memo: 'text', 'id' or 'hash'")
+	PaymentCmd.Flags().StringVar(&flagAsset, "asset", flagAsset, "Send <amount> of the asset, '<code>:<issuer address>', instead of BOSCoin")
 }
 
 ///
@@ -250,6 +292,48 @@ func MakeTransactionPayment(kpSource keypair.KP, kpDest keypair.KP, amount commo
 	return tx
 }
 
+///
+/// Make a full transaction, with a single asset payment operation in it
+///
+/// Params:
+///   kpSource = Sender's keypair.Full seed/address
+///   kpDest   = Receiver's keypair.FromAddress address
+///   asset    = Asset to send, the receiver must trust it
+///   amount   = Amount of asset to send
+///   seqid    = SequenceID of the last transaction
+///
+/// Returns:
+///  `sebak.Transaction` = The generated `Transaction` to do an asset payment
+///
+func MakeTransactionAssetPayment(kpSource keypair.KP, kpDest keypair.KP, asset common.Asset, amount common.Amount, seqid uint64) transaction.Transaction {
+	opb := operation.NewAssetPayment(kpDest.Address(), asset, amount)
+
+	op := operation.Operation{
+		H: operation.Header{
+			Type: operation.TypeAssetPayment,
+		},
+		B: opb,
+	}
+
+	txBody := transaction.Body{
+		Source:     kpSource.Address(),
+		Fee:        common.BaseFee,
+		SequenceID: seqid,
+		Operations: []operation.Operation{op},
+	}
+
+	tx := transaction.Transaction{
+		H: transaction.Header{
+			Version: common.TransactionVersionV1,
+			Created: common.NowISO8601(),
+			Hash:    txBody.MakeHashString(),
+		},
+		B: txBody,
+	}
+
+	return tx
+}
+
 ///
 /// Get the BlockAccount of the sender
 ///
@@ -277,3 +361,43 @@ func getSenderDetails(conn *network.HTTP2NetworkClient, sender keypair.KP) (bloc
 	err = json.Unmarshal(retBody, &ba)
 	return ba, err
 }
+
+///
+/// Get the `node.NodeInfo` of the node
+///
+func getNodeInfo(conn *network.HTTP2NetworkClient) (nodeInfo node.NodeInfo, err error) {
+	var retBody []byte
+	if retBody, err = conn.GetNodeInfo(); err != nil {
+		return
+	}
+
+	return node.NewNodeInfoFromJSON(retBody)
+}
+
+///
+/// Get the `operation.FeeSchedule` of network from the node info
+///
+func getFeeSchedule(conn *network.HTTP2NetworkClient) (fs operation.FeeSchedule, err error) {
+	var nodeInfo node.NodeInfo
+	if nodeInfo, err = getNodeInfo(conn); err != nil {
+		return
+	}
+
+	return nodeInfo.Policy.FeeSchedule, nil
+}
+
+///
+/// Choose the latest transaction version, which both of the node and the
+/// wallet know. The node, which does not advertise the versions, accepts
+/// only `common.TransactionVersionV1`.
+///
+func negotiateTransactionVersion(nodeInfo node.NodeInfo) string {
+	version := common.TransactionVersionV1
+	for _, v := range nodeInfo.AcceptedTransactionVersions() {
+		if common.IsKnownTransactionVersion(v) {
+			version = v
+		}
+	}
+
+	return version
+}
@@ -66,7 +66,7 @@ func init() {
 				os.Exit(1)
 			}
 
-			if !senderAccount.IsFrozen() {
+			if !senderAccount.IsFrozen() || senderAccount.IsVesting() {
 				fmt.Printf("Account is not frozen account")
 				os.Exit(1)
 			}
@@ -83,7 +83,14 @@ func init() {
 				}
 			}
 
+			var feeSchedule operation.FeeSchedule
+			if feeSchedule, err = getFeeSchedule(client); err != nil {
+				log.Fatal("Could not fetch fee schedule: ", err)
+				os.Exit(1)
+			}
+
 			tx = makeTransactionUnfreezingRequest(sender, senderAccount.SequenceID)
+			tx.B.Fee = tx.MinimumFee(feeSchedule)
 
 			tx.Sign(sender, []byte(flagNetworkID))
 
@@ -0,0 +1,113 @@
+package ballot
+
+import (
+	"encoding/json"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// NewBlockCertificate makes `block.BlockCertificate` of the block from the
+// ACCEPT ballots, which confirmed the block.
+func NewBlockCertificate(blk block.Block, ballots []Ballot) (bc block.BlockCertificate, err error) {
+	var messages []json.RawMessage
+	for _, b := range ballots {
+		var encoded []byte
+		if encoded, err = b.Serialize(); err != nil {
+			return
+		}
+		messages = append(messages, encoded)
+	}
+
+	bc = block.NewBlockCertificate(blk, messages)
+	return
+}
+
+// BlockCertificateBallots decodes the ballots of `block.BlockCertificate`.
+func BlockCertificateBallots(bc block.BlockCertificate) (ballots []Ballot, err error) {
+	for _, message := range bc.Ballots {
+		var b Ballot
+		if b, err = NewBallotFromJSON(message); err != nil {
+			return
+		}
+		ballots = append(ballots, b)
+	}
+
+	return
+}
+
+// VerifyBlockCertificate checks that `block.BlockCertificate` confirms the
+// block; every ballot must be the YES ACCEPT ballot for the block, which is
+// signed by one of the validators, and the number of ballots must reach the
+// threshold.
+func VerifyBlockCertificate(bc block.BlockCertificate, blk block.Block, networkID []byte, validators []string, threshold int) error {
+	if bc.Hash != blk.Hash || bc.Height != blk.Height {
+		return errors.InvalidBlockCertificate
+	}
+
+	ballots, err := BlockCertificateBallots(bc)
+	if err != nil {
+		return errors.InvalidBlockCertificate
+	}
+
+	isValidator := map[string]bool{}
+	for _, address := range validators {
+		isValidator[address] = true
+	}
+
+	signed := map[string]bool{}
+	for _, b := range ballots {
+		if !isValidator[b.Source()] || signed[b.Source()] {
+			return errors.InvalidBlockCertificate
+		}
+		if b.State() != StateACCEPT || b.Vote() != voting.YES {
+			return errors.InvalidBlockCertificate
+		}
+		if b.H.Hash != b.B.MakeHashString() {
+			return errors.InvalidBlockCertificate
+		}
+		if b.VerifySource(networkID) != nil || b.VerifyProposer(networkID) != nil {
+			return errors.InvalidBlockCertificate
+		}
+		if !isBallotOfBlock(b, blk) {
+			return errors.InvalidBlockCertificate
+		}
+
+		signed[b.Source()] = true
+	}
+
+	if threshold < 1 || len(signed) < threshold {
+		return errors.InvalidBlockCertificate
+	}
+
+	return nil
+}
+
+// isBallotOfBlock checks the block was made from the ballot like
+// `runner.finishBallot`.
+func isBallotOfBlock(b Ballot, blk block.Block) bool {
+	basis := b.VotingBasis()
+	if basis.Height+1 != blk.Height || basis.Round != blk.Round || basis.BlockHash != blk.PrevBlockHash {
+		return false
+	}
+	if b.Proposer() != blk.Proposer || b.ProposerConfirmed() != blk.ProposedTime {
+		return false
+	}
+	if b.ProposerTransaction().GetHash() != blk.ProposerTransaction {
+		return false
+	}
+	if basis.TotalTxs+uint64(len(b.Transactions())+1) != blk.TotalTxs {
+		return false
+	}
+	if len(b.Transactions()) != len(blk.Transactions) {
+		return false
+	}
+	for i, hash := range b.Transactions() {
+		if hash != blk.Transactions[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,103 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestBlockCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	proposerKP := keypair.Random()
+	kps := []*keypair.Full{proposerKP, keypair.Random(), keypair.Random()}
+	var validators []string
+	for _, kp := range kps {
+		validators = append(validators, kp.Address())
+	}
+
+	basis := voting.Basis{Round: 0, Height: 1, BlockHash: "hahaha", TotalTxs: 1}
+	tx := transaction.MakeTransactionCreateAccount(conf.NetworkID, keypair.Random(), keypair.Random().Address(), common.BaseReserve)
+
+	proposed := NewBallot(proposerKP.Address(), proposerKP.Address(), basis, []string{tx.GetHash()})
+	commonAccount := block.NewBlockAccount(keypair.Random().Address(), 0)
+	opi, _ := NewInflationFromBallot(*proposed, commonAccount.Address, common.BaseReserve)
+	opc, _ := NewCollectTxFeeFromBallot(*proposed, commonAccount.Address, tx)
+	ptx, _ := NewProposerTransactionFromBallot(*proposed, opc, opi)
+	proposed.SetProposerTransaction(ptx)
+	proposed.Sign(proposerKP, conf.NetworkID)
+
+	makeACCEPT := func(kp *keypair.Full, vote voting.Hole) Ballot {
+		b := *proposed
+		b.SetSource(kp.Address())
+		b.SetVote(StateACCEPT, vote)
+		b.Sign(kp, conf.NetworkID)
+		return b
+	}
+
+	r := basis
+	r.Height++
+	r.TotalTxs += 2
+	blk := *block.NewBlock(proposerKP.Address(), r, ptx.GetHash(), []string{tx.GetHash()}, proposed.ProposerConfirmed())
+
+	var ballots []Ballot
+	for _, kp := range kps {
+		ballots = append(ballots, makeACCEPT(kp, voting.YES))
+	}
+
+	{ // valid
+		bc, err := NewBlockCertificate(blk, ballots)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(bc.Ballots))
+		require.NoError(t, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 3))
+
+		decoded, err := BlockCertificateBallots(bc)
+		require.NoError(t, err)
+		require.Equal(t, ballots[1].GetHash(), decoded[1].GetHash())
+	}
+
+	{ // not enough ballots
+		bc, _ := NewBlockCertificate(blk, ballots[:2])
+		require.NoError(t, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 2))
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 3))
+	}
+
+	{ // duplicated source
+		bc, _ := NewBlockCertificate(blk, []Ballot{ballots[0], ballots[1], ballots[1]})
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 2))
+	}
+
+	{ // unknown validator
+		bc, _ := NewBlockCertificate(blk, []Ballot{ballots[0], ballots[1], makeACCEPT(keypair.Random(), voting.YES)})
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 2))
+	}
+
+	{ // NO ballot
+		bc, _ := NewBlockCertificate(blk, []Ballot{ballots[0], makeACCEPT(kps[1], voting.NO)})
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 2))
+	}
+
+	{ // wrong signature
+		wrong := makeACCEPT(kps[2], voting.YES)
+		wrong.H.Signature = ballots[1].H.Signature
+		bc, _ := NewBlockCertificate(blk, []Ballot{ballots[0], wrong})
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, blk, conf.NetworkID, validators, 2))
+	}
+
+	{ // ballot of the other block
+		other := *block.NewBlock(proposerKP.Address(), r, ptx.GetHash(), []string{}, proposed.ProposerConfirmed())
+		bc, _ := NewBlockCertificate(other, ballots)
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, other, conf.NetworkID, validators, 2))
+
+		// certificate of the other block
+		bc, _ = NewBlockCertificate(blk, ballots)
+		require.Equal(t, errors.InvalidBlockCertificate, VerifyBlockCertificate(bc, other, conf.NetworkID, validators, 2))
+	}
+}
@@ -0,0 +1,89 @@
+package ballot
+
+import (
+	"encoding/json"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/voting"
+)
+
+// IsConflicting checks whether the two ballots are signed by the same source
+// for the same `voting.Basis` and state, but have the different proposals or
+// the different votes. The honest node sends only one ballot for each state,
+// so the conflicting ballots prove the misbehavior of the source.
+//
+// The `voting.EXP` ballot does not conflict with the other ballots; the
+// honest node, which already voted, also sends `voting.EXP` when the state is
+// expired, and `voting.EXP` does not agree to any proposal.
+func IsConflicting(a, b Ballot) bool {
+	if a.Source() != b.Source() || a.State() != b.State() || a.VotingBasis() != b.VotingBasis() {
+		return false
+	}
+	if a.GetHash() == b.GetHash() {
+		return false
+	}
+	if a.Vote() == voting.EXP || b.Vote() == voting.EXP {
+		return false
+	}
+
+	if a.Vote() != b.Vote() {
+		return true
+	}
+
+	return string(common.MustMakeObjectHash(a.B.Proposed)) != string(common.MustMakeObjectHash(b.B.Proposed))
+}
+
+// NewEvidence makes `block.Evidence` from the conflicting ballots.
+func NewEvidence(a, b Ballot) (e block.Evidence, err error) {
+	if !IsConflicting(a, b) {
+		err = errors.InvalidEvidence
+		return
+	}
+
+	var messages []json.RawMessage
+	for _, i := range []Ballot{a, b} {
+		var encoded []byte
+		if encoded, err = i.Serialize(); err != nil {
+			return
+		}
+		messages = append(messages, encoded)
+	}
+
+	basis := a.VotingBasis()
+	e = block.NewEvidence(a.Source(), basis.Height, basis.Round, a.State().String(), messages)
+	return
+}
+
+// VerifyEvidence checks that `block.Evidence` has the two conflicting ballots,
+// which are signed by the source of evidence.
+func VerifyEvidence(e block.Evidence, networkID []byte) error {
+	if len(e.Ballots) != 2 {
+		return errors.InvalidEvidence
+	}
+
+	var ballots []Ballot
+	for _, message := range e.Ballots {
+		b, err := NewBallotFromJSON(message)
+		if err != nil {
+			return errors.InvalidEvidence
+		}
+		if b.Source() != e.Source || b.State().String() != e.State {
+			return errors.InvalidEvidence
+		}
+		if b.VotingBasis().Height != e.Height || b.VotingBasis().Round != e.Round {
+			return errors.InvalidEvidence
+		}
+		if b.H.Hash != b.B.MakeHashString() || b.VerifySource(networkID) != nil {
+			return errors.InvalidEvidence
+		}
+		ballots = append(ballots, b)
+	}
+
+	if !IsConflicting(ballots[0], ballots[1]) {
+		return errors.InvalidEvidence
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestEvidence(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	proposerKP := keypair.Random()
+	kp := keypair.Random()
+	basis := voting.Basis{Round: 0, Height: 1, BlockHash: "hahaha", TotalTxs: 1}
+
+	makeBallot := func(transactions []string, state State, vote voting.Hole) Ballot {
+		proposed := NewBallot(proposerKP.Address(), proposerKP.Address(), basis, transactions)
+		proposed.Sign(proposerKP, conf.NetworkID)
+
+		b := *proposed
+		b.SetSource(kp.Address())
+		b.SetVote(state, vote)
+		b.Sign(kp, conf.NetworkID)
+		return b
+	}
+
+	a := makeBallot([]string{}, StateSIGN, voting.YES)
+
+	{ // same ballot
+		require.False(t, IsConflicting(a, a))
+	}
+
+	{ // different state
+		require.False(t, IsConflicting(a, makeBallot([]string{"tx"}, StateACCEPT, voting.YES)))
+	}
+
+	{ // different source
+		b := a
+		b.Sign(proposerKP, conf.NetworkID)
+		require.False(t, IsConflicting(a, b))
+	}
+
+	{ // different vote
+		b := a
+		b.SetVote(StateSIGN, voting.NO)
+		b.Sign(kp, conf.NetworkID)
+		require.True(t, IsConflicting(a, b))
+	}
+
+	{ // expired after vote
+		b := a
+		b.SetVote(StateSIGN, voting.EXP)
+		b.Sign(kp, conf.NetworkID)
+		require.False(t, IsConflicting(a, b))
+		require.False(t, IsConflicting(b, a))
+
+		_, err := NewEvidence(a, b)
+		require.Equal(t, errors.InvalidEvidence, err)
+	}
+
+	b := makeBallot([]string{"tx"}, StateSIGN, voting.YES)
+	{ // different proposal
+		require.True(t, IsConflicting(a, b))
+	}
+
+	e, err := NewEvidence(a, b)
+	require.NoError(t, err)
+	require.Equal(t, kp.Address(), e.Source)
+	require.Equal(t, basis.Height, e.Height)
+	require.Equal(t, basis.Round, e.Round)
+	require.Equal(t, StateSIGN.String(), e.State)
+	require.NoError(t, VerifyEvidence(e, conf.NetworkID))
+
+	{ // not conflicting
+		_, err := NewEvidence(a, a)
+		require.Equal(t, errors.InvalidEvidence, err)
+	}
+
+	{ // wrong network
+		require.Equal(t, errors.InvalidEvidence, VerifyEvidence(e, []byte("wrong-network")))
+	}
+
+	{ // forged ballot
+		forged := b
+		forged.B.Vote = voting.NO
+		e, _ := NewEvidence(a, forged)
+		require.Equal(t, errors.InvalidEvidence, VerifyEvidence(e, conf.NetworkID))
+	}
+
+	{ // wrong source
+		forged := e
+		forged.Source = proposerKP.Address()
+		require.Equal(t, errors.InvalidEvidence, VerifyEvidence(forged, conf.NetworkID))
+	}
+}
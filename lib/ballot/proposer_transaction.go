@@ -10,8 +10,9 @@ import (
 )
 
 var TypesProposerTransaction map[operation.OperationType]struct{} = map[operation.OperationType]struct{}{
-	operation.TypeCollectTxFee: struct{}{},
-	operation.TypeInflation:    struct{}{},
+	operation.TypeCollectTxFee:            struct{}{},
+	operation.TypeInflation:               struct{}{},
+	operation.TypeScheduledPaymentExecute: struct{}{},
 }
 
 type ProposerTransaction struct {
@@ -20,11 +21,10 @@ type ProposerTransaction struct {
 
 func NewProposerTransaction(proposer string, ops ...operation.Operation) (ptx ProposerTransaction, err error) {
 	var tx transaction.Transaction
-	tx, err = transaction.NewTransaction(proposer, 0, ops...)
+	tx, err = transaction.NewTransaction(proposer, 0, operation.FeeSchedule{}, ops...)
 	if err != nil {
 		return
 	}
-	tx.B.Fee = 0
 	tx.H.Hash = tx.B.MakeHashString()
 
 	ptx = ProposerTransaction{Transaction: tx}
@@ -71,7 +71,10 @@ func NewInflationFromBallot(blt Ballot, commonAccount string, initialBalance com
 	return
 }
 
-func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, opi operation.Inflation) (ptx ProposerTransaction, err error) {
+// NewProposerTransactionFromBallot makes the proposer transaction of ballot.
+// `payments` are the scheduled payments, which are due at the block of
+// ballot.
+func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, opi operation.Inflation, payments ...operation.ScheduledPaymentExecute) (ptx ProposerTransaction, err error) {
 	var ops []operation.Operation
 
 	var op operation.Operation
@@ -89,6 +92,13 @@ func NewProposerTransactionFromBallot(blt Ballot, opc operation.CollectTxFee, op
 		ops = append(ops, op)
 	}
 
+	for _, opb := range payments { // OperationScheduledPaymentExecute
+		if op, err = operation.NewOperation(opb); err != nil {
+			return
+		}
+		ops = append(ops, op)
+	}
+
 	ptx, err = NewProposerTransaction(blt.Proposer(), ops...)
 
 	return
@@ -229,12 +239,25 @@ func (p ProposerTransaction) Inflation() (opb operation.Inflation, err error) {
 	return
 }
 
+// ScheduledPayments returns the scheduled payments, which are executed by the
+// proposer transaction, in order.
+func (p ProposerTransaction) ScheduledPayments() []operation.ScheduledPaymentExecute {
+	var payments []operation.ScheduledPaymentExecute
+	for _, op := range p.B.Operations {
+		if opb, ok := op.B.(operation.ScheduledPaymentExecute); ok {
+			payments = append(payments, opb)
+		}
+	}
+
+	return payments
+}
+
 func (p *ProposerTransaction) UnmarshalJSON(b []byte) error {
 	var t transaction.Transaction
 	if err := json.Unmarshal(b, &t); err != nil {
 		return err
 	}
-	t.H.Hash = t.B.MakeHashString()
+	t.H.Hash = t.MakeHashString()
 
 	*p = ProposerTransaction{Transaction: t}
 
@@ -251,16 +274,29 @@ func CheckProposerTransactionFee(c common.Checker, args ...interface{}) (err err
 	return
 }
 
+// CheckProposerTransactionOperationTypes checks the proposer transaction has
+// one `CollectTxFee` and one `Inflation`, and the `ScheduledPaymentExecute`
+// upto `common.MaxScheduledPaymentsInBlock`.
 func CheckProposerTransactionOperationTypes(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*transaction.Checker)
 
-	if len(checker.Transaction.B.Operations) != 2 {
+	var ops []operation.Operation
+	var payments int
+	for _, op := range checker.Transaction.B.Operations {
+		if op.H.Type == operation.TypeScheduledPaymentExecute {
+			payments++
+			continue
+		}
+		ops = append(ops, op)
+	}
+
+	if len(ops) != 2 || payments > common.MaxScheduledPaymentsInBlock {
 		err = errors.InvalidProposerTransaction
 		return
 	}
 
 	var foundTypes []string
-	for _, op := range checker.Transaction.B.Operations {
+	for _, op := range ops {
 		if _, found := TypesProposerTransaction[op.H.Type]; !found {
 			err = errors.InvalidOperation
 			return
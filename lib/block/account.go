@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/storage"
 )
 
@@ -26,6 +27,21 @@ type BlockAccount struct {
 	Linked   string      `json:"linked"`
 	CodeHash []byte      `json:"code_hash"`
 	RootHash common.Hash `json:"root_hash"`
+	// Signers and Thresholds are set by `operation.ManageSigners`. If no
+	// signers are set, only the account itself can sign.
+	Signers    []common.Signer   `json:"signers,omitempty"`
+	Thresholds common.Thresholds `json:"thresholds"`
+	// DataEntries is the number of data entries set by
+	// `operation.ManageData`.
+	DataEntries uint64 `json:"data_entries,omitempty"`
+	// Vesting is set for the account created by
+	// `operation.CreateVestingAccount`.
+	Vesting *common.Vesting `json:"vesting,omitempty"`
+	// Trustlines is the number of trustlines set by `operation.Trust`.
+	Trustlines uint64 `json:"trustlines,omitempty"`
+	// Flags and AllowList are set by `operation.SetOptions`.
+	Flags     common.AccountFlags `json:"flags,omitempty"`
+	AllowList []string            `json:"allow_list,omitempty"`
 }
 
 func NewBlockAccount(address string, balance common.Amount) *BlockAccount {
@@ -45,6 +61,75 @@ func (b *BlockAccount) IsFrozen() bool {
 	return b.Linked != ""
 }
 
+// IsVesting returns true if the account has the vesting schedule.
+func (b *BlockAccount) IsVesting() bool {
+	return b.Vesting != nil
+}
+
+// Spendable returns the balance, which is vested at the block of height. For
+// the account without vesting, it is the whole balance.
+func (b *BlockAccount) Spendable(height uint64) common.Amount {
+	if !b.IsVesting() {
+		return b.Balance
+	}
+
+	locked := b.Vesting.Locked(height)
+	if b.Balance < locked {
+		return 0
+	}
+
+	return b.Balance - locked
+}
+
+// HasSigners returns true if the signers of account are set.
+func (b *BlockAccount) HasSigners() bool {
+	return len(b.Signers) > 0
+}
+
+// GetSigners returns the signers of account. Without the signers set, the
+// account itself is the only signer.
+func (b *BlockAccount) GetSigners() []common.Signer {
+	if !b.HasSigners() {
+		return []common.Signer{{Address: b.Address, Weight: 1}}
+	}
+
+	return b.Signers
+}
+
+// SetSigners replaces the signers and thresholds of account.
+func (b *BlockAccount) SetSigners(signers []common.Signer, thresholds common.Thresholds) {
+	b.Signers = signers
+	b.Thresholds = thresholds
+}
+
+// SetOptions replaces the flags and allow list of account.
+func (b *BlockAccount) SetOptions(flags common.AccountFlags, allowList []string) {
+	b.Flags = flags
+	b.AllowList = allowList
+}
+
+// IsAllowed returns true if the address is the account itself or it is in the
+// allow list of account.
+func (b *BlockAccount) IsAllowed(address string) bool {
+	if address == b.Address {
+		return true
+	}
+	for _, a := range b.AllowList {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reserve returns the amount, which the balance must keep for the data
+// entries and trustlines. Each entry and trustline takes `common.BaseReserve`
+// in addition to the reserve of account itself.
+func (b *BlockAccount) Reserve() common.Amount {
+	return common.BaseReserve.MustMult(int(b.DataEntries+b.Trustlines) + 1)
+}
+
 func (b *BlockAccount) IncreaseSequenceID() {
 	b.SequenceID += 1
 }
@@ -68,6 +153,16 @@ func (b *BlockAccount) Save(st *storage.LevelDBBackend) (err error) {
 		err = st.New(key, b)
 		createdKey := GetBlockAccountCreatedKey(common.GetUniqueIDFromUUID())
 		err = st.New(createdKey, b.Address)
+		if err != nil {
+			return err
+		}
+
+		var keys blockAccountKeys
+		if keys, err = getBlockAccountKeys(st, b.Address); err != nil {
+			return err
+		}
+		keys.Created = createdKey
+		err = keys.save(st, b.Address)
 	}
 	if err != nil {
 		return err
@@ -83,6 +178,121 @@ func (b *BlockAccount) Save(st *storage.LevelDBBackend) (err error) {
 	return
 }
 
+// Remove deletes the account, it's data entries and it's 'created' index. The
+// `BlockAccountSequenceID` of account is kept with zero balance, so the
+// history of account still can be found.
+func (b *BlockAccount) Remove(st *storage.LevelDBBackend) (err error) {
+	if err = st.Remove(GetBlockAccountKey(b.Address)); err != nil {
+		return
+	}
+
+	var keys blockAccountKeys
+	if keys, err = getBlockAccountKeys(st, b.Address); err != nil {
+		return
+	}
+
+	// the data entries, which were saved before their keys are tracked, are
+	// found by the iterator; they are already committed.
+	dataKeys := append([]string{}, keys.Data...)
+	iterFunc, closeFunc := GetBlockAccountDataByAddress(st, b.Address, nil)
+	for {
+		data, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		dataKeys = append(dataKeys, GetBlockAccountDataKey(data.Address, data.Key))
+	}
+	closeFunc()
+
+	createdKey := keys.Created
+	if len(createdKey) < 1 {
+		createdKey = findBlockAccountCreatedKey(st, b.Address)
+	}
+
+	for _, key := range append(dataKeys, createdKey, getBlockAccountKeysKey(b.Address)) {
+		if err = removeIfExists(st, key); err != nil {
+			return
+		}
+	}
+
+	bac := BlockAccountSequenceID{
+		SequenceID: b.SequenceID,
+		Address:    b.Address,
+		Balance:    common.Amount(0),
+	}
+	err = bac.Save(st)
+
+	return
+}
+
+// blockAccountKeys keeps the keys of the records, which are removed with the
+// account by `BlockAccount.Remove`. They are tracked, because the iterator
+// does not see the records, which are not committed yet in the batch storage.
+//
+// models
+//  * 'address'
+// 	- 'bak-<BlockAccount.Address>': `blockAccountKeys`
+type blockAccountKeys struct {
+	Created string   `json:"created"` // key of 'created' index
+	Data    []string `json:"data"`    // keys of `BlockAccountData`
+}
+
+func getBlockAccountKeysKey(address string) string {
+	return fmt.Sprintf("%s%s", common.BlockAccountKeysPrefix, address)
+}
+
+func getBlockAccountKeys(st *storage.LevelDBBackend, address string) (keys blockAccountKeys, err error) {
+	if err = st.Get(getBlockAccountKeysKey(address), &keys); err == errors.StorageRecordDoesNotExist {
+		err = nil
+	}
+
+	return
+}
+
+func (k blockAccountKeys) save(st *storage.LevelDBBackend, address string) (err error) {
+	key := getBlockAccountKeysKey(address)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		return st.Set(key, k)
+	}
+	return st.New(key, k)
+}
+
+// findBlockAccountCreatedKey finds the key of 'created' index of the account,
+// which was saved before the key is tracked by `blockAccountKeys`.
+func findBlockAccountCreatedKey(st *storage.LevelDBBackend, address string) string {
+	iterFunc, closeFunc := GetBlockAccountAddressesByCreated(st, nil)
+	defer closeFunc()
+
+	for {
+		a, hasNext, cursor := iterFunc()
+		if !hasNext {
+			return ""
+		}
+		if a == address {
+			return string(cursor)
+		}
+	}
+}
+
+func removeIfExists(st *storage.LevelDBBackend, key string) (err error) {
+	if len(key) < 1 {
+		return
+	}
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil || !exists {
+		return
+	}
+
+	return st.Remove(key)
+}
+
 func GetBlockAccountKey(address string) string {
 	return fmt.Sprintf("%s%s", common.BlockAccountPrefixAddress, address)
 }
@@ -95,6 +305,20 @@ func ExistsBlockAccount(st *storage.LevelDBBackend, address string) (exists bool
 	return st.Has(GetBlockAccountKey(address))
 }
 
+// ExistedBlockAccount checks the account exists or it existed before it was
+// merged by `operation.AccountMerge`.
+func ExistedBlockAccount(st *storage.LevelDBBackend, address string) (existed bool, err error) {
+	if existed, err = ExistsBlockAccount(st, address); err != nil || existed {
+		return
+	}
+
+	iterFunc, closeFunc := GetBlockAccountSequenceIDByAddress(st, address, storage.NewDefaultListOptions(false, nil, 1))
+	defer closeFunc()
+	_, existed, _ = iterFunc()
+
+	return
+}
+
 func GetBlockAccount(st *storage.LevelDBBackend, address string) (b *BlockAccount, err error) {
 	if err = st.Get(GetBlockAccountKey(address), &b); err != nil {
 		return
@@ -124,19 +348,21 @@ func GetBlockAccountsByCreated(st *storage.LevelDBBackend, options storage.ListO
 	iterFunc, closeFunc := GetBlockAccountAddressesByCreated(st, options)
 
 	return (func() (*BlockAccount, bool, []byte) {
-			address, hasNext, cursor := iterFunc()
-			if !hasNext {
-				return nil, false, cursor
-			}
-
-			ba, err := GetBlockAccount(st, address)
-
-			// TODO if err != nil, stopping iteration is right? how about just
-			// ignoring the missing one?
-			if err != nil {
-				return nil, false, cursor
+			for {
+				address, hasNext, cursor := iterFunc()
+				if !hasNext {
+					return nil, false, cursor
+				}
+
+				ba, err := GetBlockAccount(st, address)
+				if err == errors.StorageRecordDoesNotExist {
+					// the merged account is skipped
+					continue
+				} else if err != nil {
+					return nil, false, cursor
+				}
+				return ba, hasNext, cursor
 			}
-			return ba, hasNext, cursor
 		}), (func() {
 			closeFunc()
 		})
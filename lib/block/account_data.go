@@ -0,0 +1,116 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockAccountData is the data entry of account, which is set by
+// `operation.ManageData`.
+//
+// models
+//  * 'address' and 'key'
+// 	- 'bad-<BlockAccountData.Address>-<BlockAccountData.Key>': `BlockAccountData`
+type BlockAccountData struct {
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+func NewBlockAccountData(address, key, value string) *BlockAccountData {
+	return &BlockAccountData{
+		Address: address,
+		Key:     key,
+		Value:   value,
+	}
+}
+
+func GetBlockAccountDataKey(address, key string) string {
+	return fmt.Sprintf("%s%s", GetBlockAccountDataKeyPrefix(address), key)
+}
+
+func GetBlockAccountDataKeyPrefix(address string) string {
+	return fmt.Sprintf("%s%s-", common.BlockAccountDataPrefix, address)
+}
+
+func (b *BlockAccountData) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+func (b *BlockAccountData) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockAccountDataKey(b.Address, b.Key)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		return st.Set(key, b)
+	}
+	if err = st.New(key, b); err != nil {
+		return
+	}
+
+	var keys blockAccountKeys
+	if keys, err = getBlockAccountKeys(st, b.Address); err != nil {
+		return
+	}
+	keys.Data = append(keys.Data, key)
+
+	return keys.save(st, b.Address)
+}
+
+func (b *BlockAccountData) Remove(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockAccountDataKey(b.Address, b.Key)
+	if err = st.Remove(key); err != nil {
+		return
+	}
+
+	var keys blockAccountKeys
+	if keys, err = getBlockAccountKeys(st, b.Address); err != nil {
+		return
+	}
+	for i, k := range keys.Data {
+		if k == key {
+			keys.Data = append(keys.Data[:i], keys.Data[i+1:]...)
+			return keys.save(st, b.Address)
+		}
+	}
+
+	return
+}
+
+func ExistsBlockAccountData(st *storage.LevelDBBackend, address, key string) (bool, error) {
+	return st.Has(GetBlockAccountDataKey(address, key))
+}
+
+func GetBlockAccountData(st *storage.LevelDBBackend, address, key string) (b *BlockAccountData, err error) {
+	if err = st.Get(GetBlockAccountDataKey(address, key), &b); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetBlockAccountDataByAddress returns the data entries of account ordered by
+// key.
+func GetBlockAccountDataByAddress(st *storage.LevelDBBackend, address string, options storage.ListOptions) (func() (*BlockAccountData, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockAccountDataKeyPrefix(address), options)
+
+	return (func() (*BlockAccountData, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockAccountData
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
@@ -0,0 +1,56 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestBlockAccountData(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	ba := TestMakeBlockAccount()
+	ba.MustSave(st)
+
+	keys := []string{"b", "a", "c"}
+	for _, key := range keys {
+		require.NoError(t, NewBlockAccountData(ba.Address, key, "value-"+key).Save(st))
+	}
+
+	// the entries of the other account are not included
+	other := TestMakeBlockAccount()
+	require.NoError(t, NewBlockAccountData(other.Address, "a", "value-a").Save(st))
+
+	var fetched []string
+	iterFunc, closeFunc := GetBlockAccountDataByAddress(st, ba.Address, nil)
+	for {
+		bd, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		require.Equal(t, "value-"+bd.Key, bd.Value)
+		fetched = append(fetched, bd.Key)
+	}
+	closeFunc()
+	require.Equal(t, []string{"a", "b", "c"}, fetched)
+
+	// update
+	require.NoError(t, NewBlockAccountData(ba.Address, "a", "updated").Save(st))
+	bd, err := GetBlockAccountData(st, ba.Address, "a")
+	require.NoError(t, err)
+	require.Equal(t, "updated", bd.Value)
+
+	// the entries are deleted with the account
+	require.NoError(t, ba.Remove(st))
+	for _, key := range keys {
+		exists, err := ExistsBlockAccountData(st, ba.Address, key)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+	exists, err := ExistsBlockAccountData(st, other.Address, "a")
+	require.NoError(t, err)
+	require.True(t, exists)
+}
@@ -129,3 +129,77 @@ func TestBlockAccountSaveBlockAccountSequenceIDs(t *testing.T) {
 		require.Equal(t, b.SequenceID, fetched[i].SequenceID)
 	}
 }
+
+func TestBlockAccountRemove(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	b := TestMakeBlockAccount()
+	b.MustSave(st)
+	require.NoError(t, b.Remove(st))
+
+	exists, err := ExistsBlockAccount(st, b.Address)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	existed, err := ExistedBlockAccount(st, b.Address)
+	require.NoError(t, err)
+	require.True(t, existed)
+
+	bac, err := GetBlockAccountSequenceID(st, b.Address, b.SequenceID)
+	require.NoError(t, err)
+	require.Equal(t, common.Amount(0), bac.Balance)
+
+	existed, err = ExistedBlockAccount(st, TestMakeBlockAccount().Address)
+	require.NoError(t, err)
+	require.False(t, existed)
+}
+
+// The data entries and the 'created' index of account are removed, even if
+// they are not committed yet in the batch storage.
+func TestBlockAccountRemoveInBatch(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	listed := func(address string) bool {
+		iterFunc, closeFunc := GetBlockAccountAddressesByCreated(st, nil)
+		defer closeFunc()
+		for {
+			a, hasNext, _ := iterFunc()
+			if !hasNext {
+				return false
+			}
+			if a == address {
+				return true
+			}
+		}
+	}
+
+	b := TestMakeBlockAccount()
+	b.MustSave(st)
+	require.NoError(t, NewBlockAccountData(b.Address, "committed", "value").Save(st))
+	require.True(t, listed(b.Address))
+
+	bs, err := st.OpenBatch()
+	require.NoError(t, err)
+	require.NoError(t, NewBlockAccountData(b.Address, "pending", "value").Save(bs))
+	require.NoError(t, b.Remove(bs))
+	require.NoError(t, bs.Commit())
+
+	for _, key := range []string{"committed", "pending"} {
+		exists, err := ExistsBlockAccountData(st, b.Address, key)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+	require.False(t, listed(b.Address))
+
+	{ // the account, whose keys are not tracked
+		legacy := TestMakeBlockAccount()
+		legacy.MustSave(st)
+		require.NoError(t, st.Remove(getBlockAccountKeysKey(legacy.Address)))
+		require.True(t, listed(legacy.Address))
+
+		require.NoError(t, legacy.Remove(st))
+		require.False(t, listed(legacy.Address))
+	}
+}
@@ -0,0 +1,207 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockTrustline is the balance of account for the asset, which is trusted by
+// `operation.Trust`.
+//
+// models
+//  * 'address' and 'asset'
+// 	- 'btl-<BlockTrustline.Address>-<BlockTrustline.Asset>': `BlockTrustline`
+type BlockTrustline struct {
+	Address string        `json:"address"`
+	Asset   common.Asset  `json:"asset"`
+	Balance common.Amount `json:"balance"`
+	Limit   common.Amount `json:"limit"`
+}
+
+func NewBlockTrustline(address string, asset common.Asset, limit common.Amount) *BlockTrustline {
+	return &BlockTrustline{
+		Address: address,
+		Asset:   asset,
+		Limit:   limit,
+	}
+}
+
+func GetBlockTrustlineKey(address string, asset common.Asset) string {
+	return fmt.Sprintf("%s%s", GetBlockTrustlineKeyPrefixAddress(address), asset)
+}
+
+func GetBlockTrustlineKeyPrefixAddress(address string) string {
+	return fmt.Sprintf("%s%s-", common.BlockTrustlinePrefix, address)
+}
+
+func (b *BlockTrustline) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+// Available returns the amount, which the trustline can receive more.
+func (b *BlockTrustline) Available() common.Amount {
+	if b.Balance > b.Limit {
+		return 0
+	}
+
+	return b.Limit - b.Balance
+}
+
+func (b *BlockTrustline) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockTrustlineKey(b.Address, b.Asset)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+func (b *BlockTrustline) Remove(st *storage.LevelDBBackend) (err error) {
+	return st.Remove(GetBlockTrustlineKey(b.Address, b.Asset))
+}
+
+func ExistsBlockTrustline(st *storage.LevelDBBackend, address string, asset common.Asset) (bool, error) {
+	return st.Has(GetBlockTrustlineKey(address, asset))
+}
+
+func GetBlockTrustline(st *storage.LevelDBBackend, address string, asset common.Asset) (b *BlockTrustline, err error) {
+	if err = st.Get(GetBlockTrustlineKey(address, asset), &b); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetBlockTrustlinesByAddress returns the trustlines of account ordered by
+// asset.
+func GetBlockTrustlinesByAddress(st *storage.LevelDBBackend, address string, options storage.ListOptions) (func() (*BlockTrustline, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockTrustlineKeyPrefixAddress(address), options)
+
+	return (func() (*BlockTrustline, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockTrustline
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// BlockAsset is the asset issued by `operation.IssueAsset`. `Issued` is the
+// amount in the trustlines of the other accounts; it is decreased when the
+// asset is sent back to the issuer.
+//
+// models
+//  * 'issuer' and 'code'
+// 	- 'bas-<BlockAsset.Issuer>-<BlockAsset.Code>': `BlockAsset`
+type BlockAsset struct {
+	Code   string        `json:"code"`
+	Issuer string        `json:"issuer"`
+	Issued common.Amount `json:"issued"`
+}
+
+func NewBlockAsset(asset common.Asset) *BlockAsset {
+	return &BlockAsset{
+		Code:   asset.Code,
+		Issuer: asset.Issuer,
+	}
+}
+
+func GetBlockAssetKey(asset common.Asset) string {
+	return fmt.Sprintf("%s%s", GetBlockAssetKeyPrefixIssuer(asset.Issuer), asset.Code)
+}
+
+func GetBlockAssetKeyPrefixIssuer(issuer string) string {
+	return fmt.Sprintf("%s%s-", common.BlockAssetPrefix, issuer)
+}
+
+// Asset returns the `common.Asset` of the issued asset.
+func (b *BlockAsset) Asset() common.Asset {
+	return common.NewAsset(b.Code, b.Issuer)
+}
+
+func (b *BlockAsset) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+func (b *BlockAsset) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockAssetKey(b.Asset())
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+func GetBlockAsset(st *storage.LevelDBBackend, asset common.Asset) (b *BlockAsset, err error) {
+	if err = st.Get(GetBlockAssetKey(asset), &b); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetOrNewBlockAsset returns the stored asset or the new one, which is not
+// issued yet.
+func GetOrNewBlockAsset(st *storage.LevelDBBackend, asset common.Asset) (b *BlockAsset, err error) {
+	var exists bool
+	if exists, err = st.Has(GetBlockAssetKey(asset)); err != nil {
+		return
+	} else if !exists {
+		b = NewBlockAsset(asset)
+		return
+	}
+
+	return GetBlockAsset(st, asset)
+}
+
+// GetBlockAssets returns the issued assets ordered by issuer and code.
+func GetBlockAssets(st *storage.LevelDBBackend, options storage.ListOptions) (func() (*BlockAsset, bool, []byte), func()) {
+	return getBlockAssets(st, common.BlockAssetPrefix, options)
+}
+
+// GetBlockAssetsByIssuer returns the assets issued by the account.
+func GetBlockAssetsByIssuer(st *storage.LevelDBBackend, issuer string, options storage.ListOptions) (func() (*BlockAsset, bool, []byte), func()) {
+	return getBlockAssets(st, GetBlockAssetKeyPrefixIssuer(issuer), options)
+}
+
+func getBlockAssets(st *storage.LevelDBBackend, prefix string, options storage.ListOptions) (func() (*BlockAsset, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(prefix, options)
+
+	return (func() (*BlockAsset, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockAsset
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestBlockTrustline(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	address := keypair.Random().Address()
+	issuer := keypair.Random().Address()
+	point := common.NewAsset("POINT", issuer)
+	usd := common.NewAsset("USD", issuer)
+
+	tl := NewBlockTrustline(address, point, common.Amount(1000))
+	require.NoError(t, tl.Save(st))
+	require.Equal(t, common.Amount(1000), tl.Available())
+
+	// the trustline of the other account is not included
+	require.NoError(t, NewBlockTrustline(issuer, point, common.Amount(1000)).Save(st))
+
+	exists, err := ExistsBlockTrustline(st, address, point)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	fetched, err := GetBlockTrustline(st, address, point)
+	require.NoError(t, err)
+	require.Equal(t, tl, fetched)
+
+	fetched.Balance = common.Amount(300)
+	require.NoError(t, fetched.Save(st))
+	require.Equal(t, common.Amount(700), fetched.Available())
+
+	require.NoError(t, NewBlockTrustline(address, usd, common.Amount(10)).Save(st))
+
+	var trustlines []*BlockTrustline
+	iterFunc, closeFunc := GetBlockTrustlinesByAddress(st, address, nil)
+	for {
+		l, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		trustlines = append(trustlines, l)
+	}
+	closeFunc()
+	require.Equal(t, 2, len(trustlines))
+	require.Equal(t, point, trustlines[0].Asset)
+	require.Equal(t, common.Amount(300), trustlines[0].Balance)
+	require.Equal(t, usd, trustlines[1].Asset)
+
+	require.NoError(t, fetched.Remove(st))
+	exists, err = ExistsBlockTrustline(st, address, point)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestBlockAsset(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	issuer := keypair.Random().Address()
+	point := common.NewAsset("POINT", issuer)
+
+	// not issued yet
+	ba, err := GetOrNewBlockAsset(st, point)
+	require.NoError(t, err)
+	require.Equal(t, point, ba.Asset())
+	require.Equal(t, common.Amount(0), ba.Issued)
+
+	ba.Issued = common.Amount(100)
+	require.NoError(t, ba.Save(st))
+	require.NoError(t, NewBlockAsset(common.NewAsset("USD", keypair.Random().Address())).Save(st))
+
+	fetched, err := GetOrNewBlockAsset(st, point)
+	require.NoError(t, err)
+	require.Equal(t, ba, fetched)
+
+	count := func(iterFunc func() (*BlockAsset, bool, []byte), closeFunc func()) int {
+		defer closeFunc()
+
+		var n int
+		for {
+			if _, hasNext, _ := iterFunc(); !hasNext {
+				break
+			}
+			n++
+		}
+		return n
+	}
+	require.Equal(t, 2, count(GetBlockAssets(st, nil)))
+	require.Equal(t, 1, count(GetBlockAssetsByIssuer(st, issuer, nil)))
+}
@@ -118,7 +118,7 @@ func TestMakeGenesisBlock(t *testing.T) {
 	err = commonAccount.Save(st)
 	require.NoError(t, err)
 
-	bk, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, conf.NetworkID)
+	bk, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID)
 	require.NoError(t, err)
 	require.Equal(t, uint64(1), bk.Height)
 	require.Equal(t, 1, len(bk.Transactions))
@@ -184,7 +184,7 @@ func TestMakeGenesisBlockOverride(t *testing.T) {
 		err = commonAccount.Save(st)
 		require.NoError(t, err)
 
-		bk, err := MakeGenesisBlock(st, *account, *commonAccount, conf.NetworkID)
+		bk, err := MakeGenesisBlock(st, *account, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID)
 		require.NoError(t, err)
 		require.Equal(t, uint64(1), bk.Height)
 	}
@@ -201,7 +201,7 @@ func TestMakeGenesisBlockOverride(t *testing.T) {
 		err = commonAccount.Save(st)
 		require.NoError(t, err)
 
-		_, err = MakeGenesisBlock(st, *account, *commonAccount, conf.NetworkID)
+		_, err = MakeGenesisBlock(st, *account, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID)
 		require.Equal(t, errors.BlockAlreadyExists, err)
 	}
 }
@@ -222,7 +222,7 @@ func TestMakeGenesisBlockFindGenesisAccount(t *testing.T) {
 	commonAccount.MustSave(st)
 
 	{
-		bk, err := MakeGenesisBlock(st, *account, *commonAccount, conf.NetworkID)
+		bk, err := MakeGenesisBlock(st, *account, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID)
 		require.NoError(t, err)
 		require.Equal(t, uint64(1), bk.Height)
 	}
@@ -263,7 +263,7 @@ func TestMakeGenesisBlockFindCommonAccount(t *testing.T) {
 	commonAccount.MustSave(st)
 
 	{
-		bk, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, conf.NetworkID)
+		bk, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID)
 		require.NoError(t, err)
 		require.Equal(t, uint64(1), bk.Height)
 	}
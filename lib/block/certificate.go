@@ -0,0 +1,71 @@
+package block
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockCertificate keeps the signed ACCEPT ballots, which confirmed the block.
+// The ballots are kept as the JSON messages like `TransactionPool`, because
+// `ballot.Ballot` depends on `block`; see `ballot.VerifyBlockCertificate`.
+//
+// models
+//  * 'hash'
+// 	- 'bc-<Block.Hash>': `BlockCertificate`
+type BlockCertificate struct {
+	Hash    string            `json:"hash"`   // hash of `Block`
+	Height  uint64            `json:"height"` // height of `Block`
+	Ballots []json.RawMessage `json:"ballots"`
+}
+
+func NewBlockCertificate(blk Block, ballots []json.RawMessage) BlockCertificate {
+	return BlockCertificate{
+		Hash:    blk.Hash,
+		Height:  blk.Height,
+		Ballots: ballots,
+	}
+}
+
+func GetBlockCertificateKey(hash string) string {
+	return fmt.Sprintf("%s%s", common.BlockCertificatePrefix, hash)
+}
+
+func (bc BlockCertificate) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockCertificateKey(bc.Hash)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	} else if exists {
+		return errors.AlreadySaved
+	}
+
+	return st.New(key, bc)
+}
+
+func (bc BlockCertificate) Serialize() ([]byte, error) {
+	return json.Marshal(bc)
+}
+
+func (bc BlockCertificate) String() string {
+	return string(common.MustMarshalJSON(bc))
+}
+
+func ExistsBlockCertificate(st *storage.LevelDBBackend, hash string) (bool, error) {
+	return st.Has(GetBlockCertificateKey(hash))
+}
+
+func GetBlockCertificate(st *storage.LevelDBBackend, hash string) (bc BlockCertificate, err error) {
+	if err = st.Get(GetBlockCertificateKey(hash), &bc); err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			err = errors.BlockCertificateDoesNotExists
+		}
+		return
+	}
+
+	return
+}
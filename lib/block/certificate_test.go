@@ -0,0 +1,51 @@
+package block
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestBlockCertificate(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	blk := GetLatestBlock(st)
+
+	{ // not found
+		exists, err := ExistsBlockCertificate(st, blk.Hash)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		_, err = GetBlockCertificate(st, blk.Hash)
+		require.Equal(t, errors.BlockCertificateDoesNotExists, err)
+	}
+
+	ballots := []json.RawMessage{
+		json.RawMessage(`{"H":{"hash":"a"}}`),
+		json.RawMessage(`{"H":{"hash":"b"}}`),
+	}
+	bc := NewBlockCertificate(blk, ballots)
+	require.NoError(t, bc.Save(st))
+
+	{ // saving again is failed
+		require.Equal(t, errors.AlreadySaved, bc.Save(st))
+	}
+
+	{ // get
+		exists, err := ExistsBlockCertificate(st, blk.Hash)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		fetched, err := GetBlockCertificate(st, blk.Hash)
+		require.NoError(t, err)
+		require.Equal(t, blk.Hash, fetched.Hash)
+		require.Equal(t, blk.Height, fetched.Height)
+		require.Equal(t, 2, len(fetched.Ballots))
+		require.JSONEq(t, string(ballots[0]), string(fetched.Ballots[0]))
+		require.JSONEq(t, string(ballots[1]), string(fetched.Ballots[1]))
+	}
+}
@@ -0,0 +1,198 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// BlockCongressMember is the member of congress, who can cast
+// `operation.CongressVote`. It is added and removed by
+// `operation.CongressMembership`.
+//
+// models
+//  * 'address'
+// 	- 'bcm-<BlockCongressMember.Address>': `BlockCongressMember`
+type BlockCongressMember struct {
+	Address string `json:"address"`
+}
+
+func NewBlockCongressMember(address string) *BlockCongressMember {
+	return &BlockCongressMember{Address: address}
+}
+
+func GetBlockCongressMemberKey(address string) string {
+	return fmt.Sprintf("%s%s", common.BlockCongressMemberPrefix, address)
+}
+
+func (b *BlockCongressMember) Save(st *storage.LevelDBBackend) error {
+	return st.New(GetBlockCongressMemberKey(b.Address), b)
+}
+
+func (b *BlockCongressMember) Delete(st *storage.LevelDBBackend) error {
+	return st.Remove(GetBlockCongressMemberKey(b.Address))
+}
+
+func ExistsBlockCongressMember(st *storage.LevelDBBackend, address string) (bool, error) {
+	return st.Has(GetBlockCongressMemberKey(address))
+}
+
+func GetBlockCongressMembers(st *storage.LevelDBBackend, options storage.ListOptions) (func() (*BlockCongressMember, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(common.BlockCongressMemberPrefix, options)
+
+	return (func() (*BlockCongressMember, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockCongressMember
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// BlockCongressVote is the vote cast by `operation.CongressVote`. The
+// `VotingHash` is the `<tx hash>-<operation index>` of
+// `operation.CongressVoting`.
+//
+// models
+//  * 'voting hash' and 'voter'
+// 	- 'bcv-<BlockCongressVote.VotingHash>-<BlockCongressVote.Voter>': `BlockCongressVote`
+type BlockCongressVote struct {
+	VotingHash string               `json:"congress_voting_hash"`
+	Voter      string               `json:"voter"`
+	Vote       operation.VoteAnswer `json:"vote"`
+}
+
+func NewBlockCongressVote(votingHash, voter string, vote operation.VoteAnswer) *BlockCongressVote {
+	return &BlockCongressVote{
+		VotingHash: votingHash,
+		Voter:      voter,
+		Vote:       vote,
+	}
+}
+
+func GetBlockCongressVoteKey(votingHash, voter string) string {
+	return fmt.Sprintf("%s%s", GetBlockCongressVoteKeyPrefixVoting(votingHash), voter)
+}
+
+func GetBlockCongressVoteKeyPrefixVoting(votingHash string) string {
+	return fmt.Sprintf("%s%s-", common.BlockCongressVotePrefix, votingHash)
+}
+
+func (b *BlockCongressVote) Save(st *storage.LevelDBBackend) error {
+	return st.New(GetBlockCongressVoteKey(b.VotingHash, b.Voter), b)
+}
+
+func ExistsBlockCongressVote(st *storage.LevelDBBackend, votingHash, voter string) (bool, error) {
+	return st.Has(GetBlockCongressVoteKey(votingHash, voter))
+}
+
+// GetBlockCongressVotesByVoting returns the votes of the voting.
+func GetBlockCongressVotesByVoting(st *storage.LevelDBBackend, votingHash string, options storage.ListOptions) (func() (*BlockCongressVote, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockCongressVoteKeyPrefixVoting(votingHash), options)
+
+	return (func() (*BlockCongressVote, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockCongressVote
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// BlockCongressVotingTally counts the votes of the voting. It is created
+// with `operation.CongressVoting` and `Closed` is set when
+// `operation.CongressVotingResult` of the voting is accepted. The voting,
+// which was created before the votes are cast on chain, does not have the
+// tally until it's result is accepted.
+//
+// models
+//  * 'voting hash'
+// 	- 'bct-<BlockCongressVotingTally.VotingHash>': `BlockCongressVotingTally`
+type BlockCongressVotingTally struct {
+	VotingHash string `json:"congress_voting_hash"`
+	Yes        uint64 `json:"yes"`
+	No         uint64 `json:"no"`
+	ABS        uint64 `json:"abs"`
+	Closed     bool   `json:"closed"`
+}
+
+func NewBlockCongressVotingTally(votingHash string) *BlockCongressVotingTally {
+	return &BlockCongressVotingTally{VotingHash: votingHash}
+}
+
+func GetBlockCongressVotingTallyKey(votingHash string) string {
+	return fmt.Sprintf("%s%s", common.BlockCongressVotingTallyPrefix, votingHash)
+}
+
+func (b *BlockCongressVotingTally) Count() uint64 {
+	return b.Yes + b.No + b.ABS
+}
+
+func (b *BlockCongressVotingTally) Add(vote operation.VoteAnswer) {
+	switch vote {
+	case operation.VoteYes:
+		b.Yes++
+	case operation.VoteNo:
+		b.No++
+	case operation.VoteABS:
+		b.ABS++
+	}
+}
+
+// Passed returns true if more than half of the votes are yes.
+func (b *BlockCongressVotingTally) Passed() bool {
+	return b.Yes*2 > b.Count()
+}
+
+func (b *BlockCongressVotingTally) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockCongressVotingTallyKey(b.VotingHash)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+func ExistsBlockCongressVotingTally(st *storage.LevelDBBackend, votingHash string) (bool, error) {
+	return st.Has(GetBlockCongressVotingTallyKey(votingHash))
+}
+
+// GetBlockCongressVotingTally returns the tally of the voting. If the tally
+// does not exist, the empty tally is returned.
+func GetBlockCongressVotingTally(st *storage.LevelDBBackend, votingHash string) (b *BlockCongressVotingTally, err error) {
+	key := GetBlockCongressVotingTallyKey(votingHash)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	} else if !exists {
+		b = NewBlockCongressVotingTally(votingHash)
+		return
+	}
+
+	err = st.Get(key, &b)
+
+	return
+}
@@ -0,0 +1,76 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestBlockCongressVote(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	votingHash := "txhash-0"
+	voters := []string{keypair.Random().Address(), keypair.Random().Address()}
+
+	for _, voter := range voters {
+		require.NoError(t, NewBlockCongressMember(voter).Save(st))
+		require.NoError(t, NewBlockCongressVote(votingHash, voter, operation.VoteYes).Save(st))
+
+		exists, err := ExistsBlockCongressVote(st, votingHash, voter)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// the votes of the other voting
+	require.NoError(t, NewBlockCongressVote("txhash-00", voters[0], operation.VoteNo).Save(st))
+
+	var votes []*BlockCongressVote
+	iterFunc, closeFunc := GetBlockCongressVotesByVoting(st, votingHash, nil)
+	for {
+		v, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		votes = append(votes, v)
+	}
+	closeFunc()
+	require.Equal(t, 2, len(votes))
+
+	require.NoError(t, NewBlockCongressMember(voters[0]).Delete(st))
+	exists, err := ExistsBlockCongressMember(st, voters[0])
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestBlockCongressVotingTally(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	votingHash := "txhash-0"
+
+	// empty tally
+	tally, err := GetBlockCongressVotingTally(st, votingHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), tally.Count())
+	require.False(t, tally.Passed())
+
+	for _, vote := range []operation.VoteAnswer{operation.VoteYes, operation.VoteYes, operation.VoteNo, operation.VoteABS} {
+		tally.Add(vote)
+	}
+	require.NoError(t, tally.Save(st))
+
+	tally, err = GetBlockCongressVotingTally(st, votingHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), tally.Count())
+	require.Equal(t, uint64(2), tally.Yes)
+	// the half is not passed
+	require.False(t, tally.Passed())
+
+	tally.Add(operation.VoteYes)
+	require.True(t, tally.Passed())
+}
@@ -0,0 +1,99 @@
+package block
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// Evidence keeps the pair of conflicting ballots, which are signed by the same
+// validator for the same `voting.Basis` and state. Like `BlockCertificate`,
+// the ballots are kept as the JSON messages; see `ballot.VerifyEvidence`.
+//
+// models
+//  * 'source', 'height', 'round' and 'state'
+// 	- 'ev-<Evidence.Source>-<Evidence.Height>-<Evidence.Round>-<Evidence.State>': `Evidence`
+type Evidence struct {
+	Source   string            `json:"source"`
+	Height   uint64            `json:"height"`
+	Round    uint64            `json:"round"`
+	State    string            `json:"state"`
+	Ballots  []json.RawMessage `json:"ballots"`
+	Detected string            `json:"detected"` // ISO8601
+}
+
+func NewEvidence(source string, height, round uint64, state string, ballots []json.RawMessage) Evidence {
+	return Evidence{
+		Source:   source,
+		Height:   height,
+		Round:    round,
+		State:    state,
+		Ballots:  ballots,
+		Detected: common.NowISO8601(),
+	}
+}
+
+func GetEvidenceKey(source string, height, round uint64, state string) string {
+	return fmt.Sprintf("%s%020d-%020d-%s", GetEvidenceKeyPrefixSource(source), height, round, state)
+}
+
+func GetEvidenceKeyPrefixSource(source string) string {
+	return fmt.Sprintf("%s%s-", common.EvidencePrefix, source)
+}
+
+func (e Evidence) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetEvidenceKey(e.Source, e.Height, e.Round, e.State)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	} else if exists {
+		return errors.AlreadySaved
+	}
+
+	return st.New(key, e)
+}
+
+func (e Evidence) String() string {
+	return string(common.MustMarshalJSON(e))
+}
+
+func ExistsEvidence(st *storage.LevelDBBackend, source string, height, round uint64, state string) (bool, error) {
+	return st.Has(GetEvidenceKey(source, height, round, state))
+}
+
+func GetEvidence(st *storage.LevelDBBackend, source string, height, round uint64, state string) (e Evidence, err error) {
+	err = st.Get(GetEvidenceKey(source, height, round, state), &e)
+	return
+}
+
+// GetEvidences returns the evidences ordered by source, height and round.
+func GetEvidences(st *storage.LevelDBBackend, options storage.ListOptions) (func() (*Evidence, bool, []byte), func()) {
+	return getEvidences(st, common.EvidencePrefix, options)
+}
+
+// GetEvidencesBySource returns the evidences of the validator.
+func GetEvidencesBySource(st *storage.LevelDBBackend, source string, options storage.ListOptions) (func() (*Evidence, bool, []byte), func()) {
+	return getEvidences(st, GetEvidenceKeyPrefixSource(source), options)
+}
+
+func getEvidences(st *storage.LevelDBBackend, prefix string, options storage.ListOptions) (func() (*Evidence, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(prefix, options)
+
+	return (func() (*Evidence, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var e Evidence
+			common.MustUnmarshalJSON(item.Value, &e)
+
+			return &e, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
@@ -0,0 +1,78 @@
+package block
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestEvidence(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	source := keypair.Random().Address()
+	ballots := []json.RawMessage{
+		json.RawMessage(`{"H":{"hash":"a"}}`),
+		json.RawMessage(`{"H":{"hash":"b"}}`),
+	}
+
+	e := NewEvidence(source, 3, 1, "SIGN", ballots)
+	require.NoError(t, e.Save(st))
+	require.Equal(t, errors.AlreadySaved, e.Save(st))
+
+	{ // get
+		exists, err := ExistsEvidence(st, source, 3, 1, "SIGN")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		exists, err = ExistsEvidence(st, source, 3, 1, "ACCEPT")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		fetched, err := GetEvidence(st, source, 3, 1, "SIGN")
+		require.NoError(t, err)
+		require.Equal(t, e.Detected, fetched.Detected)
+		require.Equal(t, 2, len(fetched.Ballots))
+	}
+
+	other := keypair.Random().Address()
+	require.NoError(t, NewEvidence(other, 2, 0, "ACCEPT", ballots).Save(st))
+	require.NoError(t, NewEvidence(source, 10, 0, "ACCEPT", ballots).Save(st))
+
+	{ // by source, ordered by height
+		var evidences []Evidence
+		iterFunc, closeFunc := GetEvidencesBySource(st, source, nil)
+		for {
+			e, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			evidences = append(evidences, *e)
+		}
+		closeFunc()
+
+		require.Equal(t, 2, len(evidences))
+		require.Equal(t, uint64(3), evidences[0].Height)
+		require.Equal(t, uint64(10), evidences[1].Height)
+	}
+
+	{ // all
+		var count int
+		iterFunc, closeFunc := GetEvidences(st, nil)
+		for {
+			_, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			count++
+		}
+		closeFunc()
+
+		require.Equal(t, 3, count)
+	}
+}
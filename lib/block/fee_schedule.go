@@ -0,0 +1,19 @@
+package block
+
+import (
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// GetFeeSchedule returns the fee schedule of network, which is set by the
+// `operation.NetworkParameters` of genesis block.
+func GetFeeSchedule(st *storage.LevelDBBackend) (fs operation.FeeSchedule, err error) {
+	var params operation.NetworkParameters
+	if params, err = GetNetworkParameters(st); err != nil {
+		return
+	}
+
+	fs = params.FeeSchedule
+
+	return
+}
@@ -0,0 +1,67 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestFeeSchedule(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	makeGenesis := func(params operation.NetworkParameters) (*storage.LevelDBBackend, *Block, error) {
+		st := storage.NewTestStorage()
+
+		genesisAccount := NewBlockAccount(GenesisKP.Address(), conf.InitialBalance)
+		genesisAccount.MustSave(st)
+		commonAccount := NewBlockAccount(CommonKP.Address(), 0)
+		commonAccount.MustSave(st)
+
+		blk, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, params, conf.NetworkID)
+		return st, blk, err
+	}
+
+	// the genesis block of default parameters does not have them
+	st, defaultBlock, err := makeGenesis(operation.NewNetworkParameters())
+	require.NoError(t, err)
+	defer st.Close()
+	require.Equal(t, uint64(2), defaultBlock.TotalOps)
+
+	fs, err := GetFeeSchedule(st)
+	require.NoError(t, err)
+	require.Equal(t, operation.NewFeeSchedule(), fs)
+
+	params := operation.NewNetworkParameters()
+	params.FeeSchedule.Operations = map[operation.OperationType]common.Amount{operation.TypePayment: common.BaseFee * 2}
+
+	st1, blk, err := makeGenesis(params)
+	require.NoError(t, err)
+	defer st1.Close()
+	require.Equal(t, uint64(3), blk.TotalOps)
+	require.NotEqual(t, defaultBlock.Hash, blk.Hash)
+
+	fetched, err := GetFeeSchedule(st1)
+	require.NoError(t, err)
+	require.Equal(t, params.FeeSchedule, fetched)
+
+	// the parameters are the part of the genesis transaction
+	bt, err := GetBlockTransaction(st1, blk.Transactions[0])
+	require.NoError(t, err)
+	tp, err := GetTransactionPool(st1, bt.Hash)
+	require.NoError(t, err)
+	tx := tp.Transaction()
+	require.Equal(t, 3, len(tx.B.Operations))
+	require.Equal(t, operation.TypeNetworkParameters, tx.B.Operations[2].H.Type)
+	require.Equal(t, bt.Hash, tx.B.MakeHashString())
+
+	// the fee lower than `common.BaseFee` can not be set
+	params.FeeSchedule.Operations[operation.TypePayment] = common.BaseFee - 1
+	st2, _, err := makeGenesis(params)
+	defer st2.Close()
+	require.Equal(t, errors.InvalidFee, err)
+}
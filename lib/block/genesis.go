@@ -36,20 +36,26 @@ func GetGenesis(st *storage.LevelDBBackend) Block {
 //
 // This Transaction is different from other normal Transaction;
 // * signed by `keypair.Master(string(networkID))`
-// * must have only two `Operation`, `CreateAccount`
+// * must have two `Operation`, `CreateAccount`
 // * The first `Operation` is for genesis account
 //   * `CreateAccount.Amount` is same with balance of genesis account
 //   * `CreateAccount.Target` is genesis account
 // * The next `Operation` is for common account
 //   * `CreateAccount.Amount` is 0
 //   * `CreateAccount.Target` is common account
+// * The last `Operation` is `NetworkParameters`, only if `params` is not
+//   the default
 // * `Transaction.B.Fee` is 0
-func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, commonAccount BlockAccount, networkID []byte) (blk *Block, err error) {
+func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, commonAccount BlockAccount, params operation.NetworkParameters, networkID []byte) (blk *Block, err error) {
 	if genesisAccount.Address == commonAccount.Address {
 		err = fmt.Errorf("genesis account and common account are same.")
 		return
 	}
 
+	if err = params.IsWellFormed(common.Config{}); err != nil {
+		return
+	}
+
 	var exists bool
 	if exists, err = ExistsBlockByHeight(st, 1); exists || err != nil {
 		if exists {
@@ -83,6 +89,11 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 		ops = append(ops, op)
 	}
 
+	if !params.IsDefault() {
+		op, _ := operation.NewOperation(params)
+		ops = append(ops, op)
+	}
+
 	txBody := transaction.Body{
 		Source:     genesisAccount.Address,
 		Fee:        0,
@@ -107,7 +118,7 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 		voting.Basis{
 			Height:   common.GenesisBlockHeight,
 			TotalTxs: 1,
-			TotalOps: uint64(len(tx.B.Operations)), // op for creating genesis account and common account operations
+			TotalOps: uint64(len(tx.B.Operations)), // op for creating genesis account and common account operations, and network parameters
 		},
 		"",
 		[]string{tx.GetHash()},
@@ -131,3 +142,46 @@ func MakeGenesisBlock(st *storage.LevelDBBackend, genesisAccount BlockAccount, c
 
 	return
 }
+
+// GetNetworkParameters returns the `operation.NetworkParameters` of the
+// genesis block. If the genesis block does not have it or the genesis block
+// is not made yet, the default parameters are returned.
+func GetNetworkParameters(st *storage.LevelDBBackend) (params operation.NetworkParameters, err error) {
+	var blk Block
+	if blk, err = GetBlockByHeight(st, common.GenesisBlockHeight); err == errors.StorageRecordDoesNotExist {
+		params = operation.NewNetworkParameters()
+		err = nil
+		return
+	} else if err != nil {
+		return
+	}
+	if len(blk.Transactions) < 1 {
+		err = errors.WrongBlockFound
+		return
+	}
+
+	var bt BlockTransaction
+	if bt, err = GetBlockTransaction(st, blk.Transactions[0]); err != nil {
+		return
+	}
+
+	for _, opHash := range bt.Operations {
+		var bo BlockOperation
+		if bo, err = GetBlockOperation(st, opHash); err != nil {
+			return
+		}
+		if bo.Type != operation.TypeNetworkParameters {
+			continue
+		}
+
+		var body operation.Body
+		if body, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
+			return
+		}
+		params = body.(operation.NetworkParameters)
+		return
+	}
+
+	params = operation.NewNetworkParameters()
+	return
+}
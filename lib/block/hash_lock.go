@@ -0,0 +1,115 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+)
+
+type HashLockState string
+
+const (
+	HashLockLocked   HashLockState = "locked"
+	HashLockClaimed  HashLockState = "claimed"
+	HashLockRefunded HashLockState = "refunded"
+)
+
+// BlockHashLock holds the amount, which is locked by
+// `operation.HashLockCreate`. It is kept after it is claimed or refunded, so
+// the same hash lock can not be used again between the same accounts and the
+// preimage can be found by the source.
+//
+// models
+//  * 'source', 'target' and 'hash lock'
+// 	- 'bhl-<BlockHashLock.Source>-<BlockHashLock.Target>-<BlockHashLock.HashLock>': `BlockHashLock`
+type BlockHashLock struct {
+	Source   string        `json:"source"`
+	Target   string        `json:"target"`
+	Amount   common.Amount `json:"amount"`
+	HashLock string        `json:"hash_lock"`
+	Expiry   uint64        `json:"expiry"`
+	State    HashLockState `json:"state"`
+	Preimage string        `json:"preimage,omitempty"`
+}
+
+func NewBlockHashLock(source, target string, amount common.Amount, hashLock string, expiry uint64) *BlockHashLock {
+	return &BlockHashLock{
+		Source:   source,
+		Target:   target,
+		Amount:   amount,
+		HashLock: hashLock,
+		Expiry:   expiry,
+		State:    HashLockLocked,
+	}
+}
+
+func GetBlockHashLockKey(source, target, hashLock string) string {
+	return fmt.Sprintf("%s%s-%s", GetBlockHashLockKeyPrefixSource(source), target, hashLock)
+}
+
+func GetBlockHashLockKeyPrefixSource(source string) string {
+	return fmt.Sprintf("%s%s-", common.BlockHashLockPrefix, source)
+}
+
+func (b *BlockHashLock) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+// IsLocked returns true if the lock is not claimed or refunded yet.
+func (b *BlockHashLock) IsLocked() bool {
+	return b.State == HashLockLocked
+}
+
+// IsExpired checks the lock is expired at the block of given height.
+func (b *BlockHashLock) IsExpired(height uint64) bool {
+	return height > b.Expiry
+}
+
+func (b *BlockHashLock) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockHashLockKey(b.Source, b.Target, b.HashLock)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+
+	return
+}
+
+func ExistsBlockHashLock(st *storage.LevelDBBackend, source, target, hashLock string) (bool, error) {
+	return st.Has(GetBlockHashLockKey(source, target, hashLock))
+}
+
+func GetBlockHashLock(st *storage.LevelDBBackend, source, target, hashLock string) (b *BlockHashLock, err error) {
+	if err = st.Get(GetBlockHashLockKey(source, target, hashLock), &b); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetBlockHashLocksBySource returns the hash locks created by source.
+func GetBlockHashLocksBySource(st *storage.LevelDBBackend, source string, options storage.ListOptions) (func() (*BlockHashLock, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockHashLockKeyPrefixSource(source), options)
+
+	return (func() (*BlockHashLock, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockHashLock
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
@@ -0,0 +1,57 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestBlockHashLock(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	source := keypair.Random().Address()
+	target := keypair.Random().Address()
+	hashLock := operation.MakeHashLock([]byte("showmethemoney"))
+
+	lock := NewBlockHashLock(source, target, common.Amount(100), hashLock, 10)
+	require.NoError(t, lock.Save(st))
+
+	// the lock of the other source is not included
+	require.NoError(t, NewBlockHashLock(target, source, common.Amount(100), hashLock, 10).Save(st))
+
+	exists, err := ExistsBlockHashLock(st, source, target, hashLock)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	fetched, err := GetBlockHashLock(st, source, target, hashLock)
+	require.NoError(t, err)
+	require.Equal(t, lock, fetched)
+	require.True(t, fetched.IsLocked())
+	require.False(t, fetched.IsExpired(10))
+	require.True(t, fetched.IsExpired(11))
+
+	// claimed
+	fetched.State = HashLockClaimed
+	fetched.Preimage = "73686f776d657468656d6f6e6579"
+	require.NoError(t, fetched.Save(st))
+
+	var locks []*BlockHashLock
+	iterFunc, closeFunc := GetBlockHashLocksBySource(st, source, nil)
+	for {
+		l, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		locks = append(locks, l)
+	}
+	closeFunc()
+	require.Equal(t, 1, len(locks))
+	require.False(t, locks[0].IsLocked())
+	require.Equal(t, fetched.Preimage, locks[0].Preimage)
+}
@@ -51,17 +51,7 @@ func NewBlockOperationFromOperation(op operation.Operation, tx transaction.Trans
 	opHash := common.MustMakeObjectHashString(op)
 	txHash := tx.GetHash()
 
-	target := ""
-	if pop, ok := op.B.(operation.Targetable); ok {
-		target = pop.TargetAddress()
-	}
-
-	linked := ""
-	if createAccount, ok := op.B.(operation.CreateAccount); ok {
-		if createAccount.Linked != "" {
-			linked = createAccount.Linked
-		}
-	}
+	index := operation.GetIndex(op.H.Type, op.B)
 
 	return BlockOperation{
 		Hash: NewBlockOperationKey(opHash, txHash),
@@ -71,13 +61,13 @@ func NewBlockOperationFromOperation(op operation.Operation, tx transaction.Trans
 
 		Type:   op.H.Type,
 		Source: tx.B.Source,
-		Target: target,
+		Target: index.Target,
 		Body:   body,
 		Height: blockHeight,
 
 		seqID:     tx.B.SequenceID,
 		operation: op,
-		linked:    linked,
+		linked:    index.Linked,
 		opIndex:   opIndex,
 	}, nil
 }
@@ -132,6 +122,9 @@ func (bo *BlockOperation) Save(st *storage.LevelDBBackend) (err error) {
 	if err = st.New(bo.NewBlockOperationBlockHeightKey(), bo.Hash); err != nil {
 		return
 	}
+	if err = st.New(bo.NewBlockOperationTypeKey(), bo.Hash); err != nil {
+		return
+	}
 
 	if bo.hasTarget() {
 		if err = st.New(bo.NewBlockOperationTargetKey(bo.Target), bo.Hash); err != nil {
@@ -199,6 +192,10 @@ func keyPrefixBlockHeight(height uint64) string {
 	return fmt.Sprintf("%s%s-", common.BlockOperationPrefixBlockHeight, common.EncodeUint64ToByteSlice(height))
 }
 
+func keyPrefixType(ty operation.OperationType) string {
+	return fmt.Sprintf("%s%s-", common.BlockOperationPrefixType, string(ty))
+}
+
 func keyPrefixTarget(target string) string {
 	return fmt.Sprintf("%s%s-", common.BlockOperationPrefixTarget, target)
 }
@@ -300,6 +297,85 @@ func (bo BlockOperation) NewBlockOperationBlockHeightKey() string {
 	)
 }
 
+// NewBlockOperationTypeKey returns the key of type index; unlike the other
+// indices, it does not have the unique id, so the missing type index can be
+// added again by `SaveBlockOperationTypeIndex`.
+func (bo BlockOperation) NewBlockOperationTypeKey() string {
+	return fmt.Sprintf(
+		"%s%s%s",
+		keyPrefixType(bo.Type),
+		common.EncodeUint64ToByteSlice(bo.Height),
+		bo.Hash,
+	)
+}
+
+// SaveBlockOperationTypeIndex adds the missing type index of the operations
+// in the block of height. The operations, which were saved before the type
+// index was introduced, do not have it and the ones saved by the earlier
+// format of `NewBlockOperationTypeKey` have the different key, which is
+// removed.
+func SaveBlockOperationTypeIndex(st *storage.LevelDBBackend, height uint64) (err error) {
+	iterFunc, closeFunc := GetBlockOperationsByBlockHeight(st, height, nil)
+	defer closeFunc()
+
+	keys := map[string]bool{}
+	types := map[operation.OperationType]bool{}
+	for {
+		bo, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		key := bo.NewBlockOperationTypeKey()
+		keys[key] = true
+		types[bo.Type] = true
+
+		var exists bool
+		if exists, err = st.Has(key); err != nil {
+			return
+		} else if exists {
+			continue
+		}
+		if err = st.New(key, bo.Hash); err != nil {
+			return
+		}
+	}
+
+	for ty := range types {
+		if err = removeStaleBlockOperationTypeKeys(st, ty, height, keys); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// removeStaleBlockOperationTypeKeys removes the type index of the block of
+// height, which is not in the given keys.
+func removeStaleBlockOperationTypeKeys(st *storage.LevelDBBackend, ty operation.OperationType, height uint64, keys map[string]bool) (err error) {
+	prefix := fmt.Sprintf("%s%s", keyPrefixType(ty), common.EncodeUint64ToByteSlice(height))
+	iterFunc, closeFunc := st.GetIterator(prefix, nil)
+
+	var stale []string
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+		if !keys[string(item.Key)] {
+			stale = append(stale, string(item.Key))
+		}
+	}
+	closeFunc()
+
+	for _, key := range stale {
+		if err = st.Remove(key); err != nil {
+			return
+		}
+	}
+
+	return
+}
 func ExistsBlockOperation(st *storage.LevelDBBackend, hash string) (bool, error) {
 	return st.Has(key(hash))
 }
@@ -408,6 +484,16 @@ func GetBlockOperationsBySourceAndType(st *storage.LevelDBBackend, source string
 	return LoadBlockOperationsInsideIterator(st, iterFunc, closeFunc)
 }
 
+// GetBlockOperationsByType returns the operations of the type by the order
+// of block height.
+func GetBlockOperationsByType(st *storage.LevelDBBackend, ty operation.OperationType, options storage.ListOptions) (
+	func() (BlockOperation, bool, []byte),
+	func(),
+) {
+	iterFunc, closeFunc := st.GetIterator(keyPrefixType(ty), options)
+	return LoadBlockOperationsInsideIterator(st, iterFunc, closeFunc)
+}
+
 func GetBlockOperationsByTarget(st *storage.LevelDBBackend, target string, options storage.ListOptions) (
 	func() (BlockOperation, bool, []byte),
 	func(),
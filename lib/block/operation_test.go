@@ -170,3 +170,39 @@ func TestBlockOperationsByBlockHeight(t *testing.T) {
 	}
 
 }
+
+func TestSaveBlockOperationTypeIndex(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+
+	bos := TestMakeNewBlockOperation(conf.NetworkID, 3)
+	for i := range bos {
+		bos[i].Height = 2
+		bos[i].MustSave(st)
+	}
+
+	countByType := func() (n int) {
+		iterFunc, closeFunc := GetBlockOperationsByType(st, bos[0].Type, nil)
+		defer closeFunc()
+		for {
+			_, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			n++
+		}
+		return
+	}
+	require.Equal(t, 3, countByType())
+
+	// the operation saved without type index
+	require.NoError(t, st.Remove(bos[0].NewBlockOperationTypeKey()))
+	require.Equal(t, 2, countByType())
+
+	require.NoError(t, SaveBlockOperationTypeIndex(st, 2))
+	require.Equal(t, 3, countByType())
+
+	// already indexed operations are not indexed again
+	require.NoError(t, SaveBlockOperationTypeIndex(st, 2))
+	require.Equal(t, 3, countByType())
+}
@@ -0,0 +1,61 @@
+package block
+
+import (
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// ProposerRecord keeps the result of the proposers, which were selected to
+// make the block; `Missed` has the proposers of the failed rounds in order of
+// round and `Proposer` is the proposer of block. It is made with the block by
+// `consensus.NewProposerRecord` and read by `consensus.LivenessSelector`.
+//
+// models
+//  * 'height'
+// 	- 'pr-<Block.Height>': `ProposerRecord`
+type ProposerRecord struct {
+	Height   uint64   `json:"height"`
+	Proposer string   `json:"proposer"`
+	Missed   []string `json:"missed"`
+}
+
+func NewProposerRecord(blk Block, missed []string) ProposerRecord {
+	return ProposerRecord{
+		Height:   blk.Height,
+		Proposer: blk.Proposer,
+		Missed:   missed,
+	}
+}
+
+func GetProposerRecordKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", common.BlockPrefixProposerRecord, height)
+}
+
+func (pr ProposerRecord) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetProposerRecordKey(pr.Height)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	} else if exists {
+		return errors.AlreadySaved
+	}
+
+	return st.New(key, pr)
+}
+
+func (pr ProposerRecord) String() string {
+	return string(common.MustMarshalJSON(pr))
+}
+
+func ExistsProposerRecord(st *storage.LevelDBBackend, height uint64) (bool, error) {
+	return st.Has(GetProposerRecordKey(height))
+}
+
+func GetProposerRecord(st *storage.LevelDBBackend, height uint64) (pr ProposerRecord, err error) {
+	err = st.Get(GetProposerRecordKey(height), &pr)
+	return
+}
@@ -0,0 +1,35 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestProposerRecord(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	blk := GetLatestBlock(st)
+
+	exists, err := ExistsProposerRecord(st, blk.Height)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	missed := []string{keypair.Random().Address(), keypair.Random().Address()}
+	pr := NewProposerRecord(blk, missed)
+	require.NoError(t, pr.Save(st))
+	require.Equal(t, errors.AlreadySaved, pr.Save(st))
+
+	exists, err = ExistsProposerRecord(st, blk.Height)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	fetched, err := GetProposerRecord(st, blk.Height)
+	require.NoError(t, err)
+	require.Equal(t, blk.Proposer, fetched.Proposer)
+	require.Equal(t, missed, fetched.Missed)
+}
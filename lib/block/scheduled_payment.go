@@ -0,0 +1,212 @@
+package block
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+)
+
+type ScheduledPaymentState string
+
+const (
+	ScheduledPaymentActive    ScheduledPaymentState = "active"
+	ScheduledPaymentFinished  ScheduledPaymentState = "finished"
+	ScheduledPaymentCancelled ScheduledPaymentState = "cancelled"
+)
+
+// BlockScheduledPayment is the payment registered by
+// `operation.ScheduledPaymentCreate`. The amount of the remaining payments is
+// reserved from the source account and the payment is executed at the block
+// height `Next`. It is kept after it is finished or cancelled, so the same id
+// can not be used again by the source. `HasMemo` is true if the transaction,
+// which created it, has the memo; the target with
+// `common.AccountFlagRequireMemo` does not receive the payment without memo.
+//
+// models
+//  * 'source' and 'id'
+// 	- 'bsp-<BlockScheduledPayment.Source>-<BlockScheduledPayment.ID>': `BlockScheduledPayment`
+//  * 'next block height', 'source' and 'id'; only for the active payment
+// 	- 'bspd-<BlockScheduledPayment.Next>-<BlockScheduledPayment.Source>-<BlockScheduledPayment.ID>': 'bsp-<BlockScheduledPayment.Source>-<BlockScheduledPayment.ID>'
+type BlockScheduledPayment struct {
+	Source    string                `json:"source"`
+	ID        string                `json:"id"`
+	Target    string                `json:"target"`
+	Amount    common.Amount         `json:"amount"`
+	Interval  uint64                `json:"interval"`
+	Next      uint64                `json:"next"`
+	Remaining uint64                `json:"remaining"`
+	State     ScheduledPaymentState `json:"state"`
+	HasMemo   bool                  `json:"has_memo"`
+}
+
+func NewBlockScheduledPayment(source, id, target string, amount common.Amount, start, interval, count uint64) *BlockScheduledPayment {
+	return &BlockScheduledPayment{
+		Source:    source,
+		ID:        id,
+		Target:    target,
+		Amount:    amount,
+		Interval:  interval,
+		Next:      start,
+		Remaining: count,
+		State:     ScheduledPaymentActive,
+	}
+}
+
+func GetBlockScheduledPaymentKey(source, id string) string {
+	return fmt.Sprintf("%s%s", GetBlockScheduledPaymentKeyPrefixSource(source), id)
+}
+
+func GetBlockScheduledPaymentKeyPrefixSource(source string) string {
+	return fmt.Sprintf("%s%s-", common.BlockScheduledPaymentPrefix, source)
+}
+
+func GetBlockScheduledPaymentDueKey(height uint64, source, id string) string {
+	return fmt.Sprintf(
+		"%s%s%s-%s",
+		common.BlockScheduledPaymentDuePrefix,
+		common.EncodeUint64ToByteSlice(height),
+		source,
+		id,
+	)
+}
+
+func (b *BlockScheduledPayment) String() string {
+	return string(common.MustMarshalJSON(b))
+}
+
+// IsActive returns true if the payment is not finished or cancelled yet.
+func (b *BlockScheduledPayment) IsActive() bool {
+	return b.State == ScheduledPaymentActive
+}
+
+// Reserved returns the amount of the remaining payments, which is reserved
+// from the source account.
+func (b *BlockScheduledPayment) Reserved() common.Amount {
+	if !b.IsActive() {
+		return 0
+	}
+
+	amount, _ := b.Amount.MultUint64(b.Remaining)
+	return amount
+}
+
+// Save saves the payment and the due key of it's next payment. If the payment
+// is not active, the due key is not saved.
+func (b *BlockScheduledPayment) Save(st *storage.LevelDBBackend) (err error) {
+	key := GetBlockScheduledPaymentKey(b.Source, b.ID)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil {
+		return
+	}
+
+	if exists {
+		err = st.Set(key, b)
+	} else {
+		err = st.New(key, b)
+	}
+	if err != nil {
+		return
+	}
+
+	if b.IsActive() {
+		err = st.New(GetBlockScheduledPaymentDueKey(b.Next, b.Source, b.ID), key)
+	}
+
+	return
+}
+
+// RemoveDue removes the due key of the next payment. It must be called before
+// `Next` or `State` is changed.
+func (b *BlockScheduledPayment) RemoveDue(st *storage.LevelDBBackend) (err error) {
+	dueKey := GetBlockScheduledPaymentDueKey(b.Next, b.Source, b.ID)
+
+	var exists bool
+	if exists, err = st.Has(dueKey); err != nil || !exists {
+		return
+	}
+
+	return st.Remove(dueKey)
+}
+
+func ExistsBlockScheduledPayment(st *storage.LevelDBBackend, source, id string) (bool, error) {
+	return st.Has(GetBlockScheduledPaymentKey(source, id))
+}
+
+func GetBlockScheduledPayment(st *storage.LevelDBBackend, source, id string) (b *BlockScheduledPayment, err error) {
+	if err = st.Get(GetBlockScheduledPaymentKey(source, id), &b); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetBlockScheduledPaymentsBySource returns the scheduled payments created by
+// source.
+func GetBlockScheduledPaymentsBySource(st *storage.LevelDBBackend, source string, options storage.ListOptions) (func() (*BlockScheduledPayment, bool, []byte), func()) {
+	iterFunc, closeFunc := st.GetIterator(GetBlockScheduledPaymentKeyPrefixSource(source), options)
+
+	return (func() (*BlockScheduledPayment, bool, []byte) {
+			item, hasNext := iterFunc()
+			if !hasNext {
+				return nil, false, item.Key
+			}
+
+			var b BlockScheduledPayment
+			common.MustUnmarshalJSON(item.Value, &b)
+
+			return &b, hasNext, item.Key
+		}), (func() {
+			closeFunc()
+		})
+}
+
+// GetBlockScheduledPaymentsDue returns the active scheduled payments, which
+// are due at the block of given height, ordered by the height of their next
+// payment. The payments of the lower heights, which are not executed yet, are
+// also returned.
+//
+// The iterator does not see the uncommitted changes of batch, so the
+// payments are loaded by the given storage after the iterator is closed.
+func GetBlockScheduledPaymentsDue(st *storage.LevelDBBackend, height uint64) (payments []*BlockScheduledPayment, err error) {
+	var keys []string
+	iterFunc, closeFunc := st.GetIterator(common.BlockScheduledPaymentDuePrefix, nil)
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		b := item.Key[len(common.BlockScheduledPaymentDuePrefix):]
+		if len(b) < common.MaxUintEncodeByte || binary.BigEndian.Uint64(b[:common.MaxUintEncodeByte]) > height {
+			break
+		}
+
+		var key string
+		common.MustUnmarshalJSON(item.Value, &key)
+		keys = append(keys, key)
+	}
+	closeFunc()
+
+	for _, key := range keys {
+		var payment *BlockScheduledPayment
+		if err = st.Get(key, &payment); err != nil {
+			return
+		}
+
+		var exists bool
+		dueKey := GetBlockScheduledPaymentDueKey(payment.Next, payment.Source, payment.ID)
+		if exists, err = st.Has(dueKey); err != nil {
+			return
+		} else if !exists || !payment.IsActive() || payment.Next > height {
+			// already executed or cancelled in the batch
+			continue
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return
+}
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/storage"
+)
+
+func TestBlockScheduledPayment(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	source := keypair.Random().Address()
+	target := keypair.Random().Address()
+
+	payment := NewBlockScheduledPayment(source, "salary", target, common.Amount(100), 10, 5, 3)
+	require.NoError(t, payment.Save(st))
+	require.Equal(t, common.Amount(300), payment.Reserved())
+
+	// the payment of the other source is not included
+	other := NewBlockScheduledPayment(target, "salary", source, common.Amount(100), 12, 0, 1)
+	require.NoError(t, other.Save(st))
+
+	exists, err := ExistsBlockScheduledPayment(st, source, "salary")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	fetched, err := GetBlockScheduledPayment(st, source, "salary")
+	require.NoError(t, err)
+	require.Equal(t, payment, fetched)
+	require.True(t, fetched.IsActive())
+
+	{ // due payments
+		due, err := GetBlockScheduledPaymentsDue(st, 9)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(due))
+
+		due, err = GetBlockScheduledPaymentsDue(st, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(due))
+		require.Equal(t, payment, due[0])
+
+		due, err = GetBlockScheduledPaymentsDue(st, 12)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(due))
+		require.Equal(t, payment, due[0])
+		require.Equal(t, other, due[1])
+	}
+
+	// executed once
+	require.NoError(t, fetched.RemoveDue(st))
+	fetched.Next += fetched.Interval
+	fetched.Remaining--
+	require.NoError(t, fetched.Save(st))
+	require.Equal(t, common.Amount(200), fetched.Reserved())
+
+	{
+		due, err := GetBlockScheduledPaymentsDue(st, 12)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(due))
+		require.Equal(t, other, due[0])
+
+		due, err = GetBlockScheduledPaymentsDue(st, 15)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(due))
+	}
+
+	// cancelled
+	require.NoError(t, fetched.RemoveDue(st))
+	fetched.State = ScheduledPaymentCancelled
+	require.NoError(t, fetched.Save(st))
+	require.Equal(t, common.Amount(0), fetched.Reserved())
+
+	{
+		due, err := GetBlockScheduledPaymentsDue(st, 100)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(due))
+		require.Equal(t, other, due[0])
+	}
+
+	var payments []*BlockScheduledPayment
+	iterFunc, closeFunc := GetBlockScheduledPaymentsBySource(st, source, nil)
+	for {
+		p, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		payments = append(payments, p)
+	}
+	closeFunc()
+	require.Equal(t, 1, len(payments))
+	require.Equal(t, ScheduledPaymentCancelled, payments[0].State)
+	require.Equal(t, uint64(2), payments[0].Remaining)
+}
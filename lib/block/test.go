@@ -5,6 +5,7 @@ import (
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -55,7 +56,7 @@ func MakeTestBlockchain(st *storage.LevelDBBackend) {
 		panic(err)
 	}
 
-	if _, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, conf.NetworkID); err != nil {
+	if _, err := MakeGenesisBlock(st, *genesisAccount, *commonAccount, operation.NewNetworkParameters(), conf.NetworkID); err != nil {
 		panic(err)
 	}
 }
@@ -95,6 +96,13 @@ func (b *BlockOperation) MustSave(st *storage.LevelDBBackend) {
 	}
 }
 
+/// Version of `BlockValidatorSet.Save` that panics on error, usable only in tests
+func (b *BlockValidatorSet) MustSave(st *storage.LevelDBBackend) {
+	if err := b.Save(st); err != nil {
+		panic(err)
+	}
+}
+
 func TestMakeNewBlock(transactions []string) Block {
 	kp := keypair.Random()
 
@@ -26,12 +26,14 @@ type BlockTransaction struct {
 	Hash  string `json:"hash"`
 	Block string/* `Block.Hash` */ `json:"block"`
 
-	SequenceID uint64        `json:"sequence_id"`
-	Signature  string        `json:"signature"`
-	Source     string        `json:"source"`
-	Fee        common.Amount `json:"fee"`
-	Operations []string      `json:"operations"`
-	Amount     common.Amount `json:"amount"`
+	SequenceID uint64            `json:"sequence_id"`
+	Signature  string            `json:"signature"`
+	Source     string            `json:"source"`
+	Fee        common.Amount     `json:"fee"`
+	Operations []string          `json:"operations"`
+	Amount     common.Amount     `json:"amount"`
+	Memo       *transaction.Memo `json:"memo,omitempty"`
+	FeePayer   string            `json:"fee_payer,omitempty"`
 
 	Confirmed string `json:"confirmed"`
 	Created   string `json:"created"`
@@ -57,6 +59,8 @@ func NewBlockTransactionFromTransaction(blockHash string, blockHeight uint64, co
 		Fee:        tx.B.Fee,
 		Operations: opHashes,
 		Amount:     tx.TotalAmount(true),
+		Memo:       tx.B.Memo,
+		FeePayer:   tx.FeePayer(),
 		Confirmed:  confirmed,
 		Created:    tx.H.Created,
 
@@ -130,6 +134,11 @@ func (bt *BlockTransaction) Save(st *storage.LevelDBBackend) (err error) {
 	if err = st.New(bt.NewBlockTransactionKeyByAccount(bt.Source), bt.Hash); err != nil {
 		return
 	}
+	if len(bt.FeePayer) > 0 {
+		if err = st.New(bt.NewBlockTransactionKeyByAccount(bt.FeePayer), bt.Hash); err != nil {
+			return
+		}
+	}
 	if err = st.New(bt.NewBlockTransactionKeyByBlock(bt.Block), bt.Hash); err != nil {
 		return
 	}
@@ -39,6 +39,10 @@ func GetTransactionPoolKey(hash string) string {
 	return fmt.Sprintf("%s%s", common.TransactionPoolPrefix, hash)
 }
 
+func GetTransactionPoolPendingKey(hash string) string {
+	return fmt.Sprintf("%s%s", common.TransactionPoolPendingPrefix, hash)
+}
+
 func (tp TransactionPool) Save(st *storage.LevelDBBackend) (err error) {
 	key := GetTransactionPoolKey(tp.Hash)
 
@@ -115,3 +119,58 @@ func SaveTransactionPool(st *storage.LevelDBBackend, tx transaction.Transaction)
 
 	return
 }
+
+// SavePendingTransactionPool saves the transaction, which is pushed into
+// `transaction.Pool`, and marks it as pending; the pending transactions are
+// restored into `transaction.Pool` after restart.
+func SavePendingTransactionPool(st *storage.LevelDBBackend, tx transaction.Transaction) (tp TransactionPool, err error) {
+	if tp, err = SaveTransactionPool(st, tx); err != nil {
+		return
+	}
+
+	key := GetTransactionPoolPendingKey(tp.Hash)
+
+	var exists bool
+	if exists, err = st.Has(key); exists || err != nil {
+		return
+	}
+
+	if err = st.New(key, tp.Hash); err != nil {
+		return
+	}
+
+	return
+}
+
+// DeletePendingTransactionPool unmarks the pending transaction. The
+// `TransactionPool` of transaction is not removed.
+func DeletePendingTransactionPool(st *storage.LevelDBBackend, hash string) (err error) {
+	key := GetTransactionPoolPendingKey(hash)
+
+	var exists bool
+	if exists, err = st.Has(key); err != nil || !exists {
+		return
+	}
+
+	return st.Remove(key)
+}
+
+// GetPendingTransactionPoolHashes returns the hashes of the pending
+// transactions.
+func GetPendingTransactionPoolHashes(st *storage.LevelDBBackend) (hashes []string) {
+	iterFunc, closeFunc := st.GetIterator(common.TransactionPoolPendingPrefix, nil)
+	defer closeFunc()
+
+	for {
+		item, hasNext := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		var hash string
+		common.MustUnmarshalJSON(item.Value, &hash)
+		hashes = append(hashes, hash)
+	}
+
+	return
+}
@@ -46,3 +46,31 @@ func TestTransactionPool(t *testing.T) {
 		require.Error(t, err, errors.StorageRecordDoesNotExist)
 	}
 }
+
+func TestPendingTransactionPool(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+
+	_, tx := transaction.TestMakeTransaction(conf.NetworkID, 1)
+
+	_, err := SavePendingTransactionPool(st, tx)
+	require.NoError(t, err)
+
+	// saving again is ignored
+	_, err = SavePendingTransactionPool(st, tx)
+	require.NoError(t, err)
+
+	exists, err := ExistsTransactionPool(st, tx.GetHash())
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.Equal(t, []string{tx.GetHash()}, GetPendingTransactionPoolHashes(st))
+
+	// unmark; the TransactionPool is kept
+	require.NoError(t, DeletePendingTransactionPool(st, tx.GetHash()))
+	require.NoError(t, DeletePendingTransactionPool(st, tx.GetHash()))
+	require.Empty(t, GetPendingTransactionPoolHashes(st))
+
+	exists, err = ExistsTransactionPool(st, tx.GetHash())
+	require.NoError(t, err)
+	require.True(t, exists)
+}
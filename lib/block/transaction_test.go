@@ -56,6 +56,52 @@ func TestBlockTransactionSaveAndGet(t *testing.T) {
 	require.Equal(t, len(fetched.Confirmed) > 0, true)
 }
 
+func TestBlockTransactionSaveWithMemo(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kp, tx := transaction.TestMakeTransactionV2(conf.NetworkID, 1)
+	memo := transaction.NewMemo(transaction.MemoID, "1234567890")
+	tx.B.Memo = &memo
+	tx.Sign(kp, conf.NetworkID)
+
+	block := TestMakeNewBlock([]string{tx.GetHash()})
+	bt := NewBlockTransactionFromTransaction(block.Hash, block.Height, block.ProposedTime, tx)
+	require.NoError(t, bt.Save(st))
+
+	fetched, err := GetBlockTransaction(st, bt.Hash)
+	require.NoError(t, err)
+	require.Equal(t, &memo, fetched.Memo)
+}
+
+func TestBlockTransactionSaveWithFeePayer(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kp, tx := transaction.TestMakeTransactionV2(conf.NetworkID, 1)
+	payer := keypair.Random()
+	tx.SignFeePayer(payer, conf.NetworkID)
+
+	block := TestMakeNewBlock([]string{tx.GetHash()})
+	bt := NewBlockTransactionFromTransaction(block.Hash, block.Height, block.ProposedTime, tx)
+	require.NoError(t, bt.Save(st))
+
+	fetched, err := GetBlockTransaction(st, bt.Hash)
+	require.NoError(t, err)
+	require.Equal(t, payer.Address(), fetched.FeePayer)
+
+	// both of source and fee payer have the transaction
+	for _, address := range []string{kp.Address(), payer.Address()} {
+		iterFunc, closeFunc := GetBlockTransactionsByAccount(st, address, nil)
+		fetched, hasNext, _ := iterFunc()
+		closeFunc()
+		require.True(t, hasNext)
+		require.Equal(t, bt.Hash, fetched.Hash)
+	}
+}
+
 func TestBlockTransactionSaveExisting(t *testing.T) {
 	conf := common.NewTestConfig()
 	st := storage.NewTestStorage()
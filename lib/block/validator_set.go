@@ -0,0 +1,133 @@
+package block
+
+import (
+	"fmt"
+	"sort"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// BlockValidatorSet is the validators, which are active from the block of
+// `Height` until the next `BlockValidatorSet`. The first one is saved at
+// genesis from `operation.NetworkParameters.Validators` of genesis block and
+// the next ones are made by `operation.ManageValidator`.
+//
+// The heights of validator sets are kept in one record, because the iterator
+// does not work with the batch storage, which is used to finish the block.
+//
+// models
+//  * 'height'
+// 	- 'bvs-<BlockValidatorSet.Height>': `BlockValidatorSet`
+// 	- 'bvsh': heights of `BlockValidatorSet`
+type BlockValidatorSet struct {
+	Height     uint64   `json:"height"`
+	Validators []string `json:"validators"` // sorted
+}
+
+func NewBlockValidatorSet(height uint64, validators []string) *BlockValidatorSet {
+	vs := &BlockValidatorSet{Height: height, Validators: []string{}}
+	for _, address := range validators {
+		vs.Add(address)
+	}
+
+	return vs
+}
+
+func GetBlockValidatorSetKey(height uint64) string {
+	return fmt.Sprintf("%s%020d", common.BlockPrefixValidatorSet, height)
+}
+
+func (vs *BlockValidatorSet) Has(address string) bool {
+	i := sort.SearchStrings(vs.Validators, address)
+	return i < len(vs.Validators) && vs.Validators[i] == address
+}
+
+func (vs *BlockValidatorSet) Add(address string) {
+	if vs.Has(address) {
+		return
+	}
+
+	vs.Validators = append(vs.Validators, address)
+	sort.Strings(vs.Validators)
+}
+
+func (vs *BlockValidatorSet) Remove(address string) {
+	i := sort.SearchStrings(vs.Validators, address)
+	if i == len(vs.Validators) || vs.Validators[i] != address {
+		return
+	}
+
+	vs.Validators = append(vs.Validators[:i], vs.Validators[i+1:]...)
+}
+
+func (vs *BlockValidatorSet) Save(st *storage.LevelDBBackend) (err error) {
+	var heights []uint64
+	if heights, err = GetBlockValidatorSetHeights(st); err != nil {
+		return
+	}
+
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= vs.Height })
+	if i == len(heights) || heights[i] != vs.Height {
+		heights = append(heights, 0)
+		copy(heights[i+1:], heights[i:])
+		heights[i] = vs.Height
+
+		if len(heights) == 1 {
+			err = st.New(common.BlockPrefixValidatorSetHeights, heights)
+		} else {
+			err = st.Set(common.BlockPrefixValidatorSetHeights, heights)
+		}
+		if err != nil {
+			return
+		}
+
+		return st.New(GetBlockValidatorSetKey(vs.Height), vs)
+	}
+
+	return st.Set(GetBlockValidatorSetKey(vs.Height), vs)
+}
+
+func (vs *BlockValidatorSet) String() string {
+	return string(common.MustMarshalJSON(vs))
+}
+
+func ExistsBlockValidatorSet(st *storage.LevelDBBackend, height uint64) (bool, error) {
+	return st.Has(GetBlockValidatorSetKey(height))
+}
+
+// GetBlockValidatorSetHeights returns the heights of validator sets in
+// ascending order.
+func GetBlockValidatorSetHeights(st *storage.LevelDBBackend) (heights []uint64, err error) {
+	if err = st.Get(common.BlockPrefixValidatorSetHeights, &heights); err != nil {
+		if err == errors.StorageRecordDoesNotExist {
+			err = nil
+		}
+		return
+	}
+
+	return
+}
+
+// GetBlockValidatorSet returns the validator set, which is active at the
+// block of `height`.
+func GetBlockValidatorSet(st *storage.LevelDBBackend, height uint64) (vs *BlockValidatorSet, err error) {
+	var heights []uint64
+	if heights, err = GetBlockValidatorSetHeights(st); err != nil {
+		return
+	}
+
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] > height })
+	if i < 1 {
+		err = errors.BlockValidatorSetDoesNotExists
+		return
+	}
+
+	vs = &BlockValidatorSet{}
+	if err = st.Get(GetBlockValidatorSetKey(heights[i-1]), vs); err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestBlockValidatorSet(t *testing.T) {
+	st := InitTestBlockchain()
+	defer st.Close()
+
+	a, b, c := keypair.Random().Address(), keypair.Random().Address(), keypair.Random().Address()
+
+	{ // not found
+		_, err := GetBlockValidatorSet(st, common.GenesisBlockHeight)
+		require.Equal(t, errors.BlockValidatorSetDoesNotExists, err)
+	}
+
+	genesis := NewBlockValidatorSet(common.GenesisBlockHeight, []string{b, a, b})
+	require.Equal(t, 2, len(genesis.Validators))
+	require.True(t, genesis.Has(a))
+	require.True(t, genesis.Has(b))
+	require.False(t, genesis.Has(c))
+	require.NoError(t, genesis.Save(st))
+
+	later := NewBlockValidatorSet(10, genesis.Validators)
+	later.Add(c)
+	later.Remove(a)
+	require.NoError(t, later.Save(st))
+	require.True(t, genesis.Has(a))
+
+	middle := NewBlockValidatorSet(5, []string{a})
+	require.NoError(t, middle.Save(st))
+
+	heights, err := GetBlockValidatorSetHeights(st)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{common.GenesisBlockHeight, 5, 10}, heights)
+
+	{ // active set by height
+		_, err := GetBlockValidatorSet(st, common.GenesisBlockHeight-1)
+		require.Equal(t, errors.BlockValidatorSetDoesNotExists, err)
+
+		for height, expected := range map[uint64]*BlockValidatorSet{
+			common.GenesisBlockHeight: genesis,
+			4:                         genesis,
+			5:                         middle,
+			9:                         middle,
+			10:                        later,
+			100:                       later,
+		} {
+			vs, err := GetBlockValidatorSet(st, height)
+			require.NoError(t, err)
+			require.Equal(t, expected.Height, vs.Height)
+			require.Equal(t, expected.Validators, vs.Validators)
+		}
+	}
+
+	{ // update
+		middle.Add(b)
+		require.NoError(t, middle.Save(st))
+
+		vs, err := GetBlockValidatorSet(st, 7)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(vs.Validators))
+
+		heights, err := GetBlockValidatorSetHeights(st)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(heights))
+
+		exists, err := ExistsBlockValidatorSet(st, 5)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+}
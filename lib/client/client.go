@@ -18,16 +18,26 @@ import (
 const (
 	UrlPrefixForAPIV1 = "/api/v1"
 
-	UrlAccountTransactions   = "/accounts/{id}/transactions"
-	UrlAccount               = "/accounts/{id}"
-	UrlAccountOperations     = "/accounts/{id}/operations"
-	UrlAccountFrozenAccounts = "/accounts/{id}/frozen-accounts"
-	UrlFrozenAccounts        = "/frozen-accounts"
-	UrlTransactions          = "/transactions"
-	UrlTransactionByHash     = "/transactions/{id}"
-	UrlTransactionStatus     = "/transactions/{id}/status"
-	UrlTransactionOperations = "/transactions/{id}/operations"
-	UrlSubscribe             = "/subscribe"
+	UrlAccountTransactions      = "/accounts/{id}/transactions"
+	UrlAccount                  = "/accounts/{id}"
+	UrlAccountOperations        = "/accounts/{id}/operations"
+	UrlAccountFrozenAccounts    = "/accounts/{id}/frozen-accounts"
+	UrlAccountData              = "/accounts/{id}/data"
+	UrlAccountScheduledPayments = "/accounts/{id}/scheduled-payments"
+	UrlAccountTrustlines        = "/accounts/{id}/trustlines"
+	UrlFrozenAccounts           = "/frozen-accounts"
+	UrlTransactions             = "/transactions"
+	UrlTransactionsPending      = "/transactions/pending"
+	UrlTransactionByHash        = "/transactions/{id}"
+	UrlTransactionStatus        = "/transactions/{id}/status"
+	UrlTransactionOperations    = "/transactions/{id}/operations"
+	UrlSubscribe                = "/subscribe"
+	UrlFeeStats                 = "/fee-stats"
+	UrlCongressMembers          = "/congress/members"
+	UrlCongressVotings          = "/congress/votings"
+	UrlCongressVotingVotes      = "/congress/votings/{id}/votes"
+	UrlCongressResults          = "/congress/results"
+	UrlAssets                   = "/assets"
 )
 
 type QueryKey string
@@ -41,6 +51,7 @@ const (
 	QueryOrder  QueryKey = "reverse"
 	QueryCursor QueryKey = "cursor"
 	QueryType   QueryKey = "type"
+	QuerySource QueryKey = "source"
 )
 
 type Q struct {
@@ -65,6 +76,8 @@ func (qs Queries) toQueryString() string {
 			urlValues.Add(QueryCursor.String(), q.Value)
 		case QueryType:
 			urlValues.Add(QueryType.String(), q.Value)
+		case QuerySource:
+			urlValues.Add(QuerySource.String(), q.Value)
 
 		}
 	}
@@ -201,6 +214,69 @@ func (c *Client) LoadAFrozenAccounts(id string, queries ...Q) (fPage FrozenAccou
 	return
 }
 
+func (c *Client) LoadAccountData(id string, queries ...Q) (dPage AccountDataPage, err error) {
+	url := strings.Replace(UrlAccountData, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &dPage)
+	return
+}
+
+func (c *Client) LoadAccountScheduledPayments(id string, queries ...Q) (sPage ScheduledPaymentsPage, err error) {
+	url := strings.Replace(UrlAccountScheduledPayments, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &sPage)
+	return
+}
+
+func (c *Client) LoadAccountTrustlines(id string, queries ...Q) (tPage TrustlinesPage, err error) {
+	url := strings.Replace(UrlAccountTrustlines, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &tPage)
+	return
+}
+
+func (c *Client) LoadAssets(queries ...Q) (aPage AssetsPage, err error) {
+	url := UrlAssets
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &aPage)
+	return
+}
+
+func (c *Client) LoadFeeStats(queries ...Q) (feeStats FeeStats, err error) {
+	url := UrlFeeStats
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, nil, &feeStats)
+	return
+}
+
+func (c *Client) LoadCongressMembers(queries ...Q) (mPage CongressMembersPage, err error) {
+	url := UrlCongressMembers
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &mPage)
+	return
+}
+
+func (c *Client) LoadCongressVotings(queries ...Q) (vPage CongressVotingsPage, err error) {
+	url := UrlCongressVotings
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &vPage)
+	return
+}
+
+func (c *Client) LoadCongressVotes(id string, queries ...Q) (vPage CongressVotesPage, err error) {
+	url := strings.Replace(UrlCongressVotingVotes, "{id}", id, -1)
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &vPage)
+	return
+}
+
+func (c *Client) LoadCongressResults(queries ...Q) (oPage OperationsPage, err error) {
+	url := UrlCongressResults
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &oPage)
+	return
+}
+
 func (c *Client) LoadTransaction(id string, queries ...Q) (transaction Transaction, err error) {
 	url := strings.Replace(UrlTransactionByHash, "{id}", id, -1)
 	url += Queries(queries).toQueryString()
@@ -222,6 +298,15 @@ func (c *Client) LoadTransactions(queries ...Q) (tPage TransactionsPage, err err
 	return
 }
 
+// LoadPendingTransactions returns the transactions, which are not included in
+// block yet; `QuerySource` filters them by source.
+func (c *Client) LoadPendingTransactions(queries ...Q) (tPage PendingTransactionsPage, err error) {
+	url := UrlTransactionsPending
+	url += Queries(queries).toQueryString()
+	err = c.getResponse(url, http.Header{}, &tPage)
+	return
+}
+
 func (c *Client) LoadTransactionsByAccount(id string, queries ...Q) (tPage TransactionsPage, err error) {
 	url := strings.Replace(UrlAccountTransactions, "{id}", id, -1)
 	url += Queries(queries).toQueryString()
@@ -443,3 +528,26 @@ func (c *Client) StreamTransactionStatus(ctx context.Context, id string, handler
 	}
 	return c.stream(ctx, url, nil, handlerFunc)
 }
+
+// StreamPendingTransactions streams the new pending transactions. If source
+// is not empty, only the transactions of source are streamed.
+func (c *Client) StreamPendingTransactions(ctx context.Context, source string, handler func(PendingTransaction)) (err error) {
+	url := UrlTransactionsPending
+	if len(source) > 0 {
+		url += Queries{{Key: QuerySource, Value: source}}.toQueryString()
+	}
+	handlerFunc := func(b []byte) (err error) {
+		var v PendingTransaction
+		err = json.Unmarshal(b, &v)
+		if err != nil {
+			return err
+		}
+		// the first page of pending transactions is skipped
+		if len(v.Hash) < 1 {
+			return nil
+		}
+		handler(v)
+		return nil
+	}
+	return c.stream(ctx, url, nil, handlerFunc)
+}
@@ -21,12 +21,140 @@ type Account struct {
 		Self         Link `json:"self"`
 		Transactions Link `json:"transactions"`
 		Operations   Link `json:"operations"`
+		Data         Link `json:"data"`
+		Trustlines   Link `json:"trustlines"`
 	} `json:"_links"`
 
-	Address    string `json:"address"`
-	SequenceID uint64 `json:"sequence_id"`
-	Balance    string `json:"balance"`
-	Linked     string `json:"linked"`
+	Address     string            `json:"address"`
+	SequenceID  uint64            `json:"sequence_id"`
+	Balance     string            `json:"balance"`
+	Linked      string            `json:"linked"`
+	Signers     []common.Signer   `json:"signers"`
+	Thresholds  common.Thresholds `json:"thresholds"`
+	DataEntries uint64            `json:"data_entries"`
+	Trustlines  uint64            `json:"trustlines"`
+	Flags       []string          `json:"flags"`
+	AllowList   []string          `json:"allow_list"`
+}
+
+type AccountData struct {
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"_links"`
+
+	Address string `json:"address"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+type AccountDataPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []AccountData `json:"records"`
+	} `json:"_embedded"`
+}
+
+type ScheduledPayment struct {
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"_links"`
+
+	Source    string        `json:"source"`
+	ID        string        `json:"id"`
+	Target    string        `json:"target"`
+	Amount    common.Amount `json:"amount"`
+	Interval  uint64        `json:"interval"`
+	Next      uint64        `json:"next"`
+	Remaining uint64        `json:"remaining"`
+	Reserved  common.Amount `json:"reserved"`
+	State     string        `json:"state"`
+}
+
+type ScheduledPaymentsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []ScheduledPayment `json:"records"`
+	} `json:"_embedded"`
+}
+
+type Trustline struct {
+	Links struct {
+		Self   Link `json:"self"`
+		Issuer Link `json:"issuer"`
+	} `json:"_links"`
+
+	Address string        `json:"address"`
+	Asset   common.Asset  `json:"asset"`
+	Balance common.Amount `json:"balance"`
+	Limit   common.Amount `json:"limit"`
+}
+
+type TrustlinesPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Trustline `json:"records"`
+	} `json:"_embedded"`
+}
+
+type Asset struct {
+	Links struct {
+		Self   Link `json:"self"`
+		Issuer Link `json:"issuer"`
+	} `json:"_links"`
+
+	Code   string        `json:"code"`
+	Issuer string        `json:"issuer"`
+	Issued common.Amount `json:"issued"`
+}
+
+type AssetsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Asset `json:"records"`
+	} `json:"_embedded"`
+}
+
+type FeeStats struct {
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"_links"`
+
+	LastBlock       uint64 `json:"last_block"`
+	Blocks          int    `json:"blocks"`
+	Transactions    int    `json:"transactions"`
+	BaseFee         string `json:"base_fee"`
+	FeePerOperation struct {
+		Min  string `json:"min"`
+		Max  string `json:"max"`
+		Mode string `json:"mode"`
+		P10  string `json:"p10"`
+		P20  string `json:"p20"`
+		P30  string `json:"p30"`
+		P40  string `json:"p40"`
+		P50  string `json:"p50"`
+		P60  string `json:"p60"`
+		P70  string `json:"p70"`
+		P80  string `json:"p80"`
+		P90  string `json:"p90"`
+		P95  string `json:"p95"`
+		P99  string `json:"p99"`
+	} `json:"fee_per_operation"`
 }
 
 type FrozenAccount struct {
@@ -73,6 +201,13 @@ type Transaction struct {
 	SequenceID     uint64 `json:"sequence_id"`
 	Created        string `json:"created"`
 	OperationCount uint64 `json:"operation_count"`
+	Memo           *Memo  `json:"memo,omitempty"`
+	FeePayer       string `json:"fee_payer,omitempty"`
+}
+
+type Memo struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 type TransactionPost struct {
@@ -105,6 +240,33 @@ type TransactionsPage struct {
 	} `json:"_embedded"`
 }
 
+type PendingTransaction struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+		Status  Link `json:"status"`
+	} `json:"_links"`
+	Hash           string `json:"hash"`
+	Source         string `json:"source"`
+	Fee            string `json:"fee"`
+	SequenceID     uint64 `json:"sequence_id"`
+	Received       string `json:"received"`
+	Age            int64  `json:"age"`
+	OperationCount uint64 `json:"operation_count"`
+	FeePayer       string `json:"fee_payer,omitempty"`
+}
+
+type PendingTransactionsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []PendingTransaction `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Operation struct {
 	Links struct {
 		Self        Link `json:"self"`
@@ -163,6 +325,85 @@ type CongressVotingResult struct {
 	CongressVotingHash string `json:"congress_voting_hash"`
 }
 
+type CongressMember struct {
+	Links struct {
+		Self    Link `json:"self"`
+		Account Link `json:"account"`
+	} `json:"_links"`
+
+	Address string `json:"address"`
+}
+
+type CongressMembersPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []CongressMember `json:"records"`
+	} `json:"_embedded"`
+}
+
+// CongressVotingRecord is the congress voting with the tally counted by the
+// node.
+type CongressVotingRecord struct {
+	Links struct {
+		Self        Link `json:"self"`
+		Votes       Link `json:"votes"`
+		Transaction Link `json:"transaction"`
+	} `json:"_links"`
+
+	Hash           string `json:"hash"`
+	TxHash         string `json:"tx_hash"`
+	BlockHeight    uint64 `json:"block_height"`
+	Contract       string `json:"contract"`
+	Start          uint64 `json:"start"`
+	End            uint64 `json:"end"`
+	FundingAddress string `json:"funding_address"`
+	Amount         string `json:"amount"`
+	Result         struct {
+		Count uint64 `json:"count"`
+		Yes   uint64 `json:"yes"`
+		No    uint64 `json:"no"`
+		ABS   uint64 `json:"abs"`
+	} `json:"result"`
+	Closed bool `json:"closed"`
+	Passed bool `json:"passed"`
+}
+
+type CongressVotingsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []CongressVotingRecord `json:"records"`
+	} `json:"_embedded"`
+}
+
+type CongressVote struct {
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"_links"`
+
+	CongressVotingHash string `json:"congress_voting_hash"`
+	Voter              string `json:"voter"`
+	Vote               string `json:"vote"`
+}
+
+type CongressVotesPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []CongressVote `json:"records"`
+	} `json:"_embedded"`
+}
+
 type CreateAccount struct {
 	Target string `json:"target"`
 	Amount []byte `json:"amount"`
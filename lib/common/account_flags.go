@@ -0,0 +1,62 @@
+package common
+
+// AccountFlags is the policy of an account about the incoming operations. It
+// is set by `operation.SetOptions`.
+type AccountFlags uint32
+
+const (
+	// AccountFlagRequireMemo rejects the payment without memo to the
+	// account.
+	AccountFlagRequireMemo AccountFlags = 1 << iota
+	// AccountFlagBlockIncoming rejects the payment to the account from the
+	// source, which is not in the allow list of the account.
+	AccountFlagBlockIncoming
+	// AccountFlagAuthRequired rejects the account creation linked to the
+	// account by the source, which is not in the allow list of the account.
+	AccountFlagAuthRequired
+
+	// AllAccountFlags is the all known flags.
+	AllAccountFlags = AccountFlagRequireMemo | AccountFlagBlockIncoming | AccountFlagAuthRequired
+)
+
+var accountFlagNames = []struct {
+	flag AccountFlags
+	name string
+}{
+	{AccountFlagRequireMemo, "require-memo"},
+	{AccountFlagBlockIncoming, "block-incoming"},
+	{AccountFlagAuthRequired, "auth-required"},
+}
+
+// Has returns true if all the given flags are set.
+func (f AccountFlags) Has(flag AccountFlags) bool {
+	return f&flag == flag
+}
+
+// IsValid returns false if the unknown flag is set.
+func (f AccountFlags) IsValid() bool {
+	return f&^AllAccountFlags == 0
+}
+
+// Names returns the names of the flags, which are set.
+func (f AccountFlags) Names() []string {
+	names := []string{}
+	for _, n := range accountFlagNames {
+		if f.Has(n.flag) {
+			names = append(names, n.name)
+		}
+	}
+
+	return names
+}
+
+// ParseAccountFlag returns the flag of the given name.
+func ParseAccountFlag(name string) (AccountFlags, bool) {
+	for _, n := range accountFlagNames {
+		if n.name == name {
+			return n.flag, true
+		}
+	}
+
+	return 0, false
+}
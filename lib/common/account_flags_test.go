@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountFlags(t *testing.T) {
+	var flags AccountFlags
+	require.True(t, flags.IsValid())
+	require.Equal(t, []string{}, flags.Names())
+
+	flags = AccountFlagRequireMemo | AccountFlagAuthRequired
+	require.True(t, flags.IsValid())
+	require.True(t, flags.Has(AccountFlagRequireMemo))
+	require.False(t, flags.Has(AccountFlagBlockIncoming))
+	require.False(t, flags.Has(AccountFlagRequireMemo|AccountFlagBlockIncoming))
+	require.Equal(t, []string{"require-memo", "auth-required"}, flags.Names())
+
+	require.False(t, (AllAccountFlags + 1).IsValid())
+
+	for _, name := range AllAccountFlags.Names() {
+		flag, found := ParseAccountFlag(name)
+		require.True(t, found)
+		require.True(t, AllAccountFlags.Has(flag))
+	}
+	_, found := ParseAccountFlag("showmethemoney")
+	require.False(t, found)
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+// Asset is the token issued by the `Issuer` account. The native coin is not
+// an `Asset`; it is kept in the balance of account.
+type Asset struct {
+	Code   string `json:"code"`
+	Issuer string `json:"issuer"`
+}
+
+func NewAsset(code, issuer string) Asset {
+	return Asset{
+		Code:   code,
+		Issuer: issuer,
+	}
+}
+
+// ParseAsset parses the asset in the format of `Asset.String`,
+// '<code>:<issuer>'.
+func ParseAsset(s string) (a Asset, err error) {
+	i := strings.Index(s, ":")
+	if i < 0 {
+		err = errors.InvalidAsset
+		return
+	}
+
+	a = NewAsset(s[:i], s[i+1:])
+	err = a.IsWellFormed()
+
+	return
+}
+
+// IsWellFormed checks the `Code` by `IsValidAssetCode`. The `Issuer` is
+// checked by the operations.
+func (a Asset) IsWellFormed() error {
+	if !IsValidAssetCode(a.Code) || len(a.Issuer) < 1 {
+		return errors.InvalidAsset
+	}
+
+	return nil
+}
+
+// IsValidAssetCode checks the code of asset is alphanumeric and not longer
+// than `MaxAssetCodeLength`.
+func IsValidAssetCode(code string) bool {
+	if len(code) < 1 || len(code) > MaxAssetCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Implement `fmt.Stringer`
+func (a Asset) String() string {
+	return fmt.Sprintf("%s:%s", a.Code, a.Issuer)
+}
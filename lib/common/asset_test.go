@@ -0,0 +1,24 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestParseAsset(t *testing.T) {
+	issuer := keypair.Random().Address()
+
+	asset, err := ParseAsset("POINT:" + issuer)
+	require.NoError(t, err)
+	require.Equal(t, NewAsset("POINT", issuer), asset)
+	require.Equal(t, "POINT:"+issuer, asset.String())
+
+	for _, s := range []string{"POINT", "POINT:", ":" + issuer, "POINT!:" + issuer} {
+		_, err = ParseAsset(s)
+		require.Equal(t, errors.InvalidAsset, err, "asset: %s", s)
+	}
+}
@@ -20,9 +20,33 @@ type Config struct {
 	TxPoolClientLimit int
 	TxPoolNodeLimit   int
 
+	// TxPoolSourceLimit is the maximum number of transactions of one source
+	// in `transaction.Pool`, and `TxPoolTTL` is the time, how long the
+	// transaction can stay in it; 0 means no limit.
+	TxPoolSourceLimit int
+	TxPoolTTL         time.Duration
+
 	NetworkID      []byte
 	InitialBalance Amount
 
+	// ProposerSelector is the name of `consensus.ProposerSelector`; every
+	// validator must use the same one.
+	ProposerSelector string
+
+	// TxV2ActivationHeight is the upgrade height of
+	// `common.TransactionVersionV2` for the network, which genesis does not
+	// have it; every validator must use the same one. 0 means it is not
+	// activated. See `operation.NetworkParameters.Upgrade`.
+	TxV2ActivationHeight uint64
+	// CertificateActivationHeight is the upgrade height, from which the block
+	// must have the certificate; 0 means it is not required.
+	CertificateActivationHeight uint64
+	// ProposerMissLimit is the upgrade of
+	// `operation.NetworkParameters.ProposerMissLimit`, which is applied from
+	// `ProposerMissLimitActivationHeight`; 0 means no limit.
+	ProposerMissLimit                 uint64
+	ProposerMissLimitActivationHeight uint64
+
 	// Those fields are not consensus-related
 	RateLimitRuleAPI  RateLimitRule
 	RateLimitRuleNode RateLimitRule
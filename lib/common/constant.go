@@ -11,6 +11,15 @@ const (
 	// transaction will fail validation.
 	BaseFee Amount = 10000
 
+	// ReplaceByFeeRate is the minimum increase of fee per operation in
+	// percent, which the transaction must pay to replace the pending
+	// transaction of same source and sequence id in the pool.
+	ReplaceByFeeRate uint64 = 10
+
+	// FeeStatsBlocks is the number of the latest blocks, which are used to
+	// calculate the fee statistics.
+	FeeStatsBlocks uint64 = 5
+
 	// BaseReserve is minimum amount of balance for new account. By default, it
 	// is `0.1` BOS.
 	BaseReserve Amount = 1000000
@@ -18,12 +27,61 @@ const (
 	// FrozenFee is a special transaction fee about freezing, and unfreezing.
 	FrozenFee Amount = 0
 
+	// MaxSignersInAccount is the maximum number of signers, which one account
+	// can have.
+	MaxSignersInAccount int = 20
+
+	// MaxMemoTextLength is the maximum length of text memo of transaction in
+	// bytes.
+	MaxMemoTextLength int = 64
+
+	// MaxDataKeyLength is the maximum length of the key of account data entry
+	// in bytes.
+	MaxDataKeyLength int = 64
+
+	// MaxDataValueLength is the maximum length of the value of account data
+	// entry in bytes.
+	MaxDataValueLength int = 64
+
+	// MaxHashLockPreimageLength is the maximum length of the preimage of hash
+	// lock in bytes.
+	MaxHashLockPreimageLength int = 64
+
+	// MaxAllowListInAccount is the maximum number of addresses in the allow
+	// list of account.
+	MaxAllowListInAccount int = 20
+
+	// MaxAssetCodeLength is the maximum length of the code of asset.
+	MaxAssetCodeLength int = 12
+
+	// MaxScheduledPaymentIDLength is the maximum length of the id of
+	// scheduled payment in bytes.
+	MaxScheduledPaymentIDLength int = 64
+
+	// MaxScheduledPaymentsInBlock is the maximum number of the scheduled
+	// payments, which are executed in one block; the payments over it are
+	// executed in the next blocks.
+	MaxScheduledPaymentsInBlock int = 100
+
 	// GenesisBlockHeight set the block height of genesis block
 	GenesisBlockHeight uint64 = 1
 
 	// FirstProposedBlockHeight is used for calculating block time
 	FirstProposedBlockHeight uint64 = 2
 
+	// DefaultTxV2ActivationHeight is the default height of block, from which
+	// `TransactionVersionV2` is accepted; 0 means it is not activated.
+	DefaultTxV2ActivationHeight uint64 = 0
+
+	// DefaultCertificateActivationHeight is the default height of block,
+	// from which the block must have the certificate; 0 means the
+	// certificate is not required.
+	DefaultCertificateActivationHeight uint64 = 0
+
+	// DefaultProposerMissLimit is the default number of the missed proposals,
+	// by which the validator is not selected as proposer; 0 means no limit.
+	DefaultProposerMissLimit uint64 = 0
+
 	// GenesisBlockConfirmedTime is the time for the confirmed time of genesis
 	// block. This time is of the first commit of SEBAK.
 	GenesisBlockConfirmedTime string = "2018-04-17T5:07:31.000000000Z"
@@ -41,9 +99,23 @@ const (
 	HTTPCacheRedisAdapterName  = "redis"
 	HTTPCachePoolSize          = 10000
 
+	// ProposerSelectorSequential selects the proposer in order of validators
+	// and ProposerSelectorRandom selects it randomly, but deterministically
+	// from the previous block hash.
+	ProposerSelectorSequential = "sequential"
+	ProposerSelectorRandom     = "random"
+
 	// DefaultTxPoolLimit is the default tx pool limit.
 	DefaultTxPoolLimit int = 1000000
 
+	// DefaultTxPoolSourceLimit is the default maximum number of transactions
+	// of one source in tx pool.
+	DefaultTxPoolSourceLimit int = 100
+
+	// DefaultTxPoolTTL is the default time, how long the transaction can stay
+	// in tx pool.
+	DefaultTxPoolTTL = 1 * time.Hour
+
 	// DefaultOperationsInTransactionLimit is the default maximum number of
 	// operations in one transaction.
 	DefaultOperationsInTransactionLimit int = 1000
@@ -98,6 +170,11 @@ var (
 		HTTPCacheRedisAdapterName:  true,
 		"":                         true, // default value is nop cache
 	}
+	ProposerSelectorNames = map[string]bool{
+		ProposerSelectorSequential: true,
+		ProposerSelectorRandom:     true,
+		"":                         true, // default value is sequential
+	}
 	DefaultJSONRPCBindURL string = "http://127.0.0.1:54321/jsonrpc" // JSONRPC only can be accessed from localhost
 
 	// MaxTimeDiffAllow is the allowed difference of node time. The default
@@ -36,6 +36,9 @@ type Decoder = rlp.Decoder
 // Argument to the `Decoder.Decode` method
 type RLPStream = rlp.Stream
 
+// RLPEOL is returned by `RLPStream` at the end of the current list
+var RLPEOL = rlp.EOL
+
 // Encode the provided value
 // It is exposed here as it is useful for recursive calls
 // by types implementing `Encoder`
@@ -12,10 +12,29 @@ const (
 	BallotMessage      MessageType = "ballot"
 
 	TransactionVersionV1 = "1"
+	// TransactionVersionV2 can have the time bounds, memo and fee payer in
+	// `transaction.Body` and the signatures of the other signers in
+	// `transaction.Header`. It is accepted from
+	// `operation.NetworkParameters.TxV2ActivationHeight`.
+	TransactionVersionV2 = "2"
 	BallotVersionV1      = "1"
 	DiscoveryVersionV1   = "1"
 )
 
+// TransactionVersions is the known versions of transaction in order.
+var TransactionVersions = []string{TransactionVersionV1, TransactionVersionV2}
+
+// IsKnownTransactionVersion checks the version is in `TransactionVersions`.
+func IsKnownTransactionVersion(version string) bool {
+	for _, v := range TransactionVersions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
 type MessageType string
 
 func (t MessageType) String() string {
@@ -4,6 +4,9 @@ const (
 	BlockPrefixHash                       = string(0x00)
 	BlockPrefixConfirmed                  = string(0x01)
 	BlockPrefixHeight                     = string(0x02)
+	BlockPrefixProposerRecord             = string(0x03)
+	BlockPrefixValidatorSet               = string(0x04)
+	BlockPrefixValidatorSetHeights        = string(0x05)
 	BlockTransactionPrefixHash            = string(0x10)
 	BlockTransactionPrefixSource          = string(0x11)
 	BlockTransactionPrefixConfirmed       = string(0x12)
@@ -20,10 +23,24 @@ const (
 	BlockOperationPrefixCreateFrozen      = string(0x28)
 	BlockOperationPrefixFrozenLinked      = string(0x29)
 	BlockOperationPrefixBlockHeight       = string(0x2A)
+	BlockOperationPrefixType              = string(0x2B)
 	BlockAccountPrefixAddress             = string(0x30)
 	BlockAccountPrefixCreated             = string(0x31)
 	BlockAccountSequenceIDPrefix          = string(0x32)
 	BlockAccountSequenceIDByAddressPrefix = string(0x33)
+	BlockAccountDataPrefix                = string(0x34)
+	BlockHashLockPrefix                   = string(0x35)
+	BlockCongressMemberPrefix             = string(0x36)
+	BlockCongressVotePrefix               = string(0x37)
+	BlockCongressVotingTallyPrefix        = string(0x38)
+	BlockAccountKeysPrefix                = string(0x39)
+	BlockScheduledPaymentPrefix           = string(0x3A)
+	BlockScheduledPaymentDuePrefix        = string(0x3B)
+	BlockTrustlinePrefix                  = string(0x3C)
+	BlockAssetPrefix                      = string(0x3D)
+	BlockCertificatePrefix                = string(0x3E)
+	EvidencePrefix                        = string(0x3F)
 	TransactionPoolPrefix                 = string(0x40)
+	TransactionPoolPendingPrefix          = string(0x41)
 	InternalPrefix                        = string(0x50) // internal data
 )
@@ -0,0 +1,59 @@
+package common
+
+// ThresholdLevel is the security level which an operation requires from the
+// signatures of a transaction.
+type ThresholdLevel uint8
+
+const (
+	ThresholdLow ThresholdLevel = iota
+	ThresholdMedium
+	ThresholdHigh
+)
+
+// Signer is the address which can sign the transactions of an account with
+// it's `Weight`.
+type Signer struct {
+	Address string `json:"address"`
+	Weight  uint32 `json:"weight"`
+}
+
+// Thresholds is the minimum sum of the weights of signers for each
+// `ThresholdLevel`.
+type Thresholds struct {
+	Low    uint32 `json:"low"`
+	Medium uint32 `json:"medium"`
+	High   uint32 `json:"high"`
+}
+
+// Get returns the threshold of the given level. The threshold is at least 1,
+// so at least one valid signature is always needed.
+func (t Thresholds) Get(level ThresholdLevel) uint32 {
+	var threshold uint32
+	switch level {
+	case ThresholdLow:
+		threshold = t.Low
+	case ThresholdMedium:
+		threshold = t.Medium
+	default:
+		threshold = t.High
+	}
+
+	if threshold < 1 {
+		return 1
+	}
+
+	return threshold
+}
+
+// Max returns the biggest threshold.
+func (t Thresholds) Max() uint32 {
+	max := t.Get(ThresholdLow)
+	if m := t.Get(ThresholdMedium); m > max {
+		max = m
+	}
+	if h := t.Get(ThresholdHigh); h > max {
+		max = h
+	}
+
+	return max
+}
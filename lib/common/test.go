@@ -26,9 +26,13 @@ func NewTestConfig() Config {
 
 	p.NetworkID = []byte("sebak-unittest")
 	p.InitialBalance = MaximumBalance
+	p.TxV2ActivationHeight = FirstProposedBlockHeight
+	p.CertificateActivationHeight = FirstProposedBlockHeight
 
 	p.TxPoolClientLimit = DefaultTxPoolLimit
 	p.TxPoolNodeLimit = 0 // unlimited
+	p.TxPoolSourceLimit = DefaultTxPoolSourceLimit
+	p.TxPoolTTL = DefaultTxPoolTTL
 
 	p.RateLimitRuleAPI = NewRateLimitRule(RateLimitAPI)
 	p.RateLimitRuleNode = NewRateLimitRule(RateLimitNode)
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"math/big"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+// Vesting is the release schedule of the account created by
+// `operation.CreateVestingAccount`. Nothing of `Total` is vested before the
+// block height `Cliff`; from `Cliff` it is vested linearly and the whole
+// `Total` is vested at the block height `End`.
+type Vesting struct {
+	Cliff uint64 `json:"cliff"`
+	End   uint64 `json:"end"`
+	Total Amount `json:"total"`
+}
+
+func NewVesting(cliff, end uint64, total Amount) Vesting {
+	return Vesting{
+		Cliff: cliff,
+		End:   end,
+		Total: total,
+	}
+}
+
+func (v Vesting) IsWellFormed() error {
+	if v.End < 1 || v.Cliff > v.End {
+		return errors.InvalidVesting
+	}
+	if v.Total < 1 {
+		return errors.InvalidVesting
+	}
+
+	return nil
+}
+
+// Vested returns the amount, which is vested at the block of height.
+func (v Vesting) Vested(height uint64) Amount {
+	if height < v.Cliff {
+		return 0
+	}
+	if height >= v.End {
+		return v.Total
+	}
+
+	vested := new(big.Int).SetUint64(uint64(v.Total))
+	vested.Mul(vested, new(big.Int).SetUint64(height-v.Cliff))
+	vested.Div(vested, new(big.Int).SetUint64(v.End-v.Cliff))
+
+	return Amount(vested.Uint64())
+}
+
+// Locked returns the amount, which is not vested yet at the block of height.
+func (v Vesting) Locked(height uint64) Amount {
+	return v.Total - v.Vested(height)
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestVesting(t *testing.T) {
+	v := NewVesting(10, 20, Amount(1000))
+	require.NoError(t, v.IsWellFormed())
+
+	require.Equal(t, Amount(0), v.Vested(0))
+	require.Equal(t, Amount(0), v.Vested(9))
+	require.Equal(t, Amount(0), v.Vested(10))
+	require.Equal(t, Amount(100), v.Vested(11))
+	require.Equal(t, Amount(500), v.Vested(15))
+	require.Equal(t, Amount(1000), v.Vested(20))
+	require.Equal(t, Amount(1000), v.Vested(100))
+
+	require.Equal(t, Amount(1000), v.Locked(9))
+	require.Equal(t, Amount(500), v.Locked(15))
+	require.Equal(t, Amount(0), v.Locked(20))
+
+	{ // without cliff
+		v := NewVesting(0, 3, Amount(10))
+		require.Equal(t, Amount(3), v.Vested(1))
+		require.Equal(t, Amount(6), v.Vested(2))
+		require.Equal(t, Amount(10), v.Vested(3))
+	}
+
+	{ // the large amount does not overflow
+		v := NewVesting(0, 1000, MaximumBalance)
+		require.Equal(t, MaximumBalance/2, v.Vested(500))
+	}
+
+	require.Equal(t, errors.InvalidVesting, NewVesting(0, 0, Amount(10)).IsWellFormed())
+	require.Equal(t, errors.InvalidVesting, NewVesting(20, 10, Amount(10)).IsWellFormed())
+	require.Equal(t, errors.InvalidVesting, NewVesting(0, 10, Amount(0)).IsWellFormed())
+}
@@ -13,6 +13,7 @@ import (
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -26,6 +27,7 @@ type ISAAC struct {
 	connectionManager   network.ConnectionManager
 	storage             *storage.LevelDBBackend
 	proposerSelector    ProposerSelector
+	params              operation.NetworkParameters
 	log                 logging.Logger
 	policy              voting.ThresholdPolicy
 	syncer              SyncController
@@ -39,17 +41,31 @@ type ISAAC struct {
 }
 
 // ISAAC should know network.ConnectionManager
-// because the ISAAC uses connected validators when calculating proposer
+// because the ISAAC uses connected validators when calculating proposer.
+// The `operation.NetworkParameters` of genesis block is loaded once here and
+// upgraded by `conf`.
 func NewISAAC(node *node.LocalNode, p voting.ThresholdPolicy,
 	cm network.ConnectionManager, st *storage.LevelDBBackend, conf common.Config, syncer SyncController) (is *ISAAC, err error) {
 
+	var params operation.NetworkParameters
+	if params, err = block.GetNetworkParameters(st); err != nil {
+		return
+	}
+	params = params.Upgrade(conf)
+
+	var proposerSelector ProposerSelector
+	if proposerSelector, err = NewNetworkProposerSelector(conf, params, cm, st); err != nil {
+		return
+	}
+
 	is = &ISAAC{
 		Node:              node,
 		policy:            p,
 		RunningRounds:     map[string]*RunningRound{},
 		connectionManager: cm,
 		storage:           st,
-		proposerSelector:  SequentialSelector{cm},
+		proposerSelector:  proposerSelector,
+		params:            params,
 		Conf:              conf,
 		log:               log.New(logging.Ctx{"node": node.Alias()}),
 		syncer:            syncer,
@@ -73,6 +89,11 @@ func (is *ISAAC) LatestVotingBasis() voting.Basis {
 	return is.latestVotingBasis
 }
 
+// NetworkParameters returns the `operation.NetworkParameters` of network.
+func (is *ISAAC) NetworkParameters() operation.NetworkParameters {
+	return is.params
+}
+
 func (is *ISAAC) SetProposerSelector(p ProposerSelector) {
 	is.proposerSelector = p
 }
@@ -85,6 +106,12 @@ func (is *ISAAC) SelectProposer(blockHeight uint64, round uint64) string {
 	return is.proposerSelector.Select(blockHeight, round)
 }
 
+// NewProposerRecord makes the `block.ProposerRecord` of the new block by the
+// proposer selector; see `NewProposerRecord`.
+func (is *ISAAC) NewProposerRecord(blk block.Block) block.ProposerRecord {
+	return NewProposerRecord(is.proposerSelector, blk)
+}
+
 //
 // Check if `basis` is a valid one for the current round of consensus
 //
@@ -240,6 +267,43 @@ func (is *ISAAC) CanGetVotingResult(b ballot.Ballot) (rv RoundVoteResult, vh vot
 	return
 }
 
+// AcceptedBallots returns the YES ACCEPT ballots of the `RunningRound` for
+// the basis and proposer of ballot.
+func (is *ISAAC) AcceptedBallots(b ballot.Ballot) []ballot.Ballot {
+	is.RLock()
+	defer is.RUnlock()
+
+	runningRound, found := is.RunningRounds[b.VotingBasis().Index()]
+	if !found {
+		return nil
+	}
+
+	runningRound.RLock()
+	defer runningRound.RUnlock()
+
+	roundVote, err := runningRound.RoundVote(b.Proposer())
+	if err != nil {
+		return nil
+	}
+
+	return roundVote.AcceptedBallots()
+}
+
+// ConflictingBallot returns the ballot, which was already received from the
+// same source for the same basis and state, but conflicts with the given
+// ballot.
+func (is *ISAAC) ConflictingBallot(b ballot.Ballot) (ballot.Ballot, bool) {
+	is.RLock()
+	defer is.RUnlock()
+
+	runningRound, found := is.RunningRounds[b.VotingBasis().Index()]
+	if !found {
+		return ballot.Ballot{}, false
+	}
+
+	return runningRound.ConflictingBallot(b)
+}
+
 func (is *ISAAC) IsVotedByNode(b ballot.Ballot, node string) (bool, error) {
 	is.RLock()
 	defer is.RUnlock()
@@ -0,0 +1,160 @@
+package consensus
+
+import (
+	"sync"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/storage"
+)
+
+// LivenessSelector selects the proposer like the base selector, but except
+// the unresponsive validators, which missed their last `missLimit` proposals.
+// The proposals are tracked by `block.ProposerRecord`, which is saved with the
+// block; see `NewProposerRecord`. The `missLimit` and `activationHeight` are
+// of `operation.NetworkParameters`, so every node selects the same proposer.
+//
+// The proposals are counted in the window of the last
+// `2 * missLimit * <number of validators>` blocks; after the missed proposals
+// are out of the window, the unresponsive validator can be selected again.
+// The blocks before `activationHeight` are not counted, because the node,
+// which made them, may not have the records.
+type LivenessSelector struct {
+	sync.Mutex
+
+	base             candidatesSelector
+	cm               network.ConnectionManager
+	st               *storage.LevelDBBackend
+	missLimit        uint64
+	activationHeight uint64
+}
+
+func NewLivenessSelector(base ProposerSelector, cm network.ConnectionManager, st *storage.LevelDBBackend, missLimit uint64, activationHeight uint64) (*LivenessSelector, error) {
+	cs, ok := base.(candidatesSelector)
+	if !ok || missLimit < 1 {
+		return nil, errors.InvalidProposerSelector
+	}
+
+	return &LivenessSelector{
+		base:             cs,
+		cm:               cm,
+		st:               st,
+		missLimit:        missLimit,
+		activationHeight: activationHeight,
+	}, nil
+}
+
+func (s *LivenessSelector) Select(blockHeight uint64, round uint64) string {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.selectProposer(blockHeight, round)
+}
+
+// Unresponsive returns the validators, which will not be selected for the
+// next block of `blockHeight`.
+func (s *LivenessSelector) Unresponsive(blockHeight uint64) (map[string]bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.unresponsive(blockHeight, len(sortedValidators(s.cm, s.st, blockHeight)))
+}
+
+func (s *LivenessSelector) selectProposer(blockHeight uint64, round uint64) string {
+	candidates := sortedValidators(s.cm, s.st, blockHeight)
+
+	unresponsive, err := s.unresponsive(blockHeight, len(candidates))
+	if err != nil {
+		// NOTE the node, which does not have the block yet, can not take part
+		// in the consensus until it is synced.
+		if err == errors.BlockNotFound {
+			log.Debug("block not found; unresponsive validators are not excluded", "height", blockHeight)
+		} else {
+			log.Error("failed to get unresponsive validators", "height", blockHeight, "error", err)
+		}
+		return s.base.selectFrom(candidates, blockHeight, round)
+	}
+
+	var responsive []string
+	for _, address := range candidates {
+		if !unresponsive[address] {
+			responsive = append(responsive, address)
+		}
+	}
+	if len(responsive) < 1 {
+		responsive = candidates
+	}
+
+	return s.base.selectFrom(responsive, blockHeight, round)
+}
+
+func (s *LivenessSelector) unresponsive(blockHeight uint64, numberOfValidators int) (map[string]bool, error) {
+	if exists, err := block.ExistsBlockByHeight(s.st, blockHeight); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, errors.BlockNotFound
+	}
+
+	unresponsive := map[string]bool{}
+	if blockHeight+1 < s.activationHeight {
+		return unresponsive, nil
+	}
+
+	from := common.FirstProposedBlockHeight
+	if s.activationHeight > from {
+		from = s.activationHeight
+	}
+
+	// the results of proposals by validator, the latest first; `true` means
+	// missed.
+	results := map[string][]bool{}
+	add := func(address string, missed bool) {
+		if uint64(len(results[address])) < s.missLimit {
+			results[address] = append(results[address], missed)
+		}
+	}
+
+	window := 2 * s.missLimit * uint64(numberOfValidators)
+	for height := blockHeight; height >= from && blockHeight-height < window; height-- {
+		pr, err := block.GetProposerRecord(s.st, height)
+		if err != nil {
+			return nil, err
+		}
+
+		add(pr.Proposer, false)
+		for i := len(pr.Missed) - 1; i >= 0; i-- {
+			add(pr.Missed[i], true)
+		}
+	}
+
+	for address, missed := range results {
+		if uint64(len(missed)) < s.missLimit {
+			continue
+		}
+
+		unresponsive[address] = true
+		for _, m := range missed {
+			if !m {
+				delete(unresponsive, address)
+				break
+			}
+		}
+	}
+
+	return unresponsive, nil
+}
+
+// NewProposerRecord makes the `block.ProposerRecord` of the new block, which
+// is saved with the block. The missed proposers are the ones, which are
+// selected by `selector` for the failed rounds of the block, so they are
+// derived from the blocks and the records of the previous blocks.
+func NewProposerRecord(selector ProposerSelector, blk block.Block) block.ProposerRecord {
+	var missed []string
+	for round := uint64(0); round < blk.Round; round++ {
+		missed = append(missed, selector.Select(blk.Height-1, round))
+	}
+
+	return block.NewProposerRecord(blk, missed)
+}
@@ -0,0 +1,215 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestLivenessSelector(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	var nodes []*node.LocalNode
+	var networks []*network.MemoryNetwork
+	var prev *network.MemoryNetwork
+	for i := 0; i < 4; i++ {
+		_, mn, localNode := network.CreateMemoryNetwork(prev)
+		prev = mn
+		nodes = append(nodes, localNode)
+		networks = append(networks, mn)
+	}
+	for _, n := range nodes {
+		for _, v := range nodes {
+			n.AddValidators(v.ConvertToValidator())
+		}
+	}
+
+	missLimit := uint64(2)
+	var storages []*storage.LevelDBBackend
+	var selectors []*LivenessSelector
+	for i, n := range nodes {
+		st := storage.NewTestStorage()
+		defer st.Close()
+
+		policy, _ := NewDefaultVotingThresholdPolicy(66)
+		cm := network.NewValidatorConnectionManager(n, networks[i], policy, conf)
+		base, _ := NewProposerSelector(common.ProposerSelectorSequential, cm, st)
+
+		selector, err := NewLivenessSelector(base, cm, st, missLimit, 0)
+		require.NoError(t, err)
+
+		storages = append(storages, st)
+		selectors = append(selectors, selector)
+	}
+	sequential := SequentialSelector{cm: selectors[0].cm}
+
+	genesis := *block.NewBlock(nodes[0].Address(), voting.Basis{Height: common.GenesisBlockHeight}, "", []string{}, common.NowISO8601())
+	for _, st := range storages {
+		genesis.MustSave(st)
+	}
+
+	// `dead` does not propose the block at all; the blocks are made by
+	// `selectors[0]` and saved to the storage of the other nodes.
+	dead := sequential.Select(common.GenesisBlockHeight, 0)
+
+	var missed, turns int
+	var skipped bool
+	prevBlock := genesis
+	for height := common.GenesisBlockHeight + 1; height < 60; height++ {
+		if sequential.Select(prevBlock.Height, 0) == dead {
+			turns++
+		}
+
+		var round uint64
+		proposer := selectors[0].Select(prevBlock.Height, round)
+		for ; proposer == dead; proposer = selectors[0].Select(prevBlock.Height, round) {
+			missed++
+			round++
+		}
+
+		if sequential.Select(prevBlock.Height, 0) == dead && round == 0 {
+			skipped = true
+		}
+
+		basis := voting.Basis{Height: height, Round: round, BlockHash: prevBlock.Hash}
+		blk := *block.NewBlock(proposer, basis, "", []string{}, common.NowISO8601())
+		for i, st := range storages {
+			blk.MustSave(st)
+			require.NoError(t, NewProposerRecord(selectors[i], blk).Save(st))
+		}
+		prevBlock = blk
+	}
+
+	// `dead` was skipped, but selected again after the missed proposals are
+	// out of window.
+	require.True(t, skipped)
+	require.True(t, missed > int(missLimit))
+	require.True(t, missed < turns)
+
+	{ // every node selects the same proposer and has the same records
+		for height := common.GenesisBlockHeight; height <= prevBlock.Height; height++ {
+			for round := uint64(0); round < 3; round++ {
+				proposer := selectors[0].Select(height, round)
+				for _, selector := range selectors[1:] {
+					require.Equal(t, proposer, selector.Select(height, round), "height=%d round=%d", height, round)
+				}
+			}
+		}
+
+		for height := common.GenesisBlockHeight + 1; height <= prevBlock.Height; height++ {
+			expected, err := block.GetProposerRecord(storages[0], height)
+			require.NoError(t, err)
+			for _, st := range storages[1:] {
+				pr, err := block.GetProposerRecord(st, height)
+				require.NoError(t, err)
+				require.Equal(t, expected, pr)
+			}
+		}
+	}
+
+	{ // unknown block
+		_, err := selectors[0].Unresponsive(prevBlock.Height + 1)
+		require.Equal(t, errors.BlockNotFound, err)
+		require.Equal(t, sequential.Select(prevBlock.Height+1, 0), selectors[0].Select(prevBlock.Height+1, 0))
+	}
+
+	{ // the record of the block has the missed proposers of the failed rounds
+		var blk block.Block
+		for height := common.GenesisBlockHeight + 1; height <= prevBlock.Height; height++ {
+			blk, _ = block.GetBlockByHeight(storages[0], height)
+			if blk.Round > 0 {
+				break
+			}
+		}
+		require.True(t, blk.Round > 0)
+
+		pr, err := block.GetProposerRecord(storages[0], blk.Height)
+		require.NoError(t, err)
+		require.Equal(t, blk.Proposer, pr.Proposer)
+		require.Equal(t, int(blk.Round), len(pr.Missed))
+		for _, m := range pr.Missed {
+			require.Equal(t, dead, m)
+		}
+	}
+
+	{ // base selector should support the candidates
+		_, err := NewLivenessSelector(unsupportedSelector{}, nil, nil, missLimit, 0)
+		require.Equal(t, errors.InvalidProposerSelector, err)
+	}
+}
+
+func TestLivenessSelectorActivationHeight(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	_, mn, localNode := network.CreateMemoryNetwork(nil)
+	for i := 0; i < 3; i++ {
+		n, _ := node.NewLocalNode(keypair.Random(), localNode.Endpoint(), "")
+		localNode.AddValidators(n.ConvertToValidator())
+	}
+	localNode.AddValidators(localNode.ConvertToValidator())
+
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	policy, _ := NewDefaultVotingThresholdPolicy(66)
+	cm := network.NewValidatorConnectionManager(localNode, mn, policy, conf)
+	base, _ := NewProposerSelector(common.ProposerSelectorSequential, cm, st)
+	sequential := base.(SequentialSelector)
+
+	missLimit := uint64(1)
+	activationHeight := uint64(10)
+	selector, err := NewLivenessSelector(base, cm, st, missLimit, activationHeight)
+	require.NoError(t, err)
+
+	genesis := *block.NewBlock(localNode.Address(), voting.Basis{Height: common.GenesisBlockHeight}, "", []string{}, common.NowISO8601())
+	genesis.MustSave(st)
+
+	// `dead` does not propose the block at all
+	dead := sequential.Select(common.GenesisBlockHeight, 0)
+
+	prevBlock := genesis
+	for height := common.GenesisBlockHeight + 1; height < 30; height++ {
+		var round uint64
+		proposer := selector.Select(prevBlock.Height, round)
+		for ; proposer == dead; proposer = selector.Select(prevBlock.Height, round) {
+			round++
+		}
+
+		basis := voting.Basis{Height: height, Round: round, BlockHash: prevBlock.Hash}
+		blk := *block.NewBlock(proposer, basis, "", []string{}, common.NowISO8601())
+		blk.MustSave(st)
+
+		// the blocks before the activation height may not have the records
+		if height >= activationHeight {
+			require.NoError(t, NewProposerRecord(selector, blk).Save(st))
+		}
+
+		unresponsive, err := selector.Unresponsive(blk.Height)
+		require.NoError(t, err)
+		if blk.Height+1 <= activationHeight {
+			require.Empty(t, unresponsive)
+			require.Equal(t, sequential.Select(blk.Height, 0), selector.Select(blk.Height, 0))
+		}
+		prevBlock = blk
+	}
+
+	// after the activation height, `dead` is not selected
+	unresponsive, err := selector.Unresponsive(prevBlock.Height)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{dead: true}, unresponsive)
+}
+
+type unsupportedSelector struct{}
+
+func (s unsupportedSelector) Select(_ uint64, _ uint64) string {
+	return ""
+}
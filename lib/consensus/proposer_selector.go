@@ -1,21 +1,136 @@
 package consensus
 
 import (
+	"encoding/binary"
 	"sort"
+	"strconv"
 
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 type ProposerSelector interface {
 	Select(uint64, uint64) string
 }
 
+// NewProposerSelector returns the `ProposerSelector` by the name; see
+// `common.ProposerSelectorNames`.
+func NewProposerSelector(name string, cm network.ConnectionManager, st *storage.LevelDBBackend) (ProposerSelector, error) {
+	switch name {
+	case common.ProposerSelectorSequential, "":
+		return SequentialSelector{cm: cm, st: st}, nil
+	case common.ProposerSelectorRandom:
+		return RandomSelector{cm: cm, st: st}, nil
+	default:
+		return nil, errors.InvalidProposerSelector
+	}
+}
+
+// NewNetworkProposerSelector returns the `ProposerSelector` of
+// `common.Config.ProposerSelector`; if
+// `operation.NetworkParameters.ProposerMissLimit` is set, it is wrapped by
+// `LivenessSelector`, which is applied from
+// `operation.NetworkParameters.ProposerMissLimitActivationHeight`.
+func NewNetworkProposerSelector(conf common.Config, params operation.NetworkParameters, cm network.ConnectionManager, st *storage.LevelDBBackend) (ProposerSelector, error) {
+	selector, err := NewProposerSelector(conf.ProposerSelector, cm, st)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.ProposerMissLimit < 1 {
+		return selector, nil
+	}
+
+	return NewLivenessSelector(selector, cm, st, params.ProposerMissLimit, params.ProposerMissLimitActivationHeight)
+}
+
+// candidatesSelector selects the proposer from the given candidates, which
+// are sorted; it is used by `LivenessSelector` to select the proposer except
+// the unresponsive validators.
+type candidatesSelector interface {
+	selectFrom(candidates []string, blockHeight uint64, round uint64) string
+}
+
+// sortedValidators returns the sorted validators for the next block of
+// `blockHeight`; the validators of `block.BlockValidatorSet` are used if it is
+// stored, so the proposer is selected from the new validators at the
+// activation height.
+func sortedValidators(cm network.ConnectionManager, st *storage.LevelDBBackend, blockHeight uint64) []string {
+	if st != nil {
+		if vs, err := block.GetBlockValidatorSet(st, blockHeight+1); err == nil {
+			return vs.Validators
+		}
+	}
+
+	candidates := sort.StringSlice(cm.AllValidators())
+	candidates.Sort()
+	return candidates
+}
+
 type SequentialSelector struct {
 	cm network.ConnectionManager
+	st *storage.LevelDBBackend
 }
 
 func (s SequentialSelector) Select(blockHeight uint64, round uint64) string {
-	candidates := sort.StringSlice(s.cm.AllValidators())
-	candidates.Sort()
+	return s.selectFrom(sortedValidators(s.cm, s.st, blockHeight), blockHeight, round)
+}
+
+func (s SequentialSelector) selectFrom(candidates []string, blockHeight uint64, round uint64) string {
 	return candidates[(blockHeight+round)%uint64(len(candidates))]
 }
+
+// RandomSelector selects the proposer from the validators, which are
+// shuffled by the seed made from the hash of the block at `blockHeight`, so
+// the next proposer can not be known before the previous block is confirmed,
+// but every validator selects the same one. The shuffled validators are same
+// in every round of the height and the proposer of each round is selected by
+// the round, so the different validator is selected in the next round.
+type RandomSelector struct {
+	cm network.ConnectionManager
+	st *storage.LevelDBBackend
+}
+
+func (s RandomSelector) Select(blockHeight uint64, round uint64) string {
+	return s.selectFrom(sortedValidators(s.cm, s.st, blockHeight), blockHeight, round)
+}
+
+func (s RandomSelector) selectFrom(candidates []string, blockHeight uint64, round uint64) string {
+	blk, err := block.GetBlockByHeight(s.st, blockHeight)
+	if err != nil {
+		// NOTE the node, which does not have the block yet, can not take part
+		// in the consensus until it is synced.
+		if err == errors.StorageRecordDoesNotExist {
+			log.Debug("block not found; proposer is selected sequentially", "height", blockHeight)
+		} else {
+			log.Error("failed to get block; proposer is selected sequentially", "height", blockHeight, "error", err)
+		}
+		return candidates[(blockHeight+round)%uint64(len(candidates))]
+	}
+
+	shuffled := ShuffleValidators(candidates, blk.Hash)
+	return shuffled[round%uint64(len(shuffled))]
+}
+
+// ShuffleValidators shuffles the sorted validators by the seeds, which are
+// made from the block hash; see `RandomSelectorSeed`.
+func ShuffleValidators(validators []string, blockHash string) []string {
+	shuffled := append([]string{}, validators...)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := RandomSelectorSeed(blockHash, uint64(i)) % uint64(i+1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	return shuffled
+}
+
+// RandomSelectorSeed makes the seed of `RandomSelector` from the block hash
+// and the index.
+func RandomSelectorSeed(blockHash string, index uint64) uint64 {
+	hash := common.MakeHash([]byte(blockHash + "-" + strconv.FormatUint(index, 10)))
+	return binary.BigEndian.Uint64(hash[:8])
+}
@@ -0,0 +1,165 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
+)
+
+func TestRandomSelector(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	var nodes []*node.LocalNode
+	var networks []*network.MemoryNetwork
+	var prev *network.MemoryNetwork
+	for i := 0; i < 4; i++ {
+		_, mn, localNode := network.CreateMemoryNetwork(prev)
+		prev = mn
+		nodes = append(nodes, localNode)
+		networks = append(networks, mn)
+	}
+	for _, n := range nodes {
+		for _, v := range nodes {
+			n.AddValidators(v.ConvertToValidator())
+		}
+	}
+
+	// every node has the same blocks in its own storage
+	var blocks []block.Block
+	prevHash := ""
+	for height := uint64(1); height <= 30; height++ {
+		basis := voting.Basis{Height: height, BlockHash: prevHash}
+		blk := *block.NewBlock(nodes[height%4].Address(), basis, "", []string{}, common.NowISO8601())
+		blocks = append(blocks, blk)
+		prevHash = blk.Hash
+	}
+
+	var selectors []ProposerSelector
+	for i, n := range nodes {
+		st := storage.NewTestStorage()
+		defer st.Close()
+		for _, blk := range blocks {
+			blk.MustSave(st)
+		}
+
+		policy, _ := NewDefaultVotingThresholdPolicy(66)
+		cm := network.NewValidatorConnectionManager(n, networks[i], policy, conf)
+
+		selector, err := NewProposerSelector(common.ProposerSelectorRandom, cm, st)
+		require.NoError(t, err)
+		selectors = append(selectors, selector)
+	}
+
+	selected := map[string]bool{}
+	var notSequential bool
+	sequential := SequentialSelector{cm: selectors[0].(RandomSelector).cm}
+	for height := uint64(1); height <= 30; height++ {
+		// every validator is selected once in the rounds of the height
+		inHeight := map[string]bool{}
+		for round := uint64(0); round < 4; round++ {
+			proposer := selectors[0].Select(height, round)
+			for _, selector := range selectors[1:] {
+				require.Equal(t, proposer, selector.Select(height, round), "height=%d round=%d", height, round)
+			}
+
+			require.False(t, inHeight[proposer], "height=%d round=%d", height, round)
+			inHeight[proposer] = true
+
+			selected[proposer] = true
+			if proposer != sequential.Select(height, round) {
+				notSequential = true
+			}
+		}
+		require.Equal(t, selectors[0].Select(height, 0), selectors[0].Select(height, 4))
+	}
+
+	require.Equal(t, 4, len(selected))
+	require.True(t, notSequential)
+
+	{ // seed depends on block hash and index
+		require.Equal(t, RandomSelectorSeed(blocks[0].Hash, 0), RandomSelectorSeed(blocks[0].Hash, 0))
+		require.NotEqual(t, RandomSelectorSeed(blocks[0].Hash, 0), RandomSelectorSeed(blocks[0].Hash, 1))
+		require.NotEqual(t, RandomSelectorSeed(blocks[0].Hash, 0), RandomSelectorSeed(blocks[1].Hash, 0))
+	}
+
+	{ // shuffled validators are the permutation of validators
+		validators := sortedValidators(selectors[0].(RandomSelector).cm, nil, 1)
+		shuffled := ShuffleValidators(validators, blocks[0].Hash)
+		require.Equal(t, shuffled, ShuffleValidators(validators, blocks[0].Hash))
+		require.ElementsMatch(t, validators, shuffled)
+	}
+}
+
+func TestSelectorValidatorSet(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	_, mn, localNode := network.CreateMemoryNetwork(nil)
+	localNode.AddValidators(localNode.ConvertToValidator())
+
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	policy, _ := NewDefaultVotingThresholdPolicy(66)
+	cm := network.NewValidatorConnectionManager(localNode, mn, policy, conf)
+
+	other := keypair.Random().Address()
+	block.NewBlockValidatorSet(common.GenesisBlockHeight, []string{localNode.Address()}).MustSave(st)
+	block.NewBlockValidatorSet(10, []string{other}).MustSave(st)
+
+	for _, name := range []string{common.ProposerSelectorSequential, common.ProposerSelectorRandom} {
+		selector, err := NewProposerSelector(name, cm, st)
+		require.NoError(t, err)
+
+		// the validator set of the next block is used
+		require.Equal(t, localNode.Address(), selector.Select(8, 0))
+		require.Equal(t, other, selector.Select(9, 0))
+		require.Equal(t, other, selector.Select(9, 1))
+	}
+}
+
+func TestNewProposerSelector(t *testing.T) {
+	selector, err := NewProposerSelector("", nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, SequentialSelector{}, selector)
+
+	selector, err = NewProposerSelector(common.ProposerSelectorSequential, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, SequentialSelector{}, selector)
+
+	selector, err = NewProposerSelector(common.ProposerSelectorRandom, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, RandomSelector{}, selector)
+
+	_, err = NewProposerSelector("unknown", nil, nil)
+	require.Equal(t, errors.InvalidProposerSelector, err)
+}
+
+func TestNewNetworkProposerSelector(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	selector, err := NewNetworkProposerSelector(conf, operation.NewNetworkParameters(), nil, st)
+	require.NoError(t, err)
+	require.IsType(t, SequentialSelector{}, selector)
+
+	params := operation.NewNetworkParameters()
+	params.ProposerMissLimit = 2
+	params.ProposerMissLimitActivationHeight = 10
+
+	selector, err = NewNetworkProposerSelector(conf, params, nil, st)
+	require.NoError(t, err)
+	require.IsType(t, &LivenessSelector{}, selector)
+	require.Equal(t, params.ProposerMissLimit, selector.(*LivenessSelector).missLimit)
+	require.Equal(t, params.ProposerMissLimitActivationHeight, selector.(*LivenessSelector).activationHeight)
+}
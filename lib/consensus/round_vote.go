@@ -1,6 +1,8 @@
 package consensus
 
 import (
+	"sort"
+
 	logging "github.com/inconshreveable/log15"
 
 	"boscoin.io/sebak/lib/ballot"
@@ -8,16 +10,22 @@ import (
 )
 
 type RoundVoteResult map[ /* Node.Address() */ string]voting.Hole
+type RoundVoteBallots map[ /* Node.Address() */ string]ballot.Ballot
 
 type RoundVote struct {
 	SIGN   RoundVoteResult
 	ACCEPT RoundVoteResult
+
+	// ACCEPTBallots keeps the signed ACCEPT ballots for
+	// `block.BlockCertificate`.
+	ACCEPTBallots RoundVoteBallots
 }
 
 func NewRoundVote(ballot ballot.Ballot) (rv *RoundVote) {
 	rv = &RoundVote{
-		SIGN:   RoundVoteResult{},
-		ACCEPT: RoundVoteResult{},
+		SIGN:          RoundVoteResult{},
+		ACCEPT:        RoundVoteResult{},
+		ACCEPTBallots: RoundVoteBallots{},
 	}
 
 	rv.Vote(ballot)
@@ -47,6 +55,25 @@ func (rv *RoundVote) Vote(b ballot.Ballot) (isNew bool, err error) {
 		result[b.Source()] = b.Vote()
 	}
 
+	if b.State() == ballot.StateACCEPT {
+		rv.ACCEPTBallots[b.Source()] = b
+	}
+
+	return
+}
+
+// AcceptedBallots returns the YES ACCEPT ballots ordered by source.
+func (rv *RoundVote) AcceptedBallots() (ballots []ballot.Ballot) {
+	for _, b := range rv.ACCEPTBallots {
+		if b.Vote() == voting.YES {
+			ballots = append(ballots, b)
+		}
+	}
+
+	sort.Slice(ballots, func(i, j int) bool {
+		return ballots[i].Source() < ballots[j].Source()
+	})
+
 	return
 }
 
@@ -8,6 +8,8 @@ import (
 	"boscoin.io/sebak/lib/voting"
 )
 
+type RunningRoundBallots map[ballot.State]RoundVoteBallots
+
 type RunningRound struct {
 	sync.RWMutex
 
@@ -15,6 +17,10 @@ type RunningRound struct {
 	Proposer     string                              // LocalNode's `Proposer`
 	Transactions map[ /* Proposer */ string][]string /* Transaction.Hash */
 	Voted        map[ /* Proposer */ string]*RoundVote
+
+	// Ballots keeps the first ballot of each source by state regardless of
+	// proposer to detect the conflicting ballots.
+	Ballots RunningRoundBallots
 }
 
 func NewRunningRound(proposer string, ballot ballot.Ballot) (*RunningRound, error) {
@@ -27,12 +33,16 @@ func NewRunningRound(proposer string, ballot ballot.Ballot) (*RunningRound, erro
 		ballot.Proposer(): roundVote,
 	}
 
-	return &RunningRound{
+	rr := &RunningRound{
 		VotingBasis:  ballot.VotingBasis(),
 		Proposer:     proposer,
 		Transactions: transactions,
 		Voted:        voted,
-	}, nil
+		Ballots:      RunningRoundBallots{},
+	}
+	rr.keepBallot(ballot)
+
+	return rr, nil
 }
 
 func (rr *RunningRound) RoundVote(proposer string) (rv *RoundVote, err error) {
@@ -64,4 +74,32 @@ func (rr *RunningRound) Vote(ballot ballot.Ballot) {
 	} else {
 		rr.Voted[ballot.Proposer()].Vote(ballot)
 	}
+
+	rr.keepBallot(ballot)
+}
+
+func (rr *RunningRound) keepBallot(b ballot.Ballot) {
+	ballots, found := rr.Ballots[b.State()]
+	if !found {
+		ballots = RoundVoteBallots{}
+		rr.Ballots[b.State()] = ballots
+	}
+
+	if _, found := ballots[b.Source()]; !found {
+		ballots[b.Source()] = b
+	}
+}
+
+// ConflictingBallot returns the kept ballot, which conflicts with the given
+// ballot; see `ballot.IsConflicting`.
+func (rr *RunningRound) ConflictingBallot(b ballot.Ballot) (ballot.Ballot, bool) {
+	rr.RLock()
+	defer rr.RUnlock()
+
+	kept, found := rr.Ballots[b.State()][b.Source()]
+	if !found || !ballot.IsConflicting(kept, b) {
+		return ballot.Ballot{}, false
+	}
+
+	return kept, true
 }
@@ -17,6 +17,9 @@ type ISAACVotingThresholdPolicy struct {
 }
 
 func (vt *ISAACVotingThresholdPolicy) Validators() int {
+	vt.RLock()
+	defer vt.RUnlock()
+
 	return vt.validators
 }
 
@@ -24,6 +27,10 @@ func (vt *ISAACVotingThresholdPolicy) SetValidators(n int) {
 	if n < 1 {
 		panic(errors.VotingThresholdInvalidValidators)
 	}
+
+	vt.Lock()
+	defer vt.Unlock()
+
 	vt.validators = n
 }
 
@@ -46,7 +53,13 @@ func (vt *ISAACVotingThresholdPolicy) SetConnected(n int) {
 }
 
 func (vt *ISAACVotingThresholdPolicy) Threshold() int {
-	v := float64(vt.validators) * (float64(vt.threshold) / float64(100))
+	return vt.ThresholdOf(vt.Validators())
+}
+
+// ThresholdOf returns the threshold for the given number of validators; it is
+// used for the blocks, which were confirmed by the other validator set.
+func (vt *ISAACVotingThresholdPolicy) ThresholdOf(validators int) int {
+	v := float64(validators) * (float64(vt.threshold) / float64(100))
 	threshold := int(math.Ceil(v))
 
 	if threshold < 0 {
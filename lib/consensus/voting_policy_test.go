@@ -12,5 +12,6 @@ func TestThreshold(t *testing.T) {
 
 	vt.SetValidators(1000)
 	require.Equal(t, 660, vt.Threshold())
+	require.Equal(t, 3, vt.ThresholdOf(4))
 
 }
@@ -114,4 +114,67 @@ var (
 	DiscoveryPolicyDoesNotMatch               = NewError(196, "policy does not matched with discovery node")
 	SnapshotNotFound                          = NewError(197, "snapshot not found")
 	SnapshotLimitReached                      = NewError(198, "snapshots over limit")
+	TransactionInsufficientSignatures         = NewError(199, "signatures do not reach the threshold of account")
+	TransactionUnknownSigner                  = NewError(200, "signature from unknown signer")
+	DuplicatedSigner                          = NewError(201, "duplicated signer found")
+	InvalidThresholds                         = NewError(202, "thresholds can not be reached by signers")
+	TransactionHasOverMaxSignatures           = NewError(203, "too many signatures in transaction")
+	InvalidTimeBounds                         = NewError(204, "invalid time bounds")
+	TransactionNotYetValid                    = NewError(205, "transaction is not yet valid")
+	TransactionExpired                        = NewError(206, "transaction is expired")
+	InvalidMemo                               = NewError(207, "invalid memo")
+	AccountMergeHasFrozenAccounts             = NewError(208, "account which has frozen accounts can not be merged")
+	AccountMergeNotToLinked                   = NewError(209, "frozen account can be merged only to the linked account")
+	AccountMergedInBallot                     = NewError(210, "account is merged by the other transaction in ballot")
+	InvalidDataEntry                          = NewError(211, "invalid data entry")
+	DataEntryDoesNotExists                    = NewError(212, "data entry does not exists")
+	DataEntryNotEnoughReserve                 = NewError(213, "balance does not keep the reserve of data entries")
+	TransactionReplaceFeeTooLow               = NewError(214, "fee is too low to replace the transaction in pool")
+	InvalidHashLock                           = NewError(215, "invalid hash lock")
+	HashLockAlreadyExists                     = NewError(216, "hash lock already exists")
+	HashLockDoesNotExists                     = NewError(217, "hash lock does not exists")
+	HashLockExpired                           = NewError(218, "hash lock is expired")
+	HashLockNotExpired                        = NewError(219, "hash lock is not expired yet")
+	HashLockNotLocked                         = NewError(220, "hash lock is already claimed or refunded")
+	AccountMergeHasHashLocks                  = NewError(221, "account which has locked hash locks can not be merged")
+	InvalidVesting                            = NewError(222, "invalid vesting schedule")
+	VestingNotVested                          = NewError(223, "amount is not vested yet")
+	CongressMemberDoesNotExists               = NewError(224, "congress member does not exists")
+	CongressMemberAlreadyExists               = NewError(225, "congress member already exists")
+	CongressVotingNotOpened                   = NewError(226, "congress voting is not opened yet")
+	CongressVotingClosed                      = NewError(227, "congress voting is already closed")
+	CongressVoteAlreadyCast                   = NewError(228, "congress vote is already cast")
+	CongressVotingNotClosed                   = NewError(229, "congress voting is not closed yet")
+	CongressVotingResultMissMatched           = NewError(230, "congress voting result is not matched with the tally")
+	CongressVotingResultAlreadyExists         = NewError(231, "congress voting result already exists")
+	CongressVotingNotPassed                   = NewError(232, "congress voting is not passed")
+	InvalidFeePayer                           = NewError(233, "invalid fee payer")
+	ScheduledPaymentAlreadyExists             = NewError(234, "scheduled payment already exists")
+	ScheduledPaymentDoesNotExists             = NewError(235, "scheduled payment does not exists")
+	ScheduledPaymentNotActive                 = NewError(236, "scheduled payment is not active")
+	InvalidScheduledPayment                   = NewError(237, "invalid scheduled payment")
+	AccountMergeHasScheduledPayments          = NewError(238, "account has active scheduled payments")
+	InvalidAccountOptions                     = NewError(239, "invalid account options")
+	AccountRequiresMemo                       = NewError(240, "account requires memo")
+	AccountNotAllowed                         = NewError(241, "source is not allowed by account")
+	InvalidAsset                              = NewError(242, "invalid asset")
+	TrustlineDoesNotExists                    = NewError(243, "trustline does not exists")
+	TrustlineLimitExceeded                    = NewError(244, "trustline limit exceeded")
+	TrustlineHasBalance                       = NewError(245, "trustline has balance")
+	TrustlineNotEnoughReserve                 = NewError(246, "not enough balance for the reserve of trustline")
+	AssetBalanceNotEnough                     = NewError(247, "not enough asset balance")
+	AccountMergeHasAssets                     = NewError(248, "account has trustlines or issued assets")
+	TransactionRequiresV2                     = NewError(249, "transaction has the fields of version 2")
+	TransactionPoolSourceLimit                = NewError(250, "too many transactions of source in transaction pool")
+	BlockCertificateDoesNotExists             = NewError(251, "block certificate does not exists")
+	InvalidBlockCertificate                   = NewError(252, "invalid block certificate")
+	BallotEquivocation                        = NewError(253, "ballot conflicts with the ballot of same source")
+	InvalidEvidence                           = NewError(254, "invalid evidence")
+	InvalidProposerSelector                   = NewError(255, "invalid proposer selector")
+	BlockValidatorSetDoesNotExists            = NewError(256, "validator set does not exists")
+	ValidatorAlreadyExists                    = NewError(257, "validator already exists")
+	ValidatorDoesNotExists                    = NewError(258, "validator does not exists")
+	ValidatorSetEmpty                         = NewError(259, "validator set can not be empty")
+	InvalidActivationHeight                   = NewError(260, "activation height must be in the future")
+	CongressVotingNotTallied                  = NewError(261, "congress voting is not tallied on chain")
 )
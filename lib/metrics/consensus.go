@@ -20,6 +20,8 @@ type ConsensusMetrics struct {
 
 	Validators        metrics.Gauge
 	MissingValidators metrics.Gauge
+
+	EvidenceTotal metrics.Counter
 }
 
 func (c *ConsensusMetrics) SetBlockIntervalSeconds(t time.Time) time.Time {
@@ -47,6 +49,12 @@ func (c *ConsensusMetrics) SetMissingValidators(num int) {
 	c.MissingValidators.Set(float64(num))
 }
 
+// AddEvidence counts the evidences of the conflicting ballots by the ballot
+// state.
+func (c *ConsensusMetrics) AddEvidence(state string) {
+	c.EvidenceTotal.With(ConsensusEvidenceState, state).Add(1)
+}
+
 func PromConsensusMetrics() *ConsensusMetrics {
 	return &ConsensusMetrics{
 		Height: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
@@ -91,6 +99,12 @@ func PromConsensusMetrics() *ConsensusMetrics {
 			Name:      "missing_validators",
 			Help:      "Number of missing validators.",
 		}, []string{}),
+		EvidenceTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: ConsensusSubsystem,
+			Name:      "evidence_total",
+			Help:      "Number of evidences of the conflicting ballots.",
+		}, []string{ConsensusEvidenceState}),
 	}
 }
 
@@ -106,5 +120,7 @@ func NopConsensusMetrics() *ConsensusMetrics {
 
 		Validators:        discard.NewGauge(),
 		MissingValidators: discard.NewGauge(),
+
+		EvidenceTotal: discard.NewCounter(),
 	}
 }
@@ -8,9 +8,25 @@ const (
 	APISubsystem       = "api"
 )
 
+const (
+	ConsensusEvidenceState = "state"
+)
+
 const (
 	SyncComponent = "component"
 	SyncFetcher   = "fetcher"
 	SyncValidator = "validator"
 	SyncAll       = "all"
 )
+
+const (
+	TxPoolEvictReason  = "reason"
+	TxPoolEvictTTL     = "ttl"
+	TxPoolEvictInvalid = "invalid"
+	TxPoolEvictExpired = "expired"
+)
+
+const (
+	TxPoolRejectReason      = "reason"
+	TxPoolRejectSourceLimit = "source-limit"
+)
@@ -8,13 +8,33 @@ import (
 )
 
 type TxPoolMetrics struct {
-	Size metrics.Gauge
+	Size          metrics.Gauge
+	EvictedTotal  metrics.Counter
+	RejectedTotal metrics.Counter
 }
 
 func (m *TxPoolMetrics) AddSize(delta int) {
 	m.Size.Add(float64(delta))
 }
 
+// AddEvicted counts the transactions, which are evicted from txpool by the
+// reason.
+func (m *TxPoolMetrics) AddEvicted(reason string, delta int) {
+	if delta < 1 {
+		return
+	}
+	m.EvictedTotal.With(TxPoolEvictReason, reason).Add(float64(delta))
+}
+
+// AddRejected counts the transactions, which are not added into txpool by the
+// reason.
+func (m *TxPoolMetrics) AddRejected(reason string, delta int) {
+	if delta < 1 {
+		return
+	}
+	m.RejectedTotal.With(TxPoolRejectReason, reason).Add(float64(delta))
+}
+
 func PromTxPoolMetrics() *TxPoolMetrics {
 	return &TxPoolMetrics{
 		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
@@ -23,11 +43,25 @@ func PromTxPoolMetrics() *TxPoolMetrics {
 			Name:      "size",
 			Help:      "Size of txpool.",
 		}, []string{}),
+		EvictedTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: TxPoolSubsystem,
+			Name:      "evicted_total",
+			Help:      "Number of transactions evicted from txpool.",
+		}, []string{TxPoolEvictReason}),
+		RejectedTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: TxPoolSubsystem,
+			Name:      "rejected_total",
+			Help:      "Number of transactions rejected by txpool.",
+		}, []string{TxPoolRejectReason}),
 	}
 }
 
 func NopTxPoolMetrics() *TxPoolMetrics {
 	return &TxPoolMetrics{
-		Size: discard.NewGauge(),
+		Size:          discard.NewGauge(),
+		EvictedTotal:  discard.NewCounter(),
+		RejectedTotal: discard.NewCounter(),
 	}
 }
@@ -10,6 +10,7 @@ type ConnectionManager interface {
 	Start()
 	AllConnected() []string
 	AllValidators() []string
+	SetValidators(...string) bool
 	CountConnected() int
 	IsReady() bool
 	Discovery(DiscoveryMessage) error
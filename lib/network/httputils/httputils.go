@@ -23,6 +23,7 @@ var (
 		errors.TooManyRequests.Code:               http.StatusTooManyRequests,
 		errors.BlockTransactionDoesNotExists.Code: http.StatusNotFound,
 		errors.BlockAccountDoesNotExists.Code:     http.StatusNotFound,
+		errors.BlockCertificateDoesNotExists.Code: http.StatusNotFound,
 		errors.TransactionPoolFull.Code:           http.StatusLocked,
 		errors.BadRequestParameter.Code:           http.StatusBadRequest,
 	}
@@ -24,6 +24,7 @@ type ValidatorConnectionManager struct {
 	connected        map[ /* node.Address() */ string]bool
 	config           common.Config
 	discoveryChannel chan DiscoveryMessage
+	started          bool
 
 	log logging.Logger
 }
@@ -82,6 +83,10 @@ func (c *ValidatorConnectionManager) GetConnectionByEndpoint(endpoint *common.En
 }
 
 func (c *ValidatorConnectionManager) Start() {
+	c.Lock()
+	c.started = true
+	c.Unlock()
+
 	if !c.config.WatcherMode {
 		c.log.Debug("starting discovery of validators", "validators", c.localNode.GetValidators())
 
@@ -99,6 +104,80 @@ func (c *ValidatorConnectionManager) Start() {
 	go c.watchForMetrics()
 }
 
+// SetValidators changes the validators to the given addresses; the new
+// validators are discovered and connected, and the removed validators are
+// disconnected. It returns `true` if the validators are changed.
+func (c *ValidatorConnectionManager) SetValidators(addresses ...string) bool {
+	current := c.localNode.GetValidators()
+
+	isValidator := map[string]bool{}
+	var added []*node.Validator
+	for _, address := range addresses {
+		isValidator[address] = true
+		if _, found := current[address]; found {
+			continue
+		}
+
+		var v *node.Validator
+		if address == c.localNode.Address() {
+			v = c.localNode.ConvertToValidator()
+		} else {
+			var err error
+			if v, err = node.NewValidator(address, nil, ""); err != nil {
+				c.log.Error("failed to add validator", "validator", address, "error", err)
+				continue
+			}
+		}
+		added = append(added, v)
+	}
+
+	var removed []string
+	for address := range current {
+		if !isValidator[address] {
+			removed = append(removed, address)
+		}
+	}
+
+	if len(added) < 1 && len(removed) < 1 {
+		return false
+	}
+
+	c.localNode.RemoveValidators(removed...)
+	c.localNode.AddValidators(added...)
+
+	c.Lock()
+	for _, address := range removed {
+		delete(c.connected, address)
+	}
+	if isValidator[c.localNode.Address()] {
+		c.connected[c.localNode.Address()] = true
+	}
+	if connected := c.countConnectedUnlocked(); connected > 0 {
+		c.policy.SetConnected(connected)
+	}
+	started := c.started
+	c.Unlock()
+
+	metrics.Consensus.SetValidators(len(c.localNode.GetValidators()))
+	c.log.Debug("validators changed", "added", added, "removed", removed)
+
+	if !started {
+		return true
+	}
+
+	for _, v := range added {
+		if v.Address() == c.localNode.Address() {
+			continue
+		}
+		go c.connectingValidator(v)
+	}
+	if !c.config.WatcherMode {
+		go c.discoverLeftValidators()
+	}
+
+	return true
+}
+
 // setConnected returns `true` when the validator is newly connected or
 // disconnected at first
 func (c *ValidatorConnectionManager) setConnected(v *node.Validator, connected bool) bool {
@@ -159,6 +238,12 @@ func (c *ValidatorConnectionManager) countConnectedUnlocked() int {
 func (c *ValidatorConnectionManager) connectingValidator(v *node.Validator) {
 	ticker := time.NewTicker(time.Second * 1)
 	for _ = range ticker.C {
+		// the validator is removed or added again by `SetValidators`
+		if c.localNode.Validator(v.Address()) != v {
+			ticker.Stop()
+			break
+		}
+
 		if v.Endpoint() == nil {
 			continue
 		}
@@ -252,11 +337,11 @@ func (c *ValidatorConnectionManager) Broadcast(message common.Message) {
 }
 
 func (c *ValidatorConnectionManager) watchForMetrics() {
-	numValidators := len(c.localNode.GetValidators())
-	metrics.Consensus.SetValidators(numValidators)
+	metrics.Consensus.SetValidators(len(c.localNode.GetValidators()))
 
 	ticker := time.NewTicker(time.Second * 60)
 	for _ = range ticker.C {
+		numValidators := len(c.localNode.GetValidators())
 		numConnected := c.CountConnected()
 		metrics.Consensus.SetMissingValidators(numValidators - numConnected)
 	}
@@ -296,16 +381,20 @@ func (c *ValidatorConnectionManager) startDiscovery() {
 	}
 	broadcastTicker.Stop()
 
-	go func() {
-		for {
-			if len(c.discovered()) == len(c.localNode.GetValidators()) {
-				break
-			}
+	go c.discoverLeftValidators()
+}
 
-			c.broadcastDiscovery()
-			time.Sleep(time.Millisecond * 500)
+// discoverLeftValidators keeps discovering until all the validators are
+// discovered.
+func (c *ValidatorConnectionManager) discoverLeftValidators() {
+	for {
+		if len(c.discovered()) == len(c.localNode.GetValidators()) {
+			break
 		}
-	}()
+
+		c.broadcastDiscovery()
+		time.Sleep(time.Millisecond * 500)
+	}
 }
 
 func (c *ValidatorConnectionManager) Discovery(dm DiscoveryMessage) error {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 type NodeInfo struct {
@@ -24,25 +25,28 @@ type NodeInfoNode struct {
 }
 
 type NodePolicy struct {
-	NetworkID                 string        `json:"network-id"`      // network id
-	InitialBalance            common.Amount `json:"initial-balance"` // initial balance of genesis account
-	BaseReserve               common.Amount `json:"base-reserve"`    // base reserve for one account
-	BaseFee                   common.Amount `json:"base-fee"`        // base fee of operation
-	BlockTime                 time.Duration `json:"block-time"`      // block creation time
-	BlockTimeDelta            time.Duration `json:"block-time-delta"`
-	TimeoutINIT               time.Duration `json:"timeout-init"`
-	TimeoutSIGN               time.Duration `json:"timeout-sign"`
-	TimeoutACCEPT             time.Duration `json:"timeout-accept"`
-	TimeoutALLCONFIRM         time.Duration `json:"timeout-allconfirm"`
-	RateLimitRuleAPI          string        `json:"rate-limit-api"`
-	RateLimitRuleNode         string        `json:"rate-limit-node"`
-	TransactionsLimit         int           `json:"transactions-limit"`            // transactions limit in a ballot
-	OperationsLimit           int           `json:"operations-limit"`              // operations limit in a transaction
-	OperationsInBallotLimit   int           `json:"operations-in-ballot-limit"`    // operations limit in a ballot
-	GenesisBlockConfirmedTime string        `json:"genesis-block-confirmed-time"`  // confirmed time of genesis block; see `common.GenesisBlockConfirmedTime`
-	InflationRatio            string        `json:"inflation-ratio"`               // inflation ratio; see `common.InflationRatio`
-	UnfreezingPeriod          uint64        `json:"unfreezing-period"`             // unfreezing period
-	BlockHeightEndOfInflation uint64        `json:"block-height-end-of-inflation"` // block height of inflation end; see `common.BlockHeightEndOfInflation`
+	NetworkID                 string                `json:"network-id"`      // network id
+	InitialBalance            common.Amount         `json:"initial-balance"` // initial balance of genesis account
+	BaseReserve               common.Amount         `json:"base-reserve"`    // base reserve for one account
+	BaseFee                   common.Amount         `json:"base-fee"`        // base fee of operation
+	FeeSchedule               operation.FeeSchedule `json:"fee-schedule"`    // fee of each type of operation; see `operation.FeeSchedule`
+	BlockTime                 time.Duration         `json:"block-time"`      // block creation time
+	BlockTimeDelta            time.Duration         `json:"block-time-delta"`
+	TimeoutINIT               time.Duration         `json:"timeout-init"`
+	TimeoutSIGN               time.Duration         `json:"timeout-sign"`
+	TimeoutACCEPT             time.Duration         `json:"timeout-accept"`
+	TimeoutALLCONFIRM         time.Duration         `json:"timeout-allconfirm"`
+	RateLimitRuleAPI          string                `json:"rate-limit-api"`
+	RateLimitRuleNode         string                `json:"rate-limit-node"`
+	TransactionsLimit         int                   `json:"transactions-limit"`            // transactions limit in a ballot
+	OperationsLimit           int                   `json:"operations-limit"`              // operations limit in a transaction
+	OperationsInBallotLimit   int                   `json:"operations-in-ballot-limit"`    // operations limit in a ballot
+	GenesisBlockConfirmedTime string                `json:"genesis-block-confirmed-time"`  // confirmed time of genesis block; see `common.GenesisBlockConfirmedTime`
+	InflationRatio            string                `json:"inflation-ratio"`               // inflation ratio; see `common.InflationRatio`
+	UnfreezingPeriod          uint64                `json:"unfreezing-period"`             // unfreezing period
+	BlockHeightEndOfInflation uint64                `json:"block-height-end-of-inflation"` // block height of inflation end; see `common.BlockHeightEndOfInflation`
+	TransactionVersions       []string              `json:"transaction-versions"`          // versions of transaction, which are accepted in the next block
+	TxV2ActivationHeight      uint64                `json:"tx-v2-activation-height"`       // block height, from which `common.TransactionVersionV2` is accepted; 0 means it is not activated
 }
 
 type NodeBlockInfo struct {
@@ -61,6 +65,20 @@ type NodeVersion struct {
 	BuildDate string `json:"build-date"`
 }
 
+// AcceptedTransactionVersions returns the versions of transaction, which the
+// node accepts in the next block. The client chooses the latest one it knows.
+func (n NodeInfo) AcceptedTransactionVersions() []string {
+	var versions []string
+	for _, v := range n.Policy.TransactionVersions {
+		if v != common.TransactionVersionV1 && (n.Policy.TxV2ActivationHeight == 0 || n.Block.Height+1 < n.Policy.TxV2ActivationHeight) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	return versions
+}
+
 func NewNodeInfoFromJSON(b []byte) (nodeInfo NodeInfo, err error) {
 	err = json.Unmarshal(b, &nodeInfo)
 	return
@@ -128,11 +128,18 @@ func (n *LocalNode) HasValidators(address string) bool {
 	return found
 }
 
+// GetValidators returns the copy of validators, because the validators can be
+// changed by `block.BlockValidatorSet` while they are used.
 func (n *LocalNode) GetValidators() map[string]*Validator {
 	n.RLock()
 	defer n.RUnlock()
 
-	return n.validators
+	validators := map[string]*Validator{}
+	for address, v := range n.validators {
+		validators[address] = v
+	}
+
+	return validators
 }
 
 func (n *LocalNode) Validator(address string) *Validator {
@@ -154,6 +161,15 @@ func (n *LocalNode) AddValidators(validators ...*Validator) error {
 	return nil
 }
 
+func (n *LocalNode) RemoveValidators(addresses ...string) {
+	n.Lock()
+	defer n.Unlock()
+
+	for _, address := range addresses {
+		delete(n.validators, address)
+	}
+}
+
 func (n *LocalNode) ClearValidators() {
 	n.Lock()
 	defer n.Unlock()
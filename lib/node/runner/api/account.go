@@ -126,14 +126,19 @@ func (api NetworkHandlerAPI) GetFrozenAccountsByAccountHandler(w http.ResponseWr
 				firstCursor = append(firstCursor, c...)
 			}
 			var (
-				casted operation.CreateAccount
-				ok     bool
-				body   operation.Body
+				casted  operation.CreateAccount
+				ok      bool
+				body    operation.Body
+				vesting *common.Vesting
 			)
 			if body, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
 				break
 			}
-			if casted, ok = body.(operation.CreateAccount); !ok {
+			if created, isVesting := body.(operation.CreateVestingAccount); isVesting {
+				v := created.Vesting()
+				vesting = &v
+				casted = operation.NewCreateAccount(created.Target, created.Amount, created.Linked)
+			} else if casted, ok = body.(operation.CreateAccount); !ok {
 				break
 			}
 			createdBlockHeight = bo.Height
@@ -147,6 +152,15 @@ func (api NetworkHandlerAPI) GetFrozenAccountsByAccountHandler(w http.ResponseWr
 
 			opIterFunc, opCloseFunc := block.GetBlockOperationsBySource(api.storage, casted.Target, nil)
 			state = resource.FrozenState
+			var vestedAmount common.Amount
+			if vesting != nil {
+				vestedAmount = vesting.Vested(block.GetLatestBlock(api.storage).Height)
+				if vestedAmount < vesting.Total {
+					state = resource.VestingState
+				} else {
+					state = resource.VestedState
+				}
+			}
 			for {
 				bo, hasNext, _ := opIterFunc()
 				switch bo.Type {
@@ -161,6 +175,10 @@ func (api NetworkHandlerAPI) GetFrozenAccountsByAccountHandler(w http.ResponseWr
 					unfreezingOpHash = bo.OpHash
 					unfreezingBlockHeight = bo.Height
 				case operation.TypePayment:
+					if vesting != nil {
+						// the vested balance is spent
+						break
+					}
 					state = resource.ReturnedState
 					paymentOpHash = bo.OpHash
 				}
@@ -180,6 +198,8 @@ func (api NetworkHandlerAPI) GetFrozenAccountsByAccountHandler(w http.ResponseWr
 				UnfreezingRequestOpHash:      unfreezingOpHash,
 				UnfreezingRemainingBlocks:    unfreezingRemainingBlocks,
 				PaymentOpHash:                paymentOpHash,
+				Vesting:                      vesting,
+				VestedAmount:                 vestedAmount,
 			}
 			var ba *block.BlockAccount
 			if ba, err = block.GetBlockAccount(api.storage, casted.Target); err != nil {
@@ -235,14 +255,19 @@ func (api NetworkHandlerAPI) GetFrozenAccountsHandler(w http.ResponseWriter, r *
 				firstCursor = append(firstCursor, c...)
 			}
 			var (
-				casted operation.CreateAccount
-				ok     bool
-				body   operation.Body
+				casted  operation.CreateAccount
+				ok      bool
+				body    operation.Body
+				vesting *common.Vesting
 			)
 			if body, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
 				break
 			}
-			if casted, ok = body.(operation.CreateAccount); !ok {
+			if created, isVesting := body.(operation.CreateVestingAccount); isVesting {
+				v := created.Vesting()
+				vesting = &v
+				casted = operation.NewCreateAccount(created.Target, created.Amount, created.Linked)
+			} else if casted, ok = body.(operation.CreateAccount); !ok {
 				break
 			}
 			createdBlockHeight = bo.Height
@@ -256,10 +281,23 @@ func (api NetworkHandlerAPI) GetFrozenAccountsHandler(w http.ResponseWriter, r *
 
 			opIterFunc, opCloseFunc := block.GetBlockOperationsBySource(api.storage, casted.Target, nil)
 			state = resource.FrozenState
+			var vestedAmount common.Amount
+			if vesting != nil {
+				vestedAmount = vesting.Vested(block.GetLatestBlock(api.storage).Height)
+				if vestedAmount < vesting.Total {
+					state = resource.VestingState
+				} else {
+					state = resource.VestedState
+				}
+			}
 			for {
 				bo, hasNext, _ := opIterFunc()
 				switch bo.Type {
 				case operation.TypePayment:
+					if vesting != nil {
+						// the vested balance is spent
+						break
+					}
 					state = resource.ReturnedState
 					paymentOpHash = bo.OpHash
 				case operation.TypeUnfreezingRequest:
@@ -289,6 +327,8 @@ func (api NetworkHandlerAPI) GetFrozenAccountsHandler(w http.ResponseWriter, r *
 				UnfreezingRequestOpHash:      unfreezingOpHash,
 				UnfreezingRemainingBlocks:    unfreezingRemainingBlocks,
 				PaymentOpHash:                paymentOpHash,
+				Vesting:                      vesting,
+				VestedAmount:                 vestedAmount,
 			}
 			var ba *block.BlockAccount
 			if ba, err = block.GetBlockAccount(api.storage, casted.Target); err != nil {
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+func (api NetworkHandlerAPI) GetAccountDataHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	if found, err := block.ExistsBlockAccount(api.storage, address); err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	} else if !found {
+		httputils.WriteJSONError(w, errors.BlockAccountDoesNotExists)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockAccountDataByAddress(api.storage, address, p.ListOptions())
+	for {
+		bd, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewAccountData(bd))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
@@ -23,6 +23,8 @@ func TestGetAccountHandler(t *testing.T) {
 	defer ts.Close()
 	// Make Dummy BlockAccount
 	ba := block.TestMakeBlockAccount()
+	allowed := keypair.Random().Address()
+	ba.SetOptions(common.AccountFlagRequireMemo, []string{allowed})
 	ba.MustSave(storage)
 	{
 		// Do a Request
@@ -37,6 +39,8 @@ func TestGetAccountHandler(t *testing.T) {
 		common.MustUnmarshalJSON(readByte, &recv)
 
 		require.Equal(t, ba.Address, recv["address"], "address is not same")
+		require.Equal(t, []interface{}{"require-memo"}, recv["flags"])
+		require.Equal(t, []interface{}{allowed}, recv["allow_list"])
 	}
 
 	{ // unknown address
@@ -208,3 +212,97 @@ func TestGetAccountsHandler(t *testing.T) {
 	}
 
 }
+
+func TestGetAccountDataHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(storage)
+
+	for _, key := range []string{"home-domain", "kyc"} {
+		require.NoError(t, block.NewBlockAccountData(ba.Address, key, "value-"+key).Save(storage))
+	}
+
+	{
+		url := strings.Replace(GetAccountDataHandlerPattern, "{id}", ba.Address, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		reader := bufio.NewReader(respBody)
+
+		readByte, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+
+		records := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		require.Equal(t, 2, len(records))
+		for i, key := range []string{"home-domain", "kyc"} {
+			r := records[i].(map[string]interface{})
+			require.Equal(t, ba.Address, r["address"])
+			require.Equal(t, key, r["key"])
+			require.Equal(t, "value-"+key, r["value"])
+		}
+	}
+
+	{ // unknown address
+		url := strings.Replace(GetAccountDataHandlerPattern, "{id}", keypair.Random().Address(), -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestGetAccountScheduledPaymentsHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(storage)
+	target := keypair.Random().Address()
+
+	require.NoError(t, block.NewBlockScheduledPayment(ba.Address, "rent", target, common.Amount(100), 10, 5, 3).Save(storage))
+	require.NoError(t, block.NewBlockScheduledPayment(ba.Address, "salary", target, common.Amount(200), 20, 0, 1).Save(storage))
+
+	{
+		url := strings.Replace(GetAccountScheduledPaymentsPattern, "{id}", ba.Address, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		reader := bufio.NewReader(respBody)
+
+		readByte, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+
+		records := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		require.Equal(t, 2, len(records))
+
+		r := records[0].(map[string]interface{})
+		require.Equal(t, ba.Address, r["source"])
+		require.Equal(t, "rent", r["id"])
+		require.Equal(t, target, r["target"])
+		require.Equal(t, "100", r["amount"])
+		require.Equal(t, "300", r["reserved"])
+		require.Equal(t, string(block.ScheduledPaymentActive), r["state"])
+
+		r = records[1].(map[string]interface{})
+		require.Equal(t, "salary", r["id"])
+		require.Equal(t, "200", r["reserved"])
+	}
+
+	{ // unknown address
+		url := strings.Replace(GetAccountScheduledPaymentsPattern, "{id}", keypair.Random().Address(), -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
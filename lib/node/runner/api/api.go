@@ -21,15 +21,27 @@ const (
 	GetAccountsHandlerPattern              = "/accounts"
 	GetAccountOperationsHandlerPattern     = "/accounts/{id}/operations"
 	GetAccountFrozenAccountHandlerPattern  = "/accounts/{id}/frozen-accounts"
+	GetAccountDataHandlerPattern           = "/accounts/{id}/data"
+	GetAccountScheduledPaymentsPattern     = "/accounts/{id}/scheduled-payments"
+	GetAccountTrustlinesHandlerPattern     = "/accounts/{id}/trustlines"
 	GetFrozenAccountHandlerPattern         = "/frozen-accounts"
 	GetTransactionsHandlerPattern          = "/transactions"
+	GetPendingTransactionsHandlerPattern   = "/transactions/pending"
 	GetTransactionByHashHandlerPattern     = "/transactions/{id}"
 	GetTransactionOperationsHandlerPattern = "/transactions/{id}/operations"
 	GetTransactionOperationHandlerPattern  = "/transactions/{id}/operations/{opindex}"
 	GetTransactionStatusHandlerPattern     = "/transactions/{id}/status"
+	GetFeeStatsHandlerPattern              = "/fee-stats"
+	GetCongressMembersHandlerPattern       = "/congress/members"
+	GetCongressVotingsHandlerPattern       = "/congress/votings"
+	GetCongressVotesHandlerPattern         = "/congress/votings/{id}/votes"
+	GetCongressResultsHandlerPattern       = "/congress/results"
+	GetAssetsHandlerPattern                = "/assets"
+	GetEvidencesHandlerPattern             = "/evidences"
 	PostTransactionPattern                 = "/transactions"
 	GetBlocksHandlerPattern                = "/blocks"
 	GetBlockHandlerPattern                 = "/blocks/{hashOrHeight}"
+	GetBlockCertificateHandlerPattern      = "/blocks/{hashOrHeight}/certificate"
 	GetNodeInfoPattern                     = "/"
 	PostSubscribePattern                   = "/subscribe"
 )
@@ -42,6 +54,11 @@ type NetworkHandlerAPI struct {
 	version        string
 	nodeInfo       node.NodeInfo
 	GetLatestBlock func() block.Block
+	// GetTransactionVersions returns the versions of transaction, which are
+	// accepted in the block of given height.
+	GetTransactionVersions func(uint64) []string
+
+	TransactionPool *transaction.Pool
 }
 
 func NewNetworkHandlerAPI(localNode *node.LocalNode, network network.Network, storage *storage.LevelDBBackend, urlPrefix string, nodeInfo node.NodeInfo) *NetworkHandlerAPI {
@@ -80,6 +97,10 @@ func TriggerEvent(st *storage.LevelDBBackend, transactions []*transaction.Transa
 		t(event(cond(obs.Tx, obs.All)), &bt)
 		t(event(cond(obs.Tx, obs.Source, source)), &bt)
 		t(event(cond(obs.Tx, obs.TxHash, txHash)), &bt)
+		if tx.HasFeePayer() {
+			accountMap[tx.FeePayer()] = struct{}{}
+			t(event(cond(obs.Tx, obs.Source, tx.FeePayer())), &bt)
+		}
 
 		for _, op := range tx.B.Operations {
 			if err != nil {
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// GetAccountTrustlinesHandler returns the trustlines of the account with the
+// balance of each asset.
+func (api NetworkHandlerAPI) GetAccountTrustlinesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	if found, err := block.ExistsBlockAccount(api.storage, address); err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	} else if !found {
+		httputils.WriteJSONError(w, errors.BlockAccountDoesNotExists)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockTrustlinesByAddress(api.storage, address, p.ListOptions())
+	for {
+		bt, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewTrustline(bt))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+// GetAssetsHandler returns the issued assets with the amount in circulation.
+func (api NetworkHandlerAPI) GetAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockAssets(api.storage, p.ListOptions())
+	for {
+		ba, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewAsset(ba))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountTrustlinesHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	ba := block.TestMakeBlockAccount()
+	ba.MustSave(storage)
+	issuer := keypair.Random().Address()
+
+	tl := block.NewBlockTrustline(ba.Address, common.NewAsset("POINT", issuer), common.Amount(1000))
+	tl.Balance = common.Amount(100)
+	require.NoError(t, tl.Save(storage))
+	require.NoError(t, block.NewBlockTrustline(ba.Address, common.NewAsset("USD", issuer), common.Amount(10)).Save(storage))
+
+	{
+		url := strings.Replace(GetAccountTrustlinesHandlerPattern, "{id}", ba.Address, -1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		reader := bufio.NewReader(respBody)
+
+		readByte, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+
+		records := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		require.Equal(t, 2, len(records))
+
+		r := records[0].(map[string]interface{})
+		require.Equal(t, ba.Address, r["address"])
+		asset := r["asset"].(map[string]interface{})
+		require.Equal(t, "POINT", asset["code"])
+		require.Equal(t, issuer, asset["issuer"])
+		require.Equal(t, "100", r["balance"])
+		require.Equal(t, "1000", r["limit"])
+
+		r = records[1].(map[string]interface{})
+		require.Equal(t, "USD", r["asset"].(map[string]interface{})["code"])
+	}
+
+	{ // unknown address
+		url := strings.Replace(GetAccountTrustlinesHandlerPattern, "{id}", keypair.Random().Address(), -1)
+		req, _ := http.NewRequest("GET", ts.URL+url, nil)
+		resp, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestGetAssetsHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	issuer := keypair.Random().Address()
+	ba := block.NewBlockAsset(common.NewAsset("POINT", issuer))
+	ba.Issued = common.Amount(500)
+	require.NoError(t, ba.Save(storage))
+
+	respBody := request(ts, GetAssetsHandlerPattern, false)
+	defer respBody.Close()
+	reader := bufio.NewReader(respBody)
+
+	readByte, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	recv := make(map[string]interface{})
+	common.MustUnmarshalJSON(readByte, &recv)
+
+	records := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+	require.Equal(t, 1, len(records))
+
+	r := records[0].(map[string]interface{})
+	require.Equal(t, "POINT", r["code"])
+	require.Equal(t, issuer, r["issuer"])
+	require.Equal(t, "500", r["issued"])
+}
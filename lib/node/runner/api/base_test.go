@@ -9,9 +9,12 @@ import (
 	"github.com/gorilla/mux"
 
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 )
 
 var networkID []byte = []byte("sebak-unittest")
@@ -22,20 +25,36 @@ const (
 
 func prepareAPIServer() (*httptest.Server, *storage.LevelDBBackend) {
 	storage := block.InitTestBlockchain()
-	apiHandler := NetworkHandlerAPI{storage: storage}
+	apiHandler := NetworkHandlerAPI{
+		storage:         storage,
+		nodeInfo:        node.NodeInfo{Policy: node.NodePolicy{FeeSchedule: operation.NewFeeSchedule()}},
+		TransactionPool: transaction.NewPool(common.NewTestConfig()),
+	}
 
 	router := mux.NewRouter()
 	router.HandleFunc(GetAccountHandlerPattern, apiHandler.GetAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountsHandlerPattern, apiHandler.GetAccountsHandler).Methods("POST")
 	router.HandleFunc(GetAccountTransactionsHandlerPattern, apiHandler.GetTransactionsByAccountHandler).Methods("GET")
 	router.HandleFunc(GetAccountOperationsHandlerPattern, apiHandler.GetOperationsByAccountHandler).Methods("GET")
+	router.HandleFunc(GetAccountDataHandlerPattern, apiHandler.GetAccountDataHandler).Methods("GET")
+	router.HandleFunc(GetAccountScheduledPaymentsPattern, apiHandler.GetAccountScheduledPaymentsHandler).Methods("GET")
+	router.HandleFunc(GetAccountTrustlinesHandlerPattern, apiHandler.GetAccountTrustlinesHandler).Methods("GET")
 	router.HandleFunc(GetTransactionOperationHandlerPattern, apiHandler.GetOperationsByTxHashOpIndexHandler).Methods("GET")
 	router.HandleFunc(GetTransactionsHandlerPattern, apiHandler.GetTransactionsHandler).Methods("GET")
+	router.HandleFunc(GetPendingTransactionsHandlerPattern, apiHandler.GetPendingTransactionsHandler).Methods("GET")
 	router.HandleFunc(GetTransactionByHashHandlerPattern, apiHandler.GetTransactionByHashHandler).Methods("GET")
 	router.HandleFunc(GetTransactionStatusHandlerPattern, apiHandler.GetTransactionStatusByHashHandler).Methods("GET")
 	router.HandleFunc(GetTransactionOperationsHandlerPattern, apiHandler.GetOperationsByTxHandler).Methods("GET")
 	router.HandleFunc(GetBlocksHandlerPattern, apiHandler.GetBlocksHandler).Methods("GET")
 	router.HandleFunc(GetBlockHandlerPattern, apiHandler.GetBlockHandler).Methods("GET")
+	router.HandleFunc(GetBlockCertificateHandlerPattern, apiHandler.GetBlockCertificateHandler).Methods("GET")
+	router.HandleFunc(GetFeeStatsHandlerPattern, apiHandler.GetFeeStatsHandler).Methods("GET")
+	router.HandleFunc(GetCongressMembersHandlerPattern, apiHandler.GetCongressMembersHandler).Methods("GET")
+	router.HandleFunc(GetCongressVotingsHandlerPattern, apiHandler.GetCongressVotingsHandler).Methods("GET")
+	router.HandleFunc(GetCongressVotesHandlerPattern, apiHandler.GetCongressVotesHandler).Methods("GET")
+	router.HandleFunc(GetCongressResultsHandlerPattern, apiHandler.GetCongressResultsHandler).Methods("GET")
+	router.HandleFunc(GetAssetsHandlerPattern, apiHandler.GetAssetsHandler).Methods("GET")
+	router.HandleFunc(GetEvidencesHandlerPattern, apiHandler.GetEvidencesHandler).Methods("GET")
 	router.HandleFunc(PostSubscribePattern, apiHandler.PostSubscribeHandler).Methods("POST")
 	ts := httptest.NewServer(router)
 	return ts, storage
@@ -45,3 +45,32 @@ func (api NetworkHandlerAPI) GetBlockHandler(w http.ResponseWriter, r *http.Requ
 	}
 	httputils.MustWriteJSON(w, 200, res)
 }
+
+// GetBlockCertificateHandler returns the certificate of block, which has the
+// signed ACCEPT ballots of validators, who confirmed the block.
+func (api NetworkHandlerAPI) GetBlockCertificateHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hash := vars["hashOrHeight"]
+	if hash == "" {
+		err := errors.BadRequestParameter
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	if height, err := strconv.ParseUint(hash, 10, 64); err == nil {
+		b, err := block.GetBlockByHeight(api.storage, height)
+		if err != nil {
+			httputils.WriteJSONError(w, err)
+			return
+		}
+		hash = b.Hash
+	}
+
+	bc, err := block.GetBlockCertificate(api.storage, hash)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewBlockCertificate(&bc))
+}
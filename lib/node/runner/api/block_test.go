@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -47,3 +48,49 @@ func TestBlockHandler(t *testing.T) {
 	}
 
 }
+
+func TestBlockCertificateHandler(t *testing.T) {
+	ts, st := prepareAPIServer()
+	defer st.Close()
+	defer ts.Close()
+
+	genesis := block.GetLatestBlock(st)
+
+	{ // not found
+		url := strings.Replace(GetBlockCertificateHandlerPattern, "{hashOrHeight}", genesis.Hash, 1)
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		bs, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		result := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(bs, &result))
+		require.Equal(t, http.StatusNotFound, int(result["status"].(float64)))
+	}
+
+	bc := block.NewBlockCertificate(genesis, []json.RawMessage{json.RawMessage(`{"H":{"hash":"a"}}`)})
+	require.NoError(t, bc.Save(st))
+
+	reqFunc := func(url string) map[string]interface{} {
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		bs, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		result := make(map[string]interface{})
+		err = json.Unmarshal(bs, &result)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	for _, id := range []string{"1", genesis.Hash} {
+		url := strings.Replace(GetBlockCertificateHandlerPattern, "{hashOrHeight}", id, 1)
+		res := reqFunc(url)
+		require.Equal(t, genesis.Hash, res["hash"])
+		require.Equal(t, float64(genesis.Height), res["height"])
+		ballots := res["ballots"].([]interface{})
+		require.Equal(t, 1, len(ballots))
+		require.Equal(t, "a", ballots[0].(map[string]interface{})["H"].(map[string]interface{})["hash"])
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func (api NetworkHandlerAPI) GetCongressMembersHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockCongressMembers(api.storage, p.ListOptions())
+	for {
+		bm, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewCongressMember(bm))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+// GetCongressVotingsHandler returns the congress votings with the tally
+// counted by the node.
+func (api NetworkHandlerAPI) GetCongressVotingsHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockOperationsByType(api.storage, operation.TypeCongressVoting, p.ListOptions())
+	for {
+		bo, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		var rv *resource.CongressVoting
+		if rv, err = api.newCongressVotingResource(bo); err != nil {
+			break
+		}
+		rs = append(rs, rv)
+	}
+	closeFunc()
+
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+func (api NetworkHandlerAPI) newCongressVotingResource(bo block.BlockOperation) (rv *resource.CongressVoting, err error) {
+	var bt block.BlockTransaction
+	if bt, err = block.GetBlockTransaction(api.storage, bo.TxHash); err != nil {
+		return
+	}
+	var opIndex int
+	if opIndex, err = bt.GetOperationIndex(bo.Hash); err != nil {
+		return
+	}
+
+	var body operation.Body
+	if body, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
+		return
+	}
+
+	hash := fmt.Sprintf("%s-%d", bo.TxHash, opIndex)
+
+	var tally *block.BlockCongressVotingTally
+	if tally, err = block.GetBlockCongressVotingTally(api.storage, hash); err != nil {
+		return
+	}
+
+	rv = resource.NewCongressVoting(hash, &bo, body.(operation.CongressVoting), tally)
+
+	return
+}
+
+func (api NetworkHandlerAPI) GetCongressVotesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	votingHash := vars["id"]
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockCongressVotesByVoting(api.storage, votingHash, p.ListOptions())
+	for {
+		bv, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewCongressVote(bv))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
+
+// GetCongressResultsHandler returns the operations of
+// `operation.CongressVotingResult`.
+func (api NetworkHandlerAPI) GetCongressResultsHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockOperationsByType(api.storage, operation.TypeCongressVotingResult, p.ListOptions())
+	for {
+		bo, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		var bt block.BlockTransaction
+		if bt, err = block.GetBlockTransaction(api.storage, bo.TxHash); err != nil {
+			break
+		}
+		var opIndex int
+		if opIndex, err = bt.GetOperationIndex(bo.Hash); err != nil {
+			break
+		}
+		rs = append(rs, resource.NewOperation(&bo, opIndex))
+	}
+	closeFunc()
+
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
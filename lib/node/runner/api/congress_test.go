@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestGetCongressVotingsHandler(t *testing.T) {
+	ts, st := prepareAPIServer()
+	defer st.Close()
+	defer ts.Close()
+
+	reqFunc := func(url string) []interface{} {
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		bs, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(bs, &result))
+		records, _ := result["_embedded"].(map[string]interface{})["records"].([]interface{})
+		return records
+	}
+
+	kpCongress := keypair.Random()
+	kpFunding := keypair.Random()
+
+	opb := operation.NewCongressVoting("dummy", 10, 20, common.Amount(100), kpFunding.Address())
+	op, err := operation.NewOperation(opb)
+	require.NoError(t, err)
+	tx, err := transaction.NewTransaction(kpCongress.Address(), 0, operation.NewFeeSchedule(), op)
+	require.NoError(t, err)
+	tx.Sign(kpCongress, networkID)
+
+	theBlock := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), []string{tx.GetHash()})
+	theBlock.MustSave(st)
+	bt := block.NewBlockTransactionFromTransaction(theBlock.Hash, theBlock.Height, theBlock.ProposedTime, tx)
+	bt.MustSave(st)
+	require.NoError(t, bt.SaveBlockOperations(st))
+
+	votingHash := fmt.Sprintf("%s-0", tx.GetHash())
+
+	members := []string{keypair.Random().Address(), keypair.Random().Address()}
+	tally := block.NewBlockCongressVotingTally(votingHash)
+	for _, member := range members {
+		require.NoError(t, block.NewBlockCongressMember(member).Save(st))
+		require.NoError(t, block.NewBlockCongressVote(votingHash, member, operation.VoteYes).Save(st))
+		tally.Add(operation.VoteYes)
+	}
+	require.NoError(t, tally.Save(st))
+
+	{ // members
+		records := reqFunc(GetCongressMembersHandlerPattern)
+		require.Equal(t, 2, len(records))
+	}
+
+	{ // votings
+		records := reqFunc(GetCongressVotingsHandlerPattern)
+		require.Equal(t, 1, len(records))
+
+		r := records[0].(map[string]interface{})
+		require.Equal(t, votingHash, r["hash"])
+		require.Equal(t, opb.Contract, r["contract"])
+		require.Equal(t, float64(opb.Voting.End), r["end"])
+		require.Equal(t, false, r["closed"])
+
+		result := r["result"].(map[string]interface{})
+		require.Equal(t, float64(2), result["count"])
+		require.Equal(t, float64(2), result["yes"])
+	}
+
+	{ // votes
+		records := reqFunc(strings.Replace(GetCongressVotesHandlerPattern, "{id}", votingHash, -1))
+		require.Equal(t, 2, len(records))
+		for _, record := range records {
+			r := record.(map[string]interface{})
+			require.Equal(t, votingHash, r["congress_voting_hash"])
+			require.Equal(t, string(operation.VoteYes), r["vote"])
+		}
+	}
+
+	{ // no results yet
+		records := reqFunc(GetCongressResultsHandlerPattern)
+		require.Equal(t, 0, len(records))
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// GetEvidencesHandler returns the evidences of the conflicting ballots. The
+// `source` query filters the evidences by the validator.
+func (api NetworkHandlerAPI) GetEvidencesHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	var iterFunc func() (*block.Evidence, bool, []byte)
+	var closeFunc func()
+	if source := r.URL.Query().Get("source"); len(source) > 0 {
+		iterFunc, closeFunc = block.GetEvidencesBySource(api.storage, source, p.ListOptions())
+	} else {
+		iterFunc, closeFunc = block.GetEvidences(api.storage, p.ListOptions())
+	}
+	for {
+		e, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewEvidence(e))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+func TestGetEvidencesHandler(t *testing.T) {
+	ts, storage := prepareAPIServer()
+	defer storage.Close()
+	defer ts.Close()
+
+	ballots := []json.RawMessage{
+		json.RawMessage(`{"H":{"hash":"a"}}`),
+		json.RawMessage(`{"H":{"hash":"b"}}`),
+	}
+	source := keypair.Random().Address()
+	require.NoError(t, block.NewEvidence(source, 3, 0, "SIGN", ballots).Save(storage))
+	require.NoError(t, block.NewEvidence(keypair.Random().Address(), 4, 1, "ACCEPT", ballots).Save(storage))
+
+	getRecords := func(url string) []interface{} {
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		reader := bufio.NewReader(respBody)
+
+		readByte, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+
+		records, _ := recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		return records
+	}
+
+	{ // all
+		require.Equal(t, 2, len(getRecords(GetEvidencesHandlerPattern)))
+	}
+
+	{ // by source
+		records := getRecords(GetEvidencesHandlerPattern + "?source=" + source)
+		require.Equal(t, 1, len(records))
+
+		r := records[0].(map[string]interface{})
+		require.Equal(t, source, r["source"])
+		require.Equal(t, float64(3), r["height"])
+		require.Equal(t, float64(0), r["round"])
+		require.Equal(t, "SIGN", r["state"])
+		require.Equal(t, 2, len(r["ballots"].([]interface{})))
+	}
+
+	{ // unknown source
+		records := getRecords(GetEvidencesHandlerPattern + "?source=" + keypair.Random().Address())
+		require.Equal(t, 0, len(records))
+	}
+}
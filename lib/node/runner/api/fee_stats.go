@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/storage"
+)
+
+var feeStatsPercentiles = []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 95, 99}
+
+// GetFeeStatsHandler returns the statistics of the fee per operation of the
+// transactions in the latest `common.FeeStatsBlocks` blocks. If there is no
+// transaction, every fee is the base fee of `operation.FeeSchedule` in the
+// policy of node.
+func (api NetworkHandlerAPI) GetFeeStatsHandler(w http.ResponseWriter, r *http.Request) {
+	schedule := api.nodeInfo.Policy.FeeSchedule
+
+	fs := resource.FeeStats{
+		BaseFee:     schedule.Base,
+		Percentiles: map[int]common.Amount{},
+	}
+
+	var fees []common.Amount
+	option := storage.NewWalkOption("", common.FeeStatsBlocks, true)
+	err := block.WalkBlocks(api.storage, option, func(b *block.Block, key []byte) (next bool, err error) {
+		if fs.Blocks == 0 {
+			fs.LastBlock = b.Height
+		}
+		fs.Blocks++
+
+		for _, hash := range b.Transactions {
+			var bt block.BlockTransaction
+			if bt, err = block.GetBlockTransaction(api.storage, hash); err != nil {
+				return
+			}
+			if len(bt.Operations) < 1 {
+				continue
+			}
+			fees = append(fees, bt.Fee/common.Amount(len(bt.Operations)))
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	fs.Transactions = len(fees)
+	if len(fees) < 1 {
+		fs.Min, fs.Max, fs.Mode = schedule.Base, schedule.Base, schedule.Base
+		for _, p := range feeStatsPercentiles {
+			fs.Percentiles[p] = schedule.Base
+		}
+	} else {
+		sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+
+		fs.Min, fs.Max, fs.Mode = fees[0], fees[len(fees)-1], feeMode(fees)
+		for _, p := range feeStatsPercentiles {
+			fs.Percentiles[p] = feePercentile(fees, p)
+		}
+	}
+
+	httputils.MustWriteJSON(w, 200, resource.NewFeeStats(fs))
+}
+
+// feePercentile returns the percentile of the sorted fees by the nearest-rank
+// method.
+func feePercentile(fees []common.Amount, p int) common.Amount {
+	i := (len(fees)*p + 99) / 100
+	if i < 1 {
+		i = 1
+	}
+
+	return fees[i-1]
+}
+
+// feeMode returns the most frequent fee of the sorted fees; if there are
+// several, the lowest one.
+func feeMode(fees []common.Amount) (mode common.Amount) {
+	var count, max int
+	for i, fee := range fees {
+		if i > 0 && fees[i-1] == fee {
+			count++
+		} else {
+			count = 1
+		}
+		if count > max {
+			max, mode = count, fee
+		}
+	}
+
+	return
+}
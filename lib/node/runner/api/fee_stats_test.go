@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/transaction"
+)
+
+func TestGetFeeStatsHandler(t *testing.T) {
+	ts, st := prepareAPIServer()
+	defer st.Close()
+	defer ts.Close()
+
+	reqFunc := func() (result map[string]interface{}) {
+		respBody := request(ts, GetFeeStatsHandlerPattern, false)
+		defer respBody.Close()
+		bs, err := ioutil.ReadAll(bufio.NewReader(respBody))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bs, &result))
+
+		return
+	}
+
+	// fill the latest blocks by the transactions of different fees
+	kp := keypair.Random()
+	for i := uint64(0); i < common.FeeStatsBlocks; i++ {
+		var txs []transaction.Transaction
+		var txHashes []string
+		for j := 1; j <= 3; j++ {
+			tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+			tx.B.Fee = common.BaseFee.MustMult(j)
+			tx.Sign(kp, networkID)
+			txs = append(txs, tx)
+			txHashes = append(txHashes, tx.GetHash())
+		}
+
+		theBlock := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), txHashes)
+		theBlock.MustSave(st)
+		for _, tx := range txs {
+			bt := block.NewBlockTransactionFromTransaction(theBlock.Hash, theBlock.Height, theBlock.ProposedTime, tx)
+			bt.MustSave(st)
+		}
+	}
+
+	result := reqFunc()
+	require.Equal(t, float64(block.GetLatestBlock(st).Height), result["last_block"])
+	require.Equal(t, float64(common.FeeStatsBlocks), result["blocks"])
+	require.Equal(t, float64(common.FeeStatsBlocks*3), result["transactions"])
+	require.Equal(t, common.BaseFee.String(), result["base_fee"])
+
+	fees := result["fee_per_operation"].(map[string]interface{})
+	require.Equal(t, common.BaseFee.String(), fees["min"])
+	require.Equal(t, common.BaseFee.MustMult(3).String(), fees["max"])
+	require.Equal(t, common.BaseFee.String(), fees["mode"])
+	require.Equal(t, common.BaseFee.String(), fees["p10"])
+	require.Equal(t, common.BaseFee.MustMult(2).String(), fees["p50"])
+	require.Equal(t, common.BaseFee.MustMult(3).String(), fees["p99"])
+}
+
+func TestFeePercentile(t *testing.T) {
+	fees := []common.Amount{1, 2, 2, 3, 5, 5, 5, 8, 9, 10}
+
+	require.Equal(t, common.Amount(1), feePercentile(fees, 10))
+	require.Equal(t, common.Amount(5), feePercentile(fees, 50))
+	require.Equal(t, common.Amount(9), feePercentile(fees, 90))
+	require.Equal(t, common.Amount(10), feePercentile(fees, 99))
+	require.Equal(t, common.Amount(5), feeMode(fees))
+
+	require.Equal(t, common.Amount(7), feePercentile([]common.Amount{7}, 10))
+	require.Equal(t, common.Amount(2), feeMode([]common.Amount{2, 2, 3, 3}))
+}
@@ -31,6 +31,10 @@ func (api NetworkHandlerAPI) GetNodeInfoHandler(w http.ResponseWriter, r *http.R
 			Proposed:  latestBlock.ProposedTime,
 			Confirmed: latestBlock.Confirmed,
 		}
+
+		if api.GetTransactionVersions != nil {
+			nodeInfo.Policy.TransactionVersions = api.GetTransactionVersions(latestBlock.Height + 1)
+		}
 	}
 
 	var b []byte
@@ -15,6 +15,7 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/version"
 )
 
@@ -120,3 +121,59 @@ func TestAPIGetNodeInfoHandler(t *testing.T) {
 	receivedNodeInfo, _ = node.NewNodeInfoFromJSON(data)
 	require.Equal(t, localNode.State(), receivedNodeInfo.Node.State)
 }
+
+func TestAPIGetNodeInfoHandlerTransactionVersions(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	kp := keypair.Random()
+	localNode, _ := node.NewLocalNode(kp, nil, "")
+
+	params := operation.NewNetworkParameters()
+	params.TxV2ActivationHeight = 3
+
+	apiHandler := NetworkHandlerAPI{
+		localNode: localNode,
+		storage:   st,
+		nodeInfo: node.NodeInfo{
+			Policy: node.NodePolicy{
+				TransactionVersions:  common.TransactionVersions,
+				TxV2ActivationHeight: params.TxV2ActivationHeight,
+			},
+		},
+		GetLatestBlock: func() block.Block {
+			return block.GetLatestBlock(st)
+		},
+		GetTransactionVersions: params.TransactionVersions,
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetNodeInfoPattern, apiHandler.GetNodeInfoHandler).Methods("GET")
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	getNodeInfo := func() node.NodeInfo {
+		body := request(ts, GetNodeInfoPattern, false)
+		defer body.Close()
+		data, err := ioutil.ReadAll(bufio.NewReader(body))
+		require.NoError(t, err)
+
+		receivedNodeInfo, err := node.NewNodeInfoFromJSON(data)
+		require.NoError(t, err)
+		return receivedNodeInfo
+	}
+
+	// V2 is not accepted in the next block, 2
+	receivedNodeInfo := getNodeInfo()
+	require.Equal(t, []string{common.TransactionVersionV1}, receivedNodeInfo.Policy.TransactionVersions)
+	require.Equal(t, []string{common.TransactionVersionV1}, receivedNodeInfo.AcceptedTransactionVersions())
+
+	latest := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), []string{})
+	latest.MustSave(st)
+
+	// V2 is accepted in the next block, 3
+	receivedNodeInfo = getNodeInfo()
+	require.Equal(t, common.TransactionVersions, receivedNodeInfo.Policy.TransactionVersions)
+	require.Equal(t, common.TransactionVersions, receivedNodeInfo.AcceptedTransactionVersions())
+}
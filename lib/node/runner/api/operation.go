@@ -70,7 +70,8 @@ func (api NetworkHandlerAPI) GetOperationsByAccountHandler(w http.ResponseWriter
 		}
 	}
 
-	if found, err := block.ExistsBlockAccount(api.storage, address); err != nil {
+	// the operations of the merged account are still available
+	if found, err := block.ExistedBlockAccount(api.storage, address); err != nil {
 		httputils.WriteJSONError(w, err)
 		return
 	} else if !found {
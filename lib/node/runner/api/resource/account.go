@@ -21,10 +21,16 @@ func NewAccount(ba *block.BlockAccount) *Account {
 
 func (a Account) GetMap() hal.Entry {
 	return hal.Entry{
-		"address":     a.ba.Address,
-		"sequence_id": a.ba.SequenceID,
-		"balance":     a.ba.Balance,
-		"linked":      a.ba.Linked,
+		"address":      a.ba.Address,
+		"sequence_id":  a.ba.SequenceID,
+		"balance":      a.ba.Balance,
+		"linked":       a.ba.Linked,
+		"signers":      a.ba.GetSigners(),
+		"thresholds":   a.ba.Thresholds,
+		"data_entries": a.ba.DataEntries,
+		"trustlines":   a.ba.Trustlines,
+		"flags":        a.ba.Flags.Names(),
+		"allow_list":   a.ba.AllowList,
 	}
 }
 
@@ -35,6 +41,8 @@ func (a Account) Resource() *hal.Resource {
 	r := hal.NewResource(a, a.LinkSelf())
 	r.AddLink("transactions", hal.NewLink(strings.Replace(URLAccountTransactions, "{id}", address, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
 	r.AddLink("operations", hal.NewLink(strings.Replace(URLAccountOperations, "{id}", accountID, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	r.AddLink("data", hal.NewLink(strings.Replace(URLAccountData, "{id}", accountID, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
+	r.AddLink("trustlines", hal.NewLink(strings.Replace(URLAccountTrustlines, "{id}", accountID, -1)+"{?cursor,limit,order}", hal.LinkAttr{"templated": true}))
 	return r
 }
 
@@ -0,0 +1,35 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type AccountData struct {
+	bd *block.BlockAccountData
+}
+
+func NewAccountData(bd *block.BlockAccountData) *AccountData {
+	return &AccountData{
+		bd: bd,
+	}
+}
+
+func (a AccountData) GetMap() hal.Entry {
+	return hal.Entry{
+		"address": a.bd.Address,
+		"key":     a.bd.Key,
+		"value":   a.bd.Value,
+	}
+}
+
+func (a AccountData) Resource() *hal.Resource {
+	return hal.NewResource(a, a.LinkSelf())
+}
+
+func (a AccountData) LinkSelf() string {
+	return strings.Replace(URLAccountData, "{id}", a.bd.Address, -1)
+}
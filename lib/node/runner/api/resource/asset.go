@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type Trustline struct {
+	bt *block.BlockTrustline
+}
+
+func NewTrustline(bt *block.BlockTrustline) *Trustline {
+	return &Trustline{
+		bt: bt,
+	}
+}
+
+func (t Trustline) GetMap() hal.Entry {
+	return hal.Entry{
+		"address": t.bt.Address,
+		"asset":   t.bt.Asset,
+		"balance": t.bt.Balance,
+		"limit":   t.bt.Limit,
+	}
+}
+
+func (t Trustline) Resource() *hal.Resource {
+	r := hal.NewResource(t, t.LinkSelf())
+	r.AddNewLink("issuer", strings.Replace(URLAccounts, "{id}", t.bt.Asset.Issuer, -1))
+	return r
+}
+
+func (t Trustline) LinkSelf() string {
+	return strings.Replace(URLAccountTrustlines, "{id}", t.bt.Address, -1)
+}
+
+type Asset struct {
+	ba *block.BlockAsset
+}
+
+func NewAsset(ba *block.BlockAsset) *Asset {
+	return &Asset{
+		ba: ba,
+	}
+}
+
+func (a Asset) GetMap() hal.Entry {
+	return hal.Entry{
+		"code":   a.ba.Code,
+		"issuer": a.ba.Issuer,
+		"issued": a.ba.Issued,
+	}
+}
+
+func (a Asset) Resource() *hal.Resource {
+	r := hal.NewResource(a, a.LinkSelf())
+	r.AddNewLink("issuer", strings.Replace(URLAccounts, "{id}", a.ba.Issuer, -1))
+	return r
+}
+
+func (a Asset) LinkSelf() string {
+	return URLAssets
+}
@@ -0,0 +1,37 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type BlockCertificate struct {
+	bc *block.BlockCertificate
+}
+
+func NewBlockCertificate(bc *block.BlockCertificate) *BlockCertificate {
+	return &BlockCertificate{
+		bc: bc,
+	}
+}
+
+func (c BlockCertificate) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":    c.bc.Hash,
+		"height":  c.bc.Height,
+		"ballots": c.bc.Ballots,
+	}
+}
+
+func (c BlockCertificate) Resource() *hal.Resource {
+	r := hal.NewResource(c, c.LinkSelf())
+	r.AddLink("block", hal.NewLink(strings.Replace(URLBlocks, "{id}", c.bc.Hash, -1)))
+	return r
+}
+
+func (c BlockCertificate) LinkSelf() string {
+	return strings.Replace(URLBlockCertificate, "{id}", c.bc.Hash, -1)
+}
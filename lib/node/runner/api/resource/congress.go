@@ -0,0 +1,107 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+type CongressMember struct {
+	bm *block.BlockCongressMember
+}
+
+func NewCongressMember(bm *block.BlockCongressMember) *CongressMember {
+	return &CongressMember{bm: bm}
+}
+
+func (m CongressMember) GetMap() hal.Entry {
+	return hal.Entry{
+		"address": m.bm.Address,
+	}
+}
+
+func (m CongressMember) Resource() *hal.Resource {
+	r := hal.NewResource(m, m.LinkSelf())
+	r.AddNewLink("account", strings.Replace(URLAccounts, "{id}", m.bm.Address, -1))
+	return r
+}
+
+func (m CongressMember) LinkSelf() string {
+	return URLCongressMembers
+}
+
+// CongressVoting is the `operation.CongressVoting` with the on-chain tally.
+type CongressVoting struct {
+	Hash   string
+	bo     *block.BlockOperation
+	voting operation.CongressVoting
+	tally  *block.BlockCongressVotingTally
+}
+
+func NewCongressVoting(hash string, bo *block.BlockOperation, voting operation.CongressVoting, tally *block.BlockCongressVotingTally) *CongressVoting {
+	return &CongressVoting{
+		Hash:   hash,
+		bo:     bo,
+		voting: voting,
+		tally:  tally,
+	}
+}
+
+func (v CongressVoting) GetMap() hal.Entry {
+	return hal.Entry{
+		"hash":            v.Hash,
+		"tx_hash":         v.bo.TxHash,
+		"block_height":    v.bo.Height,
+		"contract":        v.voting.Contract,
+		"start":           v.voting.Voting.Start,
+		"end":             v.voting.Voting.End,
+		"funding_address": v.voting.FundingAddress,
+		"amount":          v.voting.Amount,
+		"result": hal.Entry{
+			"count": v.tally.Count(),
+			"yes":   v.tally.Yes,
+			"no":    v.tally.No,
+			"abs":   v.tally.ABS,
+		},
+		"closed": v.tally.Closed,
+		"passed": v.tally.Closed && v.tally.Passed(),
+	}
+}
+
+func (v CongressVoting) Resource() *hal.Resource {
+	r := hal.NewResource(v, v.LinkSelf())
+	r.AddNewLink("votes", strings.Replace(URLCongressVotingVotes, "{id}", v.Hash, -1))
+	r.AddNewLink("transaction", strings.Replace(URLTransactionByHash, "{id}", v.bo.TxHash, -1))
+	return r
+}
+
+func (v CongressVoting) LinkSelf() string {
+	return URLCongressVotings
+}
+
+type CongressVote struct {
+	bv *block.BlockCongressVote
+}
+
+func NewCongressVote(bv *block.BlockCongressVote) *CongressVote {
+	return &CongressVote{bv: bv}
+}
+
+func (v CongressVote) GetMap() hal.Entry {
+	return hal.Entry{
+		"congress_voting_hash": v.bv.VotingHash,
+		"voter":                v.bv.Voter,
+		"vote":                 v.bv.Vote,
+	}
+}
+
+func (v CongressVote) Resource() *hal.Resource {
+	return hal.NewResource(v, v.LinkSelf())
+}
+
+func (v CongressVote) LinkSelf() string {
+	return strings.Replace(URLCongressVotingVotes, "{id}", v.bv.VotingHash, -1)
+}
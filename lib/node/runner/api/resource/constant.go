@@ -4,16 +4,28 @@ const (
 	APIVersionV1 = "/v1"
 	APIPrefix    = "/api"
 
-	URLAccounts              = APIPrefix + APIVersionV1 + "/accounts/{id}"
-	URLAccountTransactions   = APIPrefix + APIVersionV1 + "/accounts/{id}/transactions"
-	URLAccountOperations     = APIPrefix + APIVersionV1 + "/accounts/{id}/operations"
-	URLAccountFrozenAccounts = APIPrefix + APIVersionV1 + "/accounts/{id}/frozen-accounts"
-	URLFrozenAccounts        = APIPrefix + APIVersionV1 + "/frozen-accounts"
-	URLTransactions          = APIPrefix + APIVersionV1 + "/transactions"
-	URLTransactionByHash     = APIPrefix + APIVersionV1 + "/transactions/{id}"
-	URLTransactionOperations = APIPrefix + APIVersionV1 + "/transactions/{id}/operations"
-	URLTransactionOperation  = APIPrefix + APIVersionV1 + "/transactions/{id}/operations/{opindex}"
-	URLTransactionStatus     = APIPrefix + APIVersionV1 + "/transactions/{id}/status"
-	URLOperations            = APIPrefix + APIVersionV1 + "/operations/{id}"
-	URLBlocks                = APIPrefix + APIVersionV1 + "/blocks/{id}"
+	URLAccounts                 = APIPrefix + APIVersionV1 + "/accounts/{id}"
+	URLAccountTransactions      = APIPrefix + APIVersionV1 + "/accounts/{id}/transactions"
+	URLAccountOperations        = APIPrefix + APIVersionV1 + "/accounts/{id}/operations"
+	URLAccountFrozenAccounts    = APIPrefix + APIVersionV1 + "/accounts/{id}/frozen-accounts"
+	URLAccountData              = APIPrefix + APIVersionV1 + "/accounts/{id}/data"
+	URLAccountScheduledPayments = APIPrefix + APIVersionV1 + "/accounts/{id}/scheduled-payments"
+	URLAccountTrustlines        = APIPrefix + APIVersionV1 + "/accounts/{id}/trustlines"
+	URLFrozenAccounts           = APIPrefix + APIVersionV1 + "/frozen-accounts"
+	URLTransactions             = APIPrefix + APIVersionV1 + "/transactions"
+	URLTransactionsPending      = APIPrefix + APIVersionV1 + "/transactions/pending"
+	URLTransactionByHash        = APIPrefix + APIVersionV1 + "/transactions/{id}"
+	URLTransactionOperations    = APIPrefix + APIVersionV1 + "/transactions/{id}/operations"
+	URLTransactionOperation     = APIPrefix + APIVersionV1 + "/transactions/{id}/operations/{opindex}"
+	URLTransactionStatus        = APIPrefix + APIVersionV1 + "/transactions/{id}/status"
+	URLOperations               = APIPrefix + APIVersionV1 + "/operations/{id}"
+	URLBlocks                   = APIPrefix + APIVersionV1 + "/blocks/{id}"
+	URLBlockCertificate         = APIPrefix + APIVersionV1 + "/blocks/{id}/certificate"
+	URLFeeStats                 = APIPrefix + APIVersionV1 + "/fee-stats"
+	URLCongressMembers          = APIPrefix + APIVersionV1 + "/congress/members"
+	URLCongressVotings          = APIPrefix + APIVersionV1 + "/congress/votings"
+	URLCongressVotingVotes      = APIPrefix + APIVersionV1 + "/congress/votings/{id}/votes"
+	URLCongressResults          = APIPrefix + APIVersionV1 + "/congress/results"
+	URLAssets                   = APIPrefix + APIVersionV1 + "/assets"
+	URLEvidences                = APIPrefix + APIVersionV1 + "/evidences"
 )
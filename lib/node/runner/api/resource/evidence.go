@@ -0,0 +1,40 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type Evidence struct {
+	e *block.Evidence
+}
+
+func NewEvidence(e *block.Evidence) *Evidence {
+	return &Evidence{
+		e: e,
+	}
+}
+
+func (e Evidence) GetMap() hal.Entry {
+	return hal.Entry{
+		"source":   e.e.Source,
+		"height":   e.e.Height,
+		"round":    e.e.Round,
+		"state":    e.e.State,
+		"ballots":  e.e.Ballots,
+		"detected": e.e.Detected,
+	}
+}
+
+func (e Evidence) Resource() *hal.Resource {
+	r := hal.NewResource(e, e.LinkSelf())
+	r.AddNewLink("source", strings.Replace(URLAccounts, "{id}", e.e.Source, -1))
+	return r
+}
+
+func (e Evidence) LinkSelf() string {
+	return URLEvidences + "?source=" + e.e.Source
+}
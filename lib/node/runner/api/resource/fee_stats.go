@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// FeeStats has the statistics of the fee per operation of the transactions
+// in the latest blocks.
+type FeeStats struct {
+	LastBlock    uint64
+	Blocks       int
+	Transactions int
+	BaseFee      common.Amount
+	Min          common.Amount
+	Max          common.Amount
+	Mode         common.Amount
+	Percentiles  map[int]common.Amount
+}
+
+func NewFeeStats(fs FeeStats) *FeeStats {
+	return &fs
+}
+
+func (fs FeeStats) GetMap() hal.Entry {
+	feePerOperation := hal.Entry{
+		"min":  fs.Min,
+		"max":  fs.Max,
+		"mode": fs.Mode,
+	}
+	for p, fee := range fs.Percentiles {
+		feePerOperation[fmt.Sprintf("p%d", p)] = fee
+	}
+
+	return hal.Entry{
+		"last_block":        fs.LastBlock,
+		"blocks":            fs.Blocks,
+		"transactions":      fs.Transactions,
+		"base_fee":          fs.BaseFee,
+		"fee_per_operation": feePerOperation,
+	}
+}
+
+func (fs FeeStats) Resource() *hal.Resource {
+	return hal.NewResource(fs, fs.LinkSelf())
+}
+
+func (fs FeeStats) LinkSelf() string {
+	return URLFeeStats
+}
@@ -15,6 +15,8 @@ const (
 	MeltingState  FrozenAccountState = "melting"
 	UnfrozenState FrozenAccountState = "unfrozen"
 	ReturnedState FrozenAccountState = "returned"
+	VestingState  FrozenAccountState = "vesting"
+	VestedState   FrozenAccountState = "vested"
 )
 
 type FrozenAccount struct {
@@ -40,10 +42,13 @@ type FrozenAccountInfo struct {
 	UnfreezingRequestOpHash      string
 	UnfreezingRemainingBlocks    uint64
 	PaymentOpHash                string
+	// Vesting is set for the vesting account
+	Vesting      *common.Vesting
+	VestedAmount common.Amount
 }
 
 func (fa FrozenAccount) GetMap() hal.Entry {
-	return hal.Entry{
+	entry := hal.Entry{
 		"address":                     fa.ba.Address,
 		"linked":                      fa.ba.Linked,
 		"create_block_height":         fa.info.CreatedBlockHeight,
@@ -56,6 +61,18 @@ func (fa FrozenAccount) GetMap() hal.Entry {
 		"unfreezing_remaining_blocks": fa.info.UnfreezingRemainingBlocks,
 		"payment_op_hash":             fa.info.PaymentOpHash,
 	}
+
+	if v := fa.info.Vesting; v != nil {
+		entry["vesting"] = hal.Entry{
+			"cliff_block_height": v.Cliff,
+			"end_block_height":   v.End,
+			"total":              v.Total,
+			"vested":             fa.info.VestedAmount,
+			"locked":             v.Total - fa.info.VestedAmount,
+		}
+	}
+
+	return entry
 }
 
 func (fa FrozenAccount) Resource() *hal.Resource {
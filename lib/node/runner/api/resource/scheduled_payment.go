@@ -0,0 +1,43 @@
+package resource
+
+import (
+	"strings"
+
+	"github.com/nvellon/hal"
+
+	"boscoin.io/sebak/lib/block"
+)
+
+type ScheduledPayment struct {
+	bp *block.BlockScheduledPayment
+}
+
+func NewScheduledPayment(bp *block.BlockScheduledPayment) *ScheduledPayment {
+	return &ScheduledPayment{
+		bp: bp,
+	}
+}
+
+func (s ScheduledPayment) GetMap() hal.Entry {
+	return hal.Entry{
+		"source":    s.bp.Source,
+		"id":        s.bp.ID,
+		"target":    s.bp.Target,
+		"amount":    s.bp.Amount,
+		"interval":  s.bp.Interval,
+		"next":      s.bp.Next,
+		"remaining": s.bp.Remaining,
+		"reserved":  s.bp.Reserved(),
+		"state":     s.bp.State,
+	}
+}
+
+func (s ScheduledPayment) Resource() *hal.Resource {
+	r := hal.NewResource(s, s.LinkSelf())
+	r.AddNewLink("target", strings.Replace(URLAccounts, "{id}", s.bp.Target, -1))
+	return r
+}
+
+func (s ScheduledPayment) LinkSelf() string {
+	return strings.Replace(URLAccountScheduledPayments, "{id}", s.bp.Source, -1)
+}
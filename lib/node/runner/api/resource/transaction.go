@@ -2,9 +2,11 @@ package resource
 
 import (
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/transaction"
 	"github.com/nvellon/hal"
 	"strings"
+	"time"
 )
 
 type Transaction struct {
@@ -21,7 +23,7 @@ func NewTransaction(bt *block.BlockTransaction, tx transaction.Transaction) *Tra
 }
 
 func (t Transaction) GetMap() hal.Entry {
-	return hal.Entry{
+	entry := hal.Entry{
 		"hash":            t.bt.Hash,
 		"block":           t.bt.Block,
 		"source":          t.bt.Source,
@@ -31,6 +33,14 @@ func (t Transaction) GetMap() hal.Entry {
 		"operation_count": len(t.bt.Operations),
 		"operations":      t.tx.B.Operations,
 	}
+	if t.bt.Memo != nil {
+		entry["memo"] = t.bt.Memo
+	}
+	if len(t.bt.FeePayer) > 0 {
+		entry["fee_payer"] = t.bt.FeePayer
+	}
+
+	return entry
 }
 func (t Transaction) Resource() *hal.Resource {
 
@@ -73,3 +83,47 @@ func (t TransactionStatus) Resource() *hal.Resource {
 func (t TransactionStatus) LinkSelf() string {
 	return strings.Replace(URLTransactionStatus, "{id}", t.Hash, -1)
 }
+
+// PendingTransaction is the transaction in `transaction.Pool`, which is not
+// included in block yet.
+type PendingTransaction struct {
+	tx       transaction.Transaction
+	received time.Time
+	now      time.Time
+}
+
+func NewPendingTransaction(tx transaction.Transaction, received, now time.Time) *PendingTransaction {
+	return &PendingTransaction{
+		tx:       tx,
+		received: received,
+		now:      now,
+	}
+}
+
+func (t PendingTransaction) GetMap() hal.Entry {
+	entry := hal.Entry{
+		"hash":            t.tx.GetHash(),
+		"source":          t.tx.B.Source,
+		"fee":             t.tx.B.Fee.String(),
+		"sequence_id":     t.tx.B.SequenceID,
+		"received":        common.FormatISO8601(t.received),
+		"age":             int64(t.now.Sub(t.received) / time.Second),
+		"operation_count": len(t.tx.B.Operations),
+	}
+	if t.tx.HasFeePayer() {
+		entry["fee_payer"] = t.tx.FeePayer()
+	}
+
+	return entry
+}
+
+func (t PendingTransaction) Resource() *hal.Resource {
+	r := hal.NewResource(t, t.LinkSelf())
+	r.AddLink("account", hal.NewLink(strings.Replace(URLAccounts, "{id}", t.tx.B.Source, -1)))
+	r.AddLink("status", hal.NewLink(strings.Replace(URLTransactionStatus, "{id}", t.tx.GetHash(), -1)))
+	return r
+}
+
+func (t PendingTransaction) LinkSelf() string {
+	return URLTransactionsPending
+}
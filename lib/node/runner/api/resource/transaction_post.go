@@ -15,7 +15,7 @@ type TransactionPost struct {
 func NewTransactionPost(tx transaction.Transaction) *TransactionPost {
 	t := &TransactionPost{
 		tx:   tx,
-		hash: tx.B.MakeHashString(),
+		hash: tx.MakeHashString(),
 	}
 	return t
 }
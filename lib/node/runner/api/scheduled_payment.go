@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/network/httputils"
+	"boscoin.io/sebak/lib/node/runner/api/resource"
+)
+
+// GetAccountScheduledPaymentsHandler returns the scheduled payments created by
+// the account, including the finished and cancelled ones. The active payment
+// is cancelled by the `operation.ScheduledPaymentCancel`.
+func (api NetworkHandlerAPI) GetAccountScheduledPaymentsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["id"]
+
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	if found, err := block.ExistsBlockAccount(api.storage, address); err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	} else if !found {
+		httputils.WriteJSONError(w, errors.BlockAccountDoesNotExists)
+		return
+	}
+
+	var rs []resource.Resource
+	var firstCursor []byte
+	var lastCursor []byte
+
+	iterFunc, closeFunc := block.GetBlockScheduledPaymentsBySource(api.storage, address, p.ListOptions())
+	for {
+		bp, hasNext, c := iterFunc()
+		if !hasNext {
+			break
+		}
+		if len(firstCursor) == 0 {
+			firstCursor = append(firstCursor, c...)
+		}
+		lastCursor = append([]byte{}, c...)
+
+		rs = append(rs, resource.NewScheduledPayment(bp))
+	}
+	closeFunc()
+
+	list := p.ResourceList(rs, firstCursor, lastCursor)
+	httputils.MustWriteJSON(w, 200, list)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -12,6 +14,7 @@ import (
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network/httputils"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction"
 )
 
 func (api NetworkHandlerAPI) GetTransactionsHandler(w http.ResponseWriter, r *http.Request) {
@@ -166,3 +169,107 @@ func (api NetworkHandlerAPI) GetTransactionStatusByHashHandler(w http.ResponseWr
 		httputils.MustWriteJSON(w, 200, payload)
 	}
 }
+
+// GetPendingTransactionsHandler returns the transactions of
+// `transaction.Pool` in order of arrival. The `source` query filters the
+// transactions by source and the cursor is the received time of transaction.
+// With event stream, the new pending transactions are followed by the
+// `observer.TxPool` events.
+func (api NetworkHandlerAPI) GetPendingTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	p, err := NewPageQuery(r)
+	if err != nil {
+		httputils.WriteJSONError(w, err)
+		return
+	}
+
+	source := r.URL.Query().Get("source")
+
+	var after int64
+	if len(p.Cursor()) > 0 {
+		if after, err = strconv.ParseInt(string(p.Cursor()), 10, 64); err != nil {
+			httputils.WriteJSONError(w, errors.BadRequestParameter)
+			return
+		}
+	}
+
+	hashes := api.TransactionPool.Hashes(source)
+	if p.Reverse() {
+		for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+			hashes[i], hashes[j] = hashes[j], hashes[i]
+		}
+	}
+
+	now := time.Now()
+	var firstCursor []byte
+	var cursor []byte
+	var txs []resource.Resource
+	for _, hash := range hashes {
+		if uint64(len(txs)) >= p.Limit() {
+			break
+		}
+
+		tx, found := api.TransactionPool.Get(hash)
+		if !found {
+			continue
+		}
+		received, found := api.TransactionPool.Received(hash)
+		if !found {
+			continue
+		}
+
+		if after != 0 {
+			if p.Reverse() && received.UnixNano() >= after {
+				continue
+			} else if !p.Reverse() && received.UnixNano() <= after {
+				continue
+			}
+		}
+
+		cursor = []byte(strconv.FormatInt(received.UnixNano(), 10))
+		if len(firstCursor) == 0 {
+			firstCursor = cursor
+		}
+		txs = append(txs, resource.NewPendingTransaction(tx, received, now))
+	}
+
+	list := p.ResourceList(txs, firstCursor, cursor)
+
+	if httputils.IsEventStream(r) {
+		renderFunc := func(args ...interface{}) ([]byte, error) {
+			if len(args) <= 1 {
+				return nil, fmt.Errorf("render: value is empty")
+			}
+			i := args[1]
+
+			if i == nil {
+				return nil, nil
+			}
+
+			switch v := i.(type) {
+			case *transaction.Transaction:
+				received, found := api.TransactionPool.Received(v.GetHash())
+				if !found {
+					received = time.Now()
+				}
+				r := resource.NewPendingTransaction(*v, received, time.Now())
+				return json.Marshal(r.Resource())
+			case httputils.HALResource:
+				return json.Marshal(v.Resource())
+			}
+
+			return json.Marshal(i)
+		}
+
+		event := o.Event(o.NewCondition(o.TxPool, o.All))
+		if len(source) > 0 {
+			event = o.Event(o.NewCondition(o.TxPool, o.Source, source))
+		}
+
+		es := NewEventStream(w, r, renderFunc, DefaultContentType)
+		es.Render(list)
+		es.Run(o.ResourceObserver, event)
+		return
+	}
+
+	httputils.MustWriteJSON(w, 200, list)
+}
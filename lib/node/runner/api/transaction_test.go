@@ -5,13 +5,18 @@ import (
 	"bufio"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/require"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/node/runner/api/resource"
+	"boscoin.io/sebak/lib/transaction"
 )
 
 func TestGetTransactionByHashHandler(t *testing.T) {
@@ -262,3 +267,101 @@ func TestGetTransactionsHandlerPage(t *testing.T) {
 		}
 	}
 }
+
+func TestGetPendingTransactionsHandler(t *testing.T) {
+	st := block.InitTestBlockchain()
+	defer st.Close()
+
+	pool := transaction.NewPool(common.NewTestConfig())
+	apiHandler := NetworkHandlerAPI{storage: st, TransactionPool: pool}
+
+	router := mux.NewRouter()
+	router.HandleFunc(GetPendingTransactionsHandlerPattern, apiHandler.GetPendingTransactionsHandler).Methods("GET")
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	kp := keypair.Random()
+	var txs []transaction.Transaction
+	for i := 0; i < 3; i++ {
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = uint64(i)
+		tx.Sign(kp, networkID)
+		txs = append(txs, tx)
+	}
+	_, other := transaction.TestMakeTransaction(networkID, 2)
+	txs = append(txs, other)
+
+	for _, tx := range txs {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	get := func(url string) (records []interface{}, next string) {
+		respBody := request(ts, url, false)
+		defer respBody.Close()
+		readByte, err := ioutil.ReadAll(respBody)
+		require.NoError(t, err)
+
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(readByte, &recv)
+		records = recv["_embedded"].(map[string]interface{})["records"].([]interface{})
+		next = recv["_links"].(map[string]interface{})["next"].(map[string]interface{})["href"].(string)
+		return
+	}
+
+	{ // all
+		records, _ := get(GetPendingTransactionsHandlerPattern)
+		require.Equal(t, len(txs), len(records))
+		for i, r := range records {
+			o := r.(map[string]interface{})
+			require.Equal(t, txs[i].GetHash(), o["hash"])
+			require.Equal(t, txs[i].Source(), o["source"])
+			require.Equal(t, txs[i].B.Fee.String(), o["fee"])
+			require.Equal(t, float64(txs[i].B.SequenceID), o["sequence_id"])
+			require.NotEmpty(t, o["received"])
+		}
+	}
+
+	{ // by source
+		records, _ := get(GetPendingTransactionsHandlerPattern + "?source=" + other.Source())
+		require.Equal(t, 1, len(records))
+		require.Equal(t, other.GetHash(), records[0].(map[string]interface{})["hash"])
+	}
+
+	{ // paging
+		records, next := get(GetPendingTransactionsHandlerPattern + "?limit=3")
+		require.Equal(t, 3, len(records))
+		require.Equal(t, txs[2].GetHash(), records[2].(map[string]interface{})["hash"])
+
+		records, _ = get(next)
+		require.Equal(t, 1, len(records))
+		require.Equal(t, other.GetHash(), records[0].(map[string]interface{})["hash"])
+	}
+
+	{ // reverse
+		records, _ := get(GetPendingTransactionsHandlerPattern + "?reverse=true&limit=1")
+		require.Equal(t, 1, len(records))
+		require.Equal(t, other.GetHash(), records[0].(map[string]interface{})["hash"])
+	}
+
+	{ // stream; the transaction added into pool is followed
+		respBody := request(ts, GetPendingTransactionsHandlerPattern+"?source="+kp.Address(), true)
+		defer respBody.Close()
+		reader := bufio.NewReader(respBody)
+
+		_, err := reader.ReadBytes('\n') // the current pending transactions
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond) // wait for subscribing
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = uint64(len(txs))
+		tx.Sign(kp, networkID)
+		require.NoError(t, pool.Add(tx))
+
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		recv := make(map[string]interface{})
+		common.MustUnmarshalJSON(line, &recv)
+		require.Equal(t, tx.GetHash(), recv["hash"])
+		require.Equal(t, kp.Address(), recv["source"])
+	}
+}
@@ -20,6 +20,7 @@ const (
 	NodeItemBlock            NodeItemDataType = "block"
 	NodeItemBlockHeader      NodeItemDataType = "block-header"
 	NodeItemBlockTransaction NodeItemDataType = "block-transaction"
+	NodeItemBlockCertificate NodeItemDataType = "block-certificate"
 	NodeItemTransaction      NodeItemDataType = "transaction"
 	NodeItemError            NodeItemDataType = "error"
 )
@@ -144,6 +145,12 @@ func (nh NetworkHandlerNode) GetBlocksHandler(w http.ResponseWriter, r *http.Req
 					nh.renderNodeItem(w, NodeItemBlockTransaction, tx)
 				}
 			}
+
+			if bc, err := block.GetBlockCertificate(nh.storage, b.Hash); err == nil {
+				nh.renderNodeItem(w, NodeItemBlockCertificate, bc)
+			} else if err != errors.BlockCertificateDoesNotExists {
+				nh.renderNodeItem(w, NodeItemError, err)
+			}
 		}
 	}
 
@@ -179,6 +186,10 @@ func UnmarshalNodeItemResponse(d []byte) (itemType NodeItemDataType, b interface
 		var t block.BlockTransaction
 		err = unmarshal(&t)
 		b = t
+	case NodeItemBlockCertificate:
+		var t block.BlockCertificate
+		err = unmarshal(&t)
+		b = t
 	case NodeItemTransaction:
 		var t transaction.Transaction
 		err = unmarshal(&t)
@@ -572,6 +572,9 @@ func TestGetBlocksHandlerWithModeFull(t *testing.T) {
 	p.Prepare()
 	defer p.Done()
 
+	bc := block.NewBlockCertificate(p.blocks[1], []json.RawMessage{json.RawMessage(`{"H":{"hash":"a"}}`)})
+	require.NoError(t, bc.Save(p.st))
+
 	{ // by default, mode will be `GetBlocksOptionsModeFull`
 		u := p.URL(nil)
 		u.RawQuery = fmt.Sprintf("mode=%s", GetBlocksOptionsModeFull)
@@ -600,6 +603,12 @@ func TestGetBlocksHandlerWithModeFull(t *testing.T) {
 			expectedNumberOfTransactions += len(b.Transactions)
 		}
 		require.Equal(t, expectedNumberOfTransactions, len(rbs[NodeItemBlockTransaction]))
+
+		// only the block, which has certificate
+		require.Equal(t, 1, len(rbs[NodeItemBlockCertificate]))
+		rbc := rbs[NodeItemBlockCertificate][0].(block.BlockCertificate)
+		require.Equal(t, bc.Hash, rbc.Hash)
+		require.Equal(t, 1, len(rbc.Ballots))
 	}
 }
 
@@ -95,7 +95,6 @@ func (api NetworkHandlerNode) ConnectHandler(w http.ResponseWriter, r *http.Requ
 var HandleTransactionCheckerFuncs = []common.CheckerFunc{
 	TransactionUnmarshal,
 	HasTransaction,
-	MessageHasSameSource,
 	MessageValidate,
 	PushIntoTransactionPoolFromClient,
 	BroadcastTransaction,
@@ -104,7 +103,6 @@ var HandleTransactionCheckerFuncs = []common.CheckerFunc{
 var HandleTransactionCheckerFuncsWithoutBroadcast = []common.CheckerFunc{
 	TransactionUnmarshal,
 	HasTransaction,
-	MessageHasSameSource,
 	MessageValidate,
 	PushIntoTransactionPoolFromNode,
 }
@@ -112,7 +110,6 @@ var HandleTransactionCheckerFuncsWithoutBroadcast = []common.CheckerFunc{
 var HandleTransactionCheckerForWatcherFuncs = []common.CheckerFunc{
 	TransactionUnmarshal,
 	HasTransaction,
-	MessageHasSameSource,
 	MessageValidate,
 	BroadcastTransactionFromWatcher,
 }
@@ -0,0 +1,259 @@
+package runner
+
+import (
+	"sort"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// assetState keeps the trustlines and assets changed by the asset operations
+// on top of the storage, so the asset operations of one transaction or one
+// ballot are checked together before they are finished. The removed
+// trustline is kept with zero `Limit`.
+type assetState struct {
+	st         *storage.LevelDBBackend
+	parent     *assetState
+	trustlines map[string]*block.BlockTrustline
+	assets     map[string]*block.BlockAsset
+}
+
+func newAssetState(st *storage.LevelDBBackend, parent *assetState) *assetState {
+	return &assetState{
+		st:         st,
+		parent:     parent,
+		trustlines: map[string]*block.BlockTrustline{},
+		assets:     map[string]*block.BlockAsset{},
+	}
+}
+
+// getTrustline returns the copy of trustline. If the trustline does not
+// exist or it is removed, nil is returned.
+func (s *assetState) getTrustline(address string, asset common.Asset) (*block.BlockTrustline, error) {
+	key := block.GetBlockTrustlineKey(address, asset)
+
+	var tl *block.BlockTrustline
+	if found, ok := s.trustlines[key]; ok {
+		tl = found
+	} else if s.parent != nil {
+		return s.parent.getTrustline(address, asset)
+	} else {
+		exists, err := block.ExistsBlockTrustline(s.st, address, asset)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, nil
+		}
+		if tl, err = block.GetBlockTrustline(s.st, address, asset); err != nil {
+			return nil, err
+		}
+	}
+
+	if tl.Limit < 1 {
+		return nil, nil
+	}
+
+	copied := *tl
+	return &copied, nil
+}
+
+// getAsset returns the copy of asset. If the asset is not issued yet, the new
+// one is returned.
+func (s *assetState) getAsset(asset common.Asset) (*block.BlockAsset, error) {
+	var ba *block.BlockAsset
+	if found, ok := s.assets[block.GetBlockAssetKey(asset)]; ok {
+		ba = found
+	} else if s.parent != nil {
+		return s.parent.getAsset(asset)
+	} else {
+		var err error
+		if ba, err = block.GetOrNewBlockAsset(s.st, asset); err != nil {
+			return nil, err
+		}
+	}
+
+	copied := *ba
+	return &copied, nil
+}
+
+func (s *assetState) setTrustline(tl *block.BlockTrustline) {
+	s.trustlines[block.GetBlockTrustlineKey(tl.Address, tl.Asset)] = tl
+}
+
+func (s *assetState) setAsset(ba *block.BlockAsset) {
+	s.assets[block.GetBlockAssetKey(ba.Asset())] = ba
+}
+
+// applyTransaction applies the asset operations of transaction.
+func (s *assetState) applyTransaction(tx transaction.Transaction) (err error) {
+	for _, op := range tx.B.Operations {
+		if err = s.apply(tx.B.Source, op); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// apply applies the asset operation of source. The other operations are
+// ignored.
+func (s *assetState) apply(source string, op operation.Operation) (err error) {
+	switch opb := op.B.(type) {
+	case operation.Trust:
+		var tl *block.BlockTrustline
+		if tl, err = s.getTrustline(source, opb.Asset); err != nil {
+			return
+		}
+
+		if opb.IsRemove() {
+			if tl == nil {
+				return errors.TrustlineDoesNotExists
+			}
+			if tl.Balance > 0 {
+				return errors.TrustlineHasBalance
+			}
+		} else if tl == nil {
+			tl = block.NewBlockTrustline(source, opb.Asset, 0)
+		} else if opb.Limit < tl.Balance {
+			return errors.TrustlineLimitExceeded
+		}
+		tl.Limit = opb.Limit
+		s.setTrustline(tl)
+	case operation.IssueAsset:
+		asset := opb.Asset(source)
+		if err = s.deposit(opb.Target, asset, opb.Amount); err != nil {
+			return
+		}
+
+		var ba *block.BlockAsset
+		if ba, err = s.getAsset(asset); err != nil {
+			return
+		}
+		if ba.Issued, err = ba.Issued.Add(opb.Amount); err != nil {
+			return
+		}
+		s.setAsset(ba)
+	case operation.AssetPayment:
+		// the issuer issues the asset by `operation.IssueAsset`
+		if source == opb.Asset.Issuer {
+			return errors.InvalidOperation
+		}
+
+		var tl *block.BlockTrustline
+		if tl, err = s.getTrustline(source, opb.Asset); err != nil {
+			return
+		} else if tl == nil {
+			return errors.TrustlineDoesNotExists
+		}
+		if tl.Balance < opb.Amount {
+			return errors.AssetBalanceNotEnough
+		}
+		tl.Balance = tl.Balance.MustSub(opb.Amount)
+		s.setTrustline(tl)
+
+		if opb.Target != opb.Asset.Issuer {
+			return s.deposit(opb.Target, opb.Asset, opb.Amount)
+		}
+
+		// redeemed by the issuer
+		var ba *block.BlockAsset
+		if ba, err = s.getAsset(opb.Asset); err != nil {
+			return
+		}
+		if ba.Issued, err = ba.Issued.Sub(opb.Amount); err != nil {
+			return
+		}
+		s.setAsset(ba)
+	}
+
+	return
+}
+
+func (s *assetState) deposit(address string, asset common.Asset, amount common.Amount) (err error) {
+	var tl *block.BlockTrustline
+	if tl, err = s.getTrustline(address, asset); err != nil {
+		return
+	} else if tl == nil {
+		return errors.TrustlineDoesNotExists
+	}
+	if tl.Available() < amount {
+		return errors.TrustlineLimitExceeded
+	}
+	tl.Balance = tl.Balance.MustAdd(amount)
+	s.setTrustline(tl)
+
+	return
+}
+
+// merge merges the changes to the parent state.
+func (s *assetState) merge() {
+	for key, tl := range s.trustlines {
+		s.parent.trustlines[key] = tl
+	}
+	for key, ba := range s.assets {
+		s.parent.assets[key] = ba
+	}
+}
+
+// save saves the changes to the storage. The number of trustlines of account
+// is also updated.
+func (s *assetState) save() (err error) {
+	var keys []string
+	for key := range s.trustlines {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tl := s.trustlines[key]
+
+		var exists bool
+		if exists, err = block.ExistsBlockTrustline(s.st, tl.Address, tl.Asset); err != nil {
+			return
+		}
+
+		removed := tl.Limit < 1
+		if removed {
+			if exists {
+				if err = tl.Remove(s.st); err != nil {
+					return
+				}
+			}
+		} else if err = tl.Save(s.st); err != nil {
+			return
+		}
+
+		if exists == removed {
+			var ba *block.BlockAccount
+			if ba, err = block.GetBlockAccount(s.st, tl.Address); err != nil {
+				return errors.BlockAccountDoesNotExists
+			}
+			if removed {
+				ba.Trustlines--
+			} else {
+				ba.Trustlines++
+			}
+			if err = ba.Save(s.st); err != nil {
+				return
+			}
+		}
+	}
+
+	keys = nil
+	for key := range s.assets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err = s.assets[key].Save(s.st); err != nil {
+			return
+		}
+	}
+
+	return
+}
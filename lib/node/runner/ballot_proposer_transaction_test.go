@@ -422,6 +422,48 @@ func TestProposedTransactionWithInflationWrongAmount(t *testing.T) {
 	require.Equal(t, errors.InvalidOperation, err)
 }
 
+func TestProposedTransactionWithScheduledPayments(t *testing.T) {
+	p := &ballotCheckerProposedTransaction{}
+	p.Prepare()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	block.NewBlockAccount(kpt.Address(), common.Amount(common.BaseReserve)).MustSave(p.nr.Storage())
+	payment := block.NewBlockScheduledPayment(kps.Address(), "salary", kpt.Address(), common.Amount(100), p.genesisBlock.Height+1, 1, 2)
+	require.NoError(t, payment.Save(p.nr.Storage()))
+
+	makeChecker := func(blt *ballot.Ballot) *BallotChecker {
+		return &BallotChecker{
+			DefaultChecker: common.DefaultChecker{Funcs: []common.CheckerFunc{BallotValidateOperationBodyScheduledPayments}},
+			NodeRunner:     p.nr,
+			Conf:           p.nr.Conf,
+			LocalNode:      p.nr.Node(),
+			Ballot:         *blt,
+			VotingHole:     voting.NOTYET,
+			Log:            p.nr.Log(),
+		}
+	}
+
+	{ // without the due payment
+		blt := p.MakeBallot(0)
+		err := common.RunChecker(makeChecker(blt), common.DefaultDeferFunc)
+		require.Equal(t, errors.InvalidOperation, err)
+	}
+
+	{ // with the due payment
+		blt := p.MakeBallot(0)
+		ptx := blt.ProposerTransaction()
+		op, _ := operation.NewOperation(operation.NewScheduledPaymentExecute(kps.Address(), "salary", kpt.Address(), common.Amount(100), false))
+		ptx.B.Operations = append(ptx.B.Operations, op)
+		ptx.H.Hash = ptx.B.MakeHashString()
+		blt.SetProposerTransaction(ptx)
+		blt.Sign(p.proposerNode.Keypair(), networkID)
+
+		require.NoError(t, blt.ProposerTransaction().IsWellFormedWithBallot(*blt, p.config))
+		require.NoError(t, common.RunChecker(makeChecker(blt), common.DefaultDeferFunc))
+	}
+}
+
 func TestProposedTransactionWithNotZeroFee(t *testing.T) {
 	p := &ballotCheckerProposedTransaction{}
 	p.Prepare()
@@ -629,6 +671,8 @@ func TestProposedTransactionStoreWithZeroAmount(t *testing.T) {
 	previousCommonAccount, _ := block.GetBlockAccount(p.nr.Storage(), p.commonAccount.Address)
 
 	{
+		VoteAcceptBallot(p.nr, *blt, p.proposerNode, p.config)
+
 		_, _, err := finishBallot(
 			p.nr,
 			*blt,
@@ -714,6 +758,8 @@ func TestProposedTransactionStoreWithAmount(t *testing.T) {
 	previousCommonAccount, _ := block.GetBlockAccount(p.nr.Storage(), p.commonAccount.Address)
 
 	{
+		VoteAcceptBallot(p.nr, *blt, p.proposerNode, p.config)
+
 		_, _, err := finishBallot(
 			p.nr,
 			*blt,
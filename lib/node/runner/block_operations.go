@@ -78,6 +78,63 @@ func (sb *SavingBlockOperations) saveCheckedBlock(height uint64) {
 	return
 }
 
+func (sb *SavingBlockOperations) getTypeIndexKey() string {
+	return fmt.Sprintf("%s-operation-type-index", common.InternalPrefix)
+}
+
+// typeIndexProgress is the progress of `backfillTypeIndex`; the operations
+// of blocks until `Until` can be saved before the type index was introduced.
+type typeIndexProgress struct {
+	Checked uint64 `json:"checked"`
+	Until   uint64 `json:"until"`
+}
+
+// backfillTypeIndex adds the type index to the operations, which were saved
+// before the type index was introduced.
+func (sb *SavingBlockOperations) backfillTypeIndex() (err error) {
+	var progress typeIndexProgress
+
+	var found bool
+	if found, err = sb.st.Has(sb.getTypeIndexKey()); err != nil {
+		return
+	} else if found {
+		if err = sb.st.Get(sb.getTypeIndexKey(), &progress); err != nil {
+			return
+		}
+	} else {
+		progress.Until = block.GetLatestBlock(sb.st).Height
+		if err = sb.st.New(sb.getTypeIndexKey(), progress); err != nil {
+			return
+		}
+	}
+
+	for progress.Checked < progress.Until {
+		height := progress.Checked + 1
+
+		var st *storage.LevelDBBackend
+		if st, err = sb.st.OpenBatch(); err != nil {
+			return
+		}
+		if err = block.SaveBlockOperationTypeIndex(st, height); err != nil {
+			st.Discard()
+			return
+		}
+
+		progress.Checked = height
+		if err = st.Set(sb.getTypeIndexKey(), progress); err != nil {
+			st.Discard()
+			return
+		}
+		if err = st.Commit(); err != nil {
+			st.Discard()
+			return
+		}
+	}
+	sb.log.Debug("type index of BlockOperations is checked", "height", progress.Checked)
+
+	return
+}
+
 func (sb *SavingBlockOperations) getNextBlock(height uint64) (nextBlock block.Block, err error) {
 	if height < sb.checkedBlockHeight {
 		height = sb.checkedBlockHeight
@@ -328,6 +385,11 @@ func (sb *SavingBlockOperations) CheckTransactionByBlock(st *storage.LevelDBBack
 func (sb *SavingBlockOperations) Start() {
 	go sb.continuousCheck()
 	go sb.startSaving()
+	go func() {
+		if err := sb.backfillTypeIndex(); err != nil {
+			sb.log.Error("failed to backfill the type index of BlockOperations", "error", err)
+		}
+	}()
 
 	return
 }
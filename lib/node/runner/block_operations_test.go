@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	"boscoin.io/sebak/lib/common/keypair"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -179,3 +181,78 @@ func TestSavingBlockOperationWithEmptyTransactions(t *testing.T) {
 		require.True(t, exists)
 	}
 }
+
+// The type index of `BlockOperation`s saved by the earlier layout, which has
+// the sequence id and unique id instead of hash, is converted by
+// `backfillTypeIndex`.
+func TestSavingBlockOperationBackfillTypeIndex(t *testing.T) {
+	p := &TestSavingBlockOperationHelper{}
+	p.Prepare()
+	defer p.Done()
+
+	blk := p.makeBlock(block.GetGenesis(p.st), 1)
+
+	sb := NewSavingBlockOperations(p.st, nil)
+	require.NoError(t, sb.Check())
+
+	var bos []block.BlockOperation
+	{
+		iterFunc, closeFunc := block.GetBlockOperationsByBlockHeight(p.st, blk.Height, nil)
+		for {
+			bo, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			bos = append(bos, bo)
+		}
+		closeFunc()
+	}
+	require.True(t, len(bos) > 2)
+
+	// the first operation does not have the type index and the others have
+	// the type index of earlier layout
+	for i, bo := range bos {
+		require.NoError(t, p.st.Remove(bo.NewBlockOperationTypeKey()))
+		if i == 0 {
+			continue
+		}
+
+		key := fmt.Sprintf(
+			"%s%s-%s%s%s",
+			common.BlockOperationPrefixType,
+			string(bo.Type),
+			common.EncodeUint64ToByteSlice(bo.Height),
+			common.EncodeUint64ToByteSlice(uint64(i)),
+			common.GetUniqueIDFromUUID(),
+		)
+		require.NoError(t, p.st.New(key, bo.Hash))
+	}
+
+	require.NoError(t, sb.backfillTypeIndex())
+
+	types := map[operation.OperationType]int{}
+	for _, bo := range bos {
+		types[bo.Type]++
+	}
+	for ty, n := range types {
+		var hashes []string
+		iterFunc, closeFunc := block.GetBlockOperationsByType(p.st, ty, nil)
+		for {
+			bo, hasNext, _ := iterFunc()
+			if !hasNext {
+				break
+			}
+			hashes = append(hashes, bo.Hash)
+		}
+		closeFunc()
+
+		require.Equal(t, n, len(hashes), "type", ty)
+		for _, bo := range bos {
+			if bo.Type != ty {
+				continue
+			}
+			_, found := common.InStringArray(hashes, bo.Hash)
+			require.True(t, found)
+		}
+	}
+}
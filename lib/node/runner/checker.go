@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"time"
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/node/runner/api"
 	"boscoin.io/sebak/lib/storage"
@@ -55,6 +57,8 @@ type BallotChecker struct {
 	Result             consensus.RoundVoteResult
 	VotingFinished     bool
 	FinishedVotingHole voting.Hole
+	// LatestBlockSources is the accounts, which are changed by the latest
+	// block; the sources, the fee payers and the targets of operations.
 	LatestBlockSources []string
 
 	Log logging.Logger
@@ -157,6 +161,30 @@ func BallotValidateOperationBodyInflation(c common.Checker, args ...interface{})
 	return
 }
 
+// BallotValidateOperationBodyScheduledPayments checks the proposer
+// transaction has the scheduled payments, which are due at the block of
+// ballot.
+func BallotValidateOperationBodyScheduledPayments(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotChecker)
+
+	if checker.IsMine || checker.Ballot.Vote() == voting.EXP {
+		return
+	}
+
+	var expected []operation.ScheduledPaymentExecute
+	height := checker.NodeRunner.Consensus().LatestBlock().Height + 1
+	if expected, err = NewScheduledPaymentExecutes(checker.NodeRunner.Storage(), height); err != nil {
+		return
+	}
+
+	if !isScheduledPaymentsMatched(expected, checker.Ballot.ProposerTransaction().ScheduledPayments()) {
+		err = errors.InvalidOperation
+		return
+	}
+
+	return
+}
+
 // BallotNotFromKnownValidators checks the incoming ballot
 // is from the known validators.
 func BallotNotFromKnownValidators(c common.Checker, args ...interface{}) (err error) {
@@ -175,6 +203,40 @@ func BallotNotFromKnownValidators(c common.Checker, args ...interface{}) (err er
 	return
 }
 
+// BallotCheckEquivocation checks whether the source already sent the other
+// ballot for the same basis and state. If the ballots conflict, the evidence
+// is stored and the ballot is rejected.
+func BallotCheckEquivocation(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotChecker)
+	if checker.IsMine {
+		return
+	}
+
+	kept, found := checker.NodeRunner.Consensus().ConflictingBallot(checker.Ballot)
+	if !found {
+		return
+	}
+
+	var evidence block.Evidence
+	if evidence, err = ballot.NewEvidence(kept, checker.Ballot); err != nil {
+		return
+	}
+	if ballot.VerifyEvidence(evidence, checker.Conf.NetworkID) != nil {
+		// the ballot can not prove the misbehavior of source
+		return nil
+	}
+
+	if err = evidence.Save(checker.NodeRunner.Storage()); err == nil {
+		metrics.Consensus.AddEvidence(evidence.State)
+		checker.Log.Warn("found conflicting ballot", "conflicting", kept.GetHash())
+	} else if err != errors.AlreadySaved {
+		return
+	}
+
+	err = errors.BallotEquivocation
+	return
+}
+
 // BallotCheckSYNC performs sync by considering sync condition.
 // And to participate in the consensus,
 // update the latestblock by referring to the database.
@@ -272,7 +334,11 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 			is.LatestBallot,
 			checker.Log,
 		)
-		if err != nil {
+		if err == errors.BlockCertificateDoesNotExists { // the block with the certificate is fetched by sync
+			log.Debug("start sync; ACCEPT ballots of latest ballot not found", "latest-ballot", is.LatestBallot.GetHash())
+			is.StartSync(syncHeight, nodeAddrs)
+			return NewCheckerStopCloseConsensus(checker, "ballot makes node in sync")
+		} else if err != nil {
 			log.Debug("failed to finish latest ballot; latestHeight == syncHeight-1", "latest-ballot", is.LatestBallot, "error", err)
 			return err
 		}
@@ -281,7 +347,11 @@ func BallotCheckSYNC(c common.Checker, args ...interface{}) error {
 		checker.NodeRunner.TransitISAACState(b.VotingBasis(), ballot.StateALLCONFIRM)
 		log.Debug("finish current ballot; latestHeight == syncHeight-1", "ballot", b.GetHash())
 		blk, _, err = finishBallot(checker.NodeRunner, b, checker.Log)
-		if err != nil {
+		if err == errors.BlockCertificateDoesNotExists {
+			log.Debug("start sync; ACCEPT ballots of current ballot not found", "ballot", b.GetHash())
+			is.StartSync(syncHeight, nodeAddrs)
+			return NewCheckerStopCloseConsensus(checker, "ballot makes node in sync")
+		} else if err != nil {
 			log.Debug("failed to finish current ballot; latestHeight == syncHeight-1", "current-ballot", b, "error", err)
 			return err
 		}
@@ -495,11 +565,33 @@ func insertMissingTransaction(nr *NodeRunner, ballot ballot.Ballot) (err error)
 			return
 		}
 
-		if err = ValidateTx(nr.Storage(), nr.Conf, tx); err != nil {
-			return
+		receivedTransaction = append(receivedTransaction, tx)
+	}
+
+	// the received transactions are validated in order of ballot, with the
+	// known transactions of same source.
+	received := map[string]transaction.Transaction{}
+	for _, tx := range receivedTransaction {
+		received[tx.GetHash()] = tx
+	}
+
+	accounts := NewRunningAccounts(nr.Storage(), nr.Conf, nr.NetworkParameters())
+	transactionCache := NewTransactionCache(nr.Storage(), nr.TransactionPool)
+	for _, hash := range ballot.Transactions() {
+		tx, isReceived := received[hash]
+		if !isReceived {
+			var found bool
+			if tx, found, err = transactionCache.Get(hash); err != nil {
+				return
+			} else if !found {
+				continue
+			}
 		}
 
-		receivedTransaction = append(receivedTransaction, tx)
+		if err = accounts.Validate(tx); err != nil && isReceived {
+			return
+		}
+		err = nil
 	}
 
 	var bs *storage.LevelDBBackend
@@ -541,7 +633,13 @@ var INITBallotTransactionCheckerFuncs = []common.CheckerFunc{
 	IsNew,
 	CheckMissingTransaction,
 	BallotTransactionsOperationLimit,
+	BallotTransactionsHashLocks,
+	BallotTransactionsCongressVotes,
+	BallotTransactionsScheduledPayments,
+	BallotTransactionsAssets,
 	BallotTransactionsSameSource,
+	BallotTransactionsAccountMerge,
+	BallotTransactionsTimeBounds,
 	BallotTransactionsOperationBodyCollectTxFee,
 	BallotTransactionsAllValid,
 }
@@ -697,7 +795,9 @@ func FinishedBallotStore(c common.Checker, args ...interface{}) error {
 		defer checker.NodeRunner.NextHeight()
 		checker.NodeRunner.Consensus().SetLatestVotingBasis(basis)
 
-		checker.NodeRunner.TransactionPool.RemoveFromSources(checker.LatestBlockSources...)
+		// the pool is cleaned up before the next proposal
+		checker.NodeRunner.removeStaleTransactions(checker.LatestBlockSources...)
+		checker.NodeRunner.evictTransactions(time.Now(), checker.LatestBlockSources...)
 		checker.NodeRunner.Consensus().RemoveRunningRoundsLowerOrEqualHeight(basis.Height)
 		checker.NodeRunner.RemoveSendRecordsLowerThanOrEqualHeight(basis.Height)
 
@@ -733,8 +833,29 @@ func saveBlock(checker *BallotChecker) error {
 		checker.LocalNode.SetConsensus()
 	}
 
+	// the accounts, which are changed by the new block
 	for _, tx := range proposedTransactions {
 		checker.LatestBlockSources = append(checker.LatestBlockSources, tx.B.Source)
+		if tx.HasFeePayer() {
+			checker.LatestBlockSources = append(checker.LatestBlockSources, tx.FeePayer())
+		}
+		for _, op := range tx.B.Operations {
+			if pop, ok := op.B.(operation.Targetable); ok {
+				checker.LatestBlockSources = append(checker.LatestBlockSources, pop.TargetAddress())
+			}
+		}
+	}
+	for _, payment := range checker.Ballot.ProposerTransaction().ScheduledPayments() {
+		checker.LatestBlockSources = append(checker.LatestBlockSources, payment.Source, payment.Target)
+	}
+
+	// drop the transactions, which can not be included in the next blocks
+	if t, err := common.ParseISO8601(blk.ProposedTime); err == nil {
+		expired := checker.NodeRunner.TransactionPool.RemoveExpired(blk.Height+1, t)
+		checker.NodeRunner.deleteRemovedTransactions(expired...)
+		if len(expired) > 0 {
+			checker.Log.Debug("expired transactions removed from pool", "expired", len(expired))
+		}
 	}
 	checker.NodeRunner.SavingBlockOperations().Save(*blk)
 
@@ -4,6 +4,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
@@ -129,14 +130,114 @@ func BallotTransactionsOperationLimit(c common.Checker, args ...interface{}) err
 	return nil
 }
 
-// BallotTransactionsSameSource checks there are transactions which has same
-// source in the `Transactions`.
+// BallotTransactionsSameSource checks the transactions in the `Transactions`
+// are valid together; every transaction is validated in order against the
+// running state of it's source and fee payer, so the transactions of same
+// source must have the consecutive sequence ids and the source must pay for
+// all of them.
 func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*BallotTransactionChecker)
 
+	var tx transaction.Transaction
+	var found bool
+
+	var validTransactions []string
+	accounts := NewRunningAccounts(checker.NodeRunner.Storage(), checker.NodeRunner.Conf, checker.NodeRunner.NetworkParameters())
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		if err = accounts.Validate(tx); err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			continue
+		}
+
+		validTransactions = append(validTransactions, hash)
+	}
+	err = nil
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsAccountMerge checks there are transactions which targets
+// the account merged by the other transaction in the `Transactions`.
+func BallotTransactionsAccountMerge(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	var tx transaction.Transaction
+	var found bool
+
+	merged := map[string]bool{}
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		for _, op := range tx.B.Operations {
+			if op.H.Type == operation.TypeAccountMerge {
+				merged[tx.B.Source] = true
+			}
+		}
+	}
+	if len(merged) < 1 {
+		return
+	}
+
 	var validTransactions []string
-	sources := map[string]bool{}
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		if isTargetingAccounts(tx, merged) {
+			if !checker.CheckTransactionsOnly {
+				err = errors.AccountMergedInBallot
+				return
+			}
+			continue
+		}
+
+		validTransactions = append(validTransactions, hash)
+	}
+	err = nil
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+func isTargetingAccounts(tx transaction.Transaction, accounts map[string]bool) bool {
+	for _, op := range tx.B.Operations {
+		index := operation.GetIndex(op.H.Type, op.B)
+		if accounts[index.Target] || accounts[index.Linked] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BallotTransactionsTimeBounds checks the transactions can be included in the
+// next block by their `transaction.TimeBounds`.
+func BallotTransactionsTimeBounds(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	var height uint64
+	var t time.Time
+	if height, t, err = getTimeBoundsBasis(checker.NodeRunner.Storage()); err != nil {
+		return
+	}
 
+	var validTransactions []string
 	var tx transaction.Transaction
 	var found bool
 	for _, hash := range checker.ValidTransactions {
@@ -146,15 +247,13 @@ func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err er
 			continue
 		}
 
-		if found := common.InStringMap(sources, tx.B.Source); found {
+		if err = tx.CheckTimeBounds(height, t); err != nil {
 			if !checker.CheckTransactionsOnly {
-				err = errors.TransactionSameSourceInBallot
 				return
 			}
 			continue
 		}
 
-		sources[tx.B.Source] = true
 		validTransactions = append(validTransactions, hash)
 	}
 	err = nil
@@ -163,6 +262,218 @@ func BallotTransactionsSameSource(c common.Checker, args ...interface{}) (err er
 	return
 }
 
+// BallotTransactionsHashLocks checks the hash lock operations can be included
+// in the next block; the claims and refunds are checked by the height of next
+// block and the same lock can not be used by the multiple operations in one
+// ballot.
+func BallotTransactionsHashLocks(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	st := checker.NodeRunner.Storage()
+	height := block.GetLatestBlock(st).Height + 1
+
+	var validTransactions []string
+	var tx transaction.Transaction
+	var found bool
+	locks := map[string]bool{}
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		var keys []string
+		for _, op := range tx.B.Operations {
+			switch op.H.Type {
+			case operation.TypeHashLockCreate, operation.TypeHashLockClaim, operation.TypeHashLockRefund:
+			default:
+				continue
+			}
+
+			var key string
+			if key, err = checkHashLockOperation(st, tx.B.Source, op, height); err != nil {
+				break
+			}
+			if _, found := locks[key]; found {
+				err = errors.HashLockNotLocked
+				break
+			}
+			keys = append(keys, key)
+		}
+
+		if err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			err = nil
+			continue
+		}
+
+		for _, key := range keys {
+			locks[key] = true
+		}
+		validTransactions = append(validTransactions, hash)
+	}
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsCongressVotes checks the congress votes and voting
+// results can be included in the next block; the member can not vote twice
+// and the voting can not have the multiple results in one ballot.
+func BallotTransactionsCongressVotes(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	st := checker.NodeRunner.Storage()
+	height := block.GetLatestBlock(st).Height + 1
+
+	var validTransactions []string
+	var tx transaction.Transaction
+	var found bool
+	votes := map[string]bool{}
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		var keys []string
+		for _, op := range tx.B.Operations {
+			switch op.H.Type {
+			case operation.TypeCongressVote, operation.TypeCongressVotingResult:
+			default:
+				continue
+			}
+
+			var key string
+			if key, err = checkCongressOperation(st, tx.B.Source, op, height); err != nil {
+				break
+			}
+			if _, found := votes[key]; found {
+				if op.H.Type == operation.TypeCongressVote {
+					err = errors.CongressVoteAlreadyCast
+				} else {
+					err = errors.CongressVotingResultAlreadyExists
+				}
+				break
+			}
+			keys = append(keys, key)
+		}
+
+		if err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			err = nil
+			continue
+		}
+
+		for _, key := range keys {
+			votes[key] = true
+		}
+		validTransactions = append(validTransactions, hash)
+	}
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsScheduledPayments checks the scheduled payment
+// operations can be included in the next block; the same scheduled payment
+// can not be used by the multiple operations in one ballot.
+func BallotTransactionsScheduledPayments(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	st := checker.NodeRunner.Storage()
+	height := block.GetLatestBlock(st).Height + 1
+
+	var validTransactions []string
+	var tx transaction.Transaction
+	var found bool
+	payments := map[string]bool{}
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		var keys []string
+		for _, op := range tx.B.Operations {
+			switch op.H.Type {
+			case operation.TypeScheduledPaymentCreate, operation.TypeScheduledPaymentCancel:
+			default:
+				continue
+			}
+
+			var key string
+			if key, err = checkScheduledPaymentOperation(st, tx.B.Source, op, height); err != nil {
+				break
+			}
+			if _, found := payments[key]; found {
+				err = errors.ScheduledPaymentAlreadyExists
+				break
+			}
+			keys = append(keys, key)
+		}
+
+		if err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			err = nil
+			continue
+		}
+
+		for _, key := range keys {
+			payments[key] = true
+		}
+		validTransactions = append(validTransactions, hash)
+	}
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
+// BallotTransactionsAssets checks the asset operations can be included in the
+// next block; the trustlines and the issued assets are changed by the
+// transactions in order, so the balance of trustline can not be spent twice in
+// one ballot.
+func BallotTransactionsAssets(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*BallotTransactionChecker)
+
+	state := newAssetState(checker.NodeRunner.Storage(), nil)
+
+	var validTransactions []string
+	var tx transaction.Transaction
+	var found bool
+	for _, hash := range checker.ValidTransactions {
+		if tx, found, err = checker.transactionCache.Get(hash); err != nil {
+			return
+		} else if !found {
+			continue
+		}
+
+		txState := newAssetState(state.st, state)
+		if err = txState.applyTransaction(tx); err != nil {
+			if !checker.CheckTransactionsOnly {
+				return
+			}
+			err = nil
+			continue
+		}
+
+		txState.merge()
+		validTransactions = append(validTransactions, hash)
+	}
+	checker.setValidTransactions(validTransactions)
+
+	return
+}
+
 // BallotTransactionsOperationBodyCollectTxFee validates the
 // `BallotTransactionsOperationBodyCollectTxFee.Amount` is matched with the
 // collected fee of all transactions.
@@ -242,8 +553,28 @@ func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction
 		return errors.BlockAccountDoesNotExists
 	}
 
-	// check, version is correct
-	if !tx.IsValidVersion(common.TransactionVersionV1) {
+	// check, fee payer exists
+	var payer *block.BlockAccount
+	if tx.HasFeePayer() {
+		if payer, err = block.GetBlockAccount(st, tx.FeePayer()); err != nil {
+			return errors.BlockAccountDoesNotExists
+		}
+	}
+
+	var params operation.NetworkParameters
+	if params, err = block.GetNetworkParameters(st); err != nil {
+		return
+	}
+
+	return validateTxWithAccount(st, config, params.Upgrade(config), ba, payer, tx)
+}
+
+// validateTxWithAccount validates the transaction against the given source
+// and fee payer account instead of the stored ones. `payer` is nil if the
+// transaction does not have the fee payer.
+func validateTxWithAccount(st *storage.LevelDBBackend, config common.Config, params operation.NetworkParameters, ba, payer *block.BlockAccount, tx transaction.Transaction) (err error) {
+	// check, version is accepted at the next block
+	if !isAcceptedTransactionVersion(st, params, tx) {
 		err = errors.InvalidMessageVersion
 		return
 	}
@@ -254,7 +585,34 @@ func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction
 		return
 	}
 
-	totalAmount := tx.TotalAmount(true)
+	// check, signatures reach the threshold of source account
+	if err = validateTxSignatures(ba, tx); err != nil {
+		return
+	}
+
+	// check, transaction is not expired; the transaction, which is not yet
+	// valid, is kept in the pool and it is checked by
+	// `BallotTransactionsTimeBounds` when it is proposed.
+	if tx.B.TimeBounds != nil {
+		var height uint64
+		var t time.Time
+		if height, t, err = getTimeBoundsBasis(st); err != nil {
+			return
+		}
+		if tx.IsExpired(height, t) {
+			err = errors.TransactionExpired
+			return
+		}
+	}
+
+	// check, fee payer can pay the fee
+	if payer != nil {
+		if err = validateFeePayer(st, payer, tx); err != nil {
+			return
+		}
+	}
+
+	totalAmount := tx.SourceAmount()
 
 	// check, have enough balance at sequenceID
 	if ba.Balance < totalAmount {
@@ -262,234 +620,1187 @@ func ValidateTx(st *storage.LevelDBBackend, config common.Config, tx transaction
 		return
 	}
 
+	// check, vesting account spends only the vested balance
+	if ba.IsVesting() && ba.Spendable(block.GetLatestBlock(st).Height+1) < totalAmount {
+		err = errors.VestingNotVested
+		return
+	}
+
+	// check, fee follows the fee schedule
+	if !tx.IsValidFee(params.FeeSchedule) {
+		err = errors.InvalidFee
+		return
+	}
+
 	for _, op := range tx.B.Operations {
-		if err = ValidateOp(st, config, ba, op); err != nil {
+		if err = ValidateOp(st, config, ba, op, tx); err != nil {
 			return
 		}
 	}
 
+	// check, the asset operations are valid together
+	if err = newAssetState(st, nil).applyTransaction(tx); err != nil {
+		return
+	}
+
+	// check, the balance keeps the reserve of data entries and trustlines
+	if err = validateReserve(st, ba, tx); err != nil {
+		return
+	}
+
 	return
 }
 
-//
-// Validate an operation
-//
-// This function is critical for consensus, as it defines the validation rules for an operation,
-// and by extension a transaction. It is called from ValidateTx.
-//
-// Params:
-//   st = Storage backend to use (e.g. to access the blocks)
-//        Only ever read from, never written to.
-//   config = consist of configuration of the network. common address, congress address, etc.
-//   source = Account from where the transaction (and ops) come from
-//   tx = Transaction to check
-//
-func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
-
-	var funcIsFrozenPayable = func(source *block.BlockAccount) (err error) {
-		// Unfreezing must be done after X period from unfreezing request
-		iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
-		bo, _, _ := iterFunc() //Get the first operation submitted by the source(frozen) account
-		closeFunc()
-		// Before unfreezing payment, unfreezing request shoud be saved
-		if bo.Type != operation.TypeUnfreezingRequest {
-			return errors.UnfreezingRequestNotRequested
-		}
-		lastblock := block.GetLatestBlock(st)
-		// unfreezing period is 241920.
-		if lastblock.Height-bo.Height < common.UnfreezingPeriod {
-			return errors.UnfreezingNotReachedExpiration
-		}
-		return nil
+// isAcceptedTransactionVersion checks the version of transaction is accepted
+// at the next block by the `operation.NetworkParameters`. The latest block is
+// read only if the version is not accepted from the first block.
+func isAcceptedTransactionVersion(st *storage.LevelDBBackend, params operation.NetworkParameters, tx transaction.Transaction) bool {
+	if params.IsAcceptedTransactionVersion(tx.H.Version, common.FirstProposedBlockHeight) {
+		return true
 	}
 
-	switch op.H.Type {
-	case operation.TypeCreateAccount:
-		var ok bool
-		var casted operation.CreateAccount
-		if casted, ok = op.B.(operation.CreateAccount); !ok {
-			return errors.TypeOperationBodyNotMatched
-		}
-
-		if exists, err := block.ExistsBlockAccount(st, casted.Target); err == nil && exists {
-			return errors.BlockAccountAlreadyExists
-		}
+	return params.IsAcceptedTransactionVersion(tx.H.Version, block.GetLatestBlock(st).Height+1)
+}
 
-		if source.IsFrozen() {
-			if err = funcIsFrozenPayable(source); err != nil {
-				return err
-			}
-		}
+// validateFeePayer checks the fee payer can pay the fee of transaction and
+// the signatures of `FeeBump` envelope reach the low threshold of fee payer.
+func validateFeePayer(st *storage.LevelDBBackend, payer *block.BlockAccount, tx transaction.Transaction) (err error) {
+	if payer.IsFrozen() && !payer.IsVesting() {
+		return errors.InvalidFeePayer
+	}
 
-	case operation.TypePayment:
-		var ok bool
-		var casted operation.Payment
-		if casted, ok = op.B.(operation.Payment); !ok {
-			return errors.TypeOperationBodyNotMatched
+	if payer.Balance < tx.B.Fee {
+		return errors.TransactionExcessAbilityToPay
+	}
+	if payer.IsVesting() && payer.Spendable(block.GetLatestBlock(st).Height+1) < tx.B.Fee {
+		return errors.VestingNotVested
+	}
+
+	return validateSignatures(payer, tx.F.Signers(), common.ThresholdLow)
+}
+
+// RunningAccounts validates the transactions in order against the running
+// state of their source accounts. The balance and sequence id of source are
+// updated by each valid transaction, so the consecutive transactions of same
+// source can be validated before they are stored in block.
+type RunningAccounts struct {
+	st       *storage.LevelDBBackend
+	config   common.Config
+	params   operation.NetworkParameters
+	accounts map[string]*block.BlockAccount
+	merged   map[string]bool
+}
+
+func NewRunningAccounts(st *storage.LevelDBBackend, config common.Config, params operation.NetworkParameters) *RunningAccounts {
+	return &RunningAccounts{
+		st:       st,
+		config:   config,
+		params:   params,
+		accounts: map[string]*block.BlockAccount{},
+		merged:   map[string]bool{},
+	}
+}
+
+func (r *RunningAccounts) get(source string) (ba *block.BlockAccount, err error) {
+	if r.merged[source] {
+		return nil, errors.AccountMergedInBallot
+	}
+
+	var found bool
+	if ba, found = r.accounts[source]; found {
+		return
+	}
+	if ba, err = block.GetBlockAccount(r.st, source); err != nil {
+		return nil, errors.BlockAccountDoesNotExists
+	}
+
+	return
+}
+
+// apply updates the running state of source and fee payer by the
+// transaction.
+func (r *RunningAccounts) apply(ba, payer *block.BlockAccount, tx transaction.Transaction) (err error) {
+	var running block.BlockAccount
+	if running, err = countReserveEntries(r.st, ba, tx); err != nil {
+		return
+	}
+	running.Balance = ba.Balance.MustSub(tx.SourceAmount())
+	running.IncreaseSequenceID()
+	r.accounts[tx.B.Source] = &running
+
+	if payer != nil {
+		runningPayer := *payer
+		runningPayer.Balance = payer.Balance.MustSub(tx.B.Fee)
+		r.accounts[tx.FeePayer()] = &runningPayer
+	}
+
+	for _, op := range tx.B.Operations {
+		if op.H.Type == operation.TypeAccountMerge {
+			r.merged[tx.B.Source] = true
 		}
-		var taccount *block.BlockAccount
-		var err error
-		if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
-			return errors.BlockAccountDoesNotExists
+	}
+
+	return
+}
+
+// Validate validates the transaction with the running state of source. If
+// it is valid, the state of source is updated.
+func (r *RunningAccounts) Validate(tx transaction.Transaction) (err error) {
+	var ba, payer *block.BlockAccount
+	if ba, err = r.get(tx.B.Source); err != nil {
+		return
+	}
+	if tx.HasFeePayer() {
+		if payer, err = r.get(tx.FeePayer()); err != nil {
+			return
 		}
+	}
+
+	if err = validateTxWithAccount(r.st, r.config, r.params, ba, payer, tx); err != nil {
+		return
+	}
+
+	return r.apply(ba, payer, tx)
+}
+
+// validateReserve checks the balance of source account keeps the reserve of
+// it's data entries and trustlines after the transaction.
+func validateReserve(st *storage.LevelDBBackend, source *block.BlockAccount, tx transaction.Transaction) (err error) {
+	for _, op := range tx.B.Operations {
+		if op.H.Type == operation.TypeAccountMerge {
+			// the data entries are deleted with the account
+			return nil
+		}
+	}
+
+	var after block.BlockAccount
+	if after, err = countReserveEntries(st, source, tx); err != nil {
+		return
+	}
+
+	if after.DataEntries+after.Trustlines < 1 {
+		return nil
+	}
 
-		// If it's a frozen account, it cannot receive payment
-		if taccount.IsFrozen() {
-			return errors.FrozenAccountNoDeposit
+	if source.Balance.MustSub(tx.SourceAmount()) < after.Reserve() {
+		if after.Trustlines > source.Trustlines {
+			return errors.TrustlineNotEnoughReserve
 		}
+		return errors.DataEntryNotEnoughReserve
+	}
 
-		// The source account is frozen account
-		if source.IsFrozen() {
-			if err = funcIsFrozenPayable(source); err != nil {
-				return err
+	return nil
+}
+
+// countReserveEntries returns the copy of source account with the number of
+// data entries and trustlines after the transaction.
+func countReserveEntries(st *storage.LevelDBBackend, source *block.BlockAccount, tx transaction.Transaction) (after block.BlockAccount, err error) {
+	after = *source
+	for _, op := range tx.B.Operations {
+		switch casted := op.B.(type) {
+		case operation.ManageData:
+			var exists bool
+			if exists, err = block.ExistsBlockAccountData(st, source.Address, casted.Key); err != nil {
+				return
+			}
+			if exists && casted.IsDelete() {
+				after.DataEntries--
+			} else if !exists && !casted.IsDelete() {
+				after.DataEntries++
+			}
+		case operation.Trust:
+			var exists bool
+			if exists, err = block.ExistsBlockTrustline(st, source.Address, casted.Asset); err != nil {
+				return
+			}
+			if exists && casted.IsRemove() {
+				after.Trustlines--
+			} else if !exists && !casted.IsRemove() {
+				after.Trustlines++
 			}
 		}
-	case operation.TypeUnfreezingRequest:
-		if _, ok := op.B.(operation.UnfreezeRequest); !ok {
-			return errors.TypeOperationBodyNotMatched
-		}
-		// Unfreezing should be done from a frozen account
-		if !source.IsFrozen() {
-			return errors.UnfreezingFromInvalidAccount
-		}
-		// Repeated unfreeze request shoud be blocked after unfreeze request saved
-		iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
-		bo, _, _ := iterFunc()
-		closeFunc()
-		if bo.Type == operation.TypeUnfreezingRequest {
-			return errors.UnfreezingRequestAlreadyReceived
-		}
-	case operation.TypeInflationPF:
-		var ok bool
-		var inflationPF operation.InflationPF
-		if inflationPF, ok = op.B.(operation.InflationPF); !ok {
-			return errors.TypeOperationBodyNotMatched
-		}
-		var taccount *block.BlockAccount
-		if taccount, err = block.GetBlockAccount(st, inflationPF.FundingAddress); err != nil {
-			return errors.BlockAccountDoesNotExists
+	}
+
+	return
+}
+
+// getTimeBoundsBasis returns the height of the next block and the proposed
+// time of the latest block, which `transaction.TimeBounds` is checked with.
+func getTimeBoundsBasis(st *storage.LevelDBBackend) (height uint64, t time.Time, err error) {
+	latest := block.GetLatestBlock(st)
+	if t, err = common.ParseISO8601(latest.ProposedTime); err != nil {
+		return
+	}
+	height = latest.Height + 1
+
+	return
+}
+
+// validateTxSignatures checks the signers of transaction are the signers of
+// source account and the sum of their weights reaches the threshold for the
+// operations.
+func validateTxSignatures(ba *block.BlockAccount, tx transaction.Transaction) (err error) {
+	if !ba.HasSigners() && len(tx.H.Signatures) < 1 {
+		// the signature of source is already verified by
+		// `Transaction.IsWellFormed()`
+		return
+	}
+
+	return validateSignatures(ba, tx.Signers(), tx.ThresholdLevel())
+}
+
+// validateSignatures checks the signers are the signers of account and the
+// sum of their weights reaches the threshold of level.
+func validateSignatures(ba *block.BlockAccount, signers []string, level common.ThresholdLevel) (err error) {
+	weights := map[string]uint32{}
+	for _, signer := range ba.GetSigners() {
+		weights[signer.Address] = signer.Weight
+	}
+
+	var total uint64
+	for _, signer := range signers {
+		weight, found := weights[signer]
+		if !found {
+			return errors.TransactionUnknownSigner
+		}
+		total += uint64(weight)
+	}
+
+	if total < uint64(ba.Thresholds.Get(level)) {
+		return errors.TransactionInsufficientSignatures
+	}
+
+	return
+}
+
+//
+// Validate an operation
+//
+// This function is critical for consensus, as it defines the validation rules for an operation,
+// and by extension a transaction. It is called from ValidateTx.
+//
+// Params:
+//   st = Storage backend to use (e.g. to access the blocks)
+//        Only ever read from, never written to.
+//   config = consist of configuration of the network. common address, congress address, etc.
+//   source = Account from where the transaction (and ops) come from
+//   tx = Transaction to check
+//
+func ValidateOp(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation, tx transaction.Transaction) (err error) {
+	handler, found := getOperationHandler(op.H.Type)
+	if !found || handler.Validate == nil {
+		return errors.UnknownOperationType
+	}
+
+	if source.IsVesting() {
+		if err = validateVestingOp(st, source, op); err != nil {
+			return
+		}
+	}
+
+	if err = handler.Validate(st, config, source, op); err != nil {
+		return
+	}
+
+	return validateAccountFlags(st, source, op, tx)
+}
+
+// validateAccountFlags checks the operation is accepted by the flags of the
+// account, which receives it.
+//  * `operation.Payment`, `operation.AssetPayment`,
+//  `operation.ScheduledPaymentCreate`, `operation.HashLockCreate` and
+//  `operation.AccountMerge`: the target with `common.AccountFlagRequireMemo`
+//  requires the memo of transaction and the target with
+//  `common.AccountFlagBlockIncoming` accepts only the allowed source.
+//  * `operation.CreateAccount` and `operation.CreateVestingAccount`: the
+//  linked account with `common.AccountFlagAuthRequired` accepts only the
+//  allowed source.
+func validateAccountFlags(st *storage.LevelDBBackend, source *block.BlockAccount, op operation.Operation, tx transaction.Transaction) (err error) {
+	switch opb := op.B.(type) {
+	case operation.Payment:
+		return validateIncomingFlags(st, source, opb.Target, tx)
+	case operation.AssetPayment:
+		return validateIncomingFlags(st, source, opb.Target, tx)
+	case operation.ScheduledPaymentCreate:
+		return validateIncomingFlags(st, source, opb.Target, tx)
+	case operation.HashLockCreate:
+		return validateIncomingFlags(st, source, opb.Target, tx)
+	case operation.AccountMerge:
+		return validateIncomingFlags(st, source, opb.Target, tx)
+	case operation.CreateAccount:
+		return validateLinkedFlags(st, source, opb.Linked)
+	case operation.CreateVestingAccount:
+		return validateLinkedFlags(st, source, opb.Linked)
+	}
+
+	return
+}
+
+func validateLinkedFlags(st *storage.LevelDBBackend, source *block.BlockAccount, address string) (err error) {
+	if len(address) < 1 {
+		return
+	}
+
+	var linked *block.BlockAccount
+	if linked, err = block.GetBlockAccount(st, address); err != nil {
+		// the account, which does not exist, does not have flags
+		return nil
+	}
+	if linked.Flags.Has(common.AccountFlagAuthRequired) && !linked.IsAllowed(source.Address) {
+		return errors.AccountNotAllowed
+	}
+
+	return
+}
+
+func validateIncomingFlags(st *storage.LevelDBBackend, source *block.BlockAccount, address string, tx transaction.Transaction) (err error) {
+	var target *block.BlockAccount
+	if target, err = block.GetBlockAccount(st, address); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	if target.Flags.Has(common.AccountFlagRequireMemo) && tx.B.Memo == nil {
+		return errors.AccountRequiresMemo
+	}
+	if target.Flags.Has(common.AccountFlagBlockIncoming) && !target.IsAllowed(source.Address) {
+		return errors.AccountNotAllowed
+	}
+
+	return
+}
+
+// validateVestingOp checks the operation of vesting account spends only the
+// vested balance. The vesting account can be merged after the whole balance is
+// vested.
+func validateVestingOp(st *storage.LevelDBBackend, source *block.BlockAccount, op operation.Operation) (err error) {
+	height := block.GetLatestBlock(st).Height + 1
+
+	switch casted := op.B.(type) {
+	case operation.AccountMerge:
+		if source.Vesting.Locked(height) > 0 {
+			return errors.VestingNotVested
 		}
-		// If it's a frozen account, it cannot receive payment
-		if taccount.IsFrozen() {
-			return errors.FrozenAccountNoDeposit
+	case operation.Payable:
+		if casted.GetAmount() > source.Spendable(height) {
+			return errors.VestingNotVested
 		}
+	}
+
+	return
+}
 
-		if config.CommonAccountAddress != source.Address {
-			return errors.InvalidOperation
+// isFrozenPayable checks the frozen account can send it's balance.
+func isFrozenPayable(st *storage.LevelDBBackend, source *block.BlockAccount) (err error) {
+	// Unfreezing must be done after X period from unfreezing request
+	iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
+	bo, _, _ := iterFunc() //Get the first operation submitted by the source(frozen) account
+	closeFunc()
+	// Before unfreezing payment, unfreezing request shoud be saved
+	if bo.Type != operation.TypeUnfreezingRequest {
+		return errors.UnfreezingRequestNotRequested
+	}
+	lastblock := block.GetLatestBlock(st)
+	// unfreezing period is 241920.
+	if lastblock.Height-bo.Height < common.UnfreezingPeriod {
+		return errors.UnfreezingNotReachedExpiration
+	}
+	return nil
+}
+
+func validateCreateAccount(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.CreateAccount
+	if casted, ok = op.B.(operation.CreateAccount); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	if exists, err := block.ExistsBlockAccount(st, casted.Target); err == nil && exists {
+		return errors.BlockAccountAlreadyExists
+	}
+
+	if source.IsFrozen() && !source.IsVesting() {
+		if err = isFrozenPayable(st, source); err != nil {
+			return err
 		}
+	}
 
-		var congressVotingHash string
-		{
-			var bo block.BlockOperation
-			var err error
+	return nil
+}
 
-			var opIndex int
-			parsedCongressVotingResultHash := strings.Split(inflationPF.VotingResult, "-") //0:TxHash, 1:Index
-			if len(parsedCongressVotingResultHash) != 2 {
-				return errors.InvalidOperation
-			}
-			txHash := parsedCongressVotingResultHash[0]
-			if opIndex, err = strconv.Atoi(parsedCongressVotingResultHash[1]); err != nil {
-				return errors.InvalidOperation
-			}
+func validateCreateVestingAccount(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.CreateVestingAccount
+	if casted, ok = op.B.(operation.CreateVestingAccount); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
 
-			if bo, err = block.GetBlockOperationWithIndex(st, txHash, opIndex); err != nil {
-				return err
-			}
+	if exists, err := block.ExistsBlockAccount(st, casted.Target); err == nil && exists {
+		return errors.BlockAccountAlreadyExists
+	}
 
-			if bo.Type != operation.TypeCongressVotingResult {
-				return errors.InvalidOperation
-			}
-			var operationBody operation.Body
-			if operationBody, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
-				return err
-			}
+	// the vesting must not be finished at the next block
+	if casted.End <= block.GetLatestBlock(st).Height+1 {
+		return errors.InvalidVesting
+	}
 
-			var o operation.CongressVotingResult
-			var ok bool
-			if o, ok = operationBody.(operation.CongressVotingResult); !ok {
-				return errors.TypeOperationBodyNotMatched
-			}
-			congressVotingHash = o.CongressVotingHash
+	if source.IsFrozen() && !source.IsVesting() {
+		if err = isFrozenPayable(st, source); err != nil {
+			return err
 		}
+	}
 
-		var congressVoting operation.CongressVoting
-		{
-			var bo block.BlockOperation
-			var err error
-			var opIndex int
-			parsedCongressVotingHash := strings.Split(congressVotingHash, "-") //0:TxHash, 1:Index
-			if len(parsedCongressVotingHash) != 2 {
-				return errors.InvalidOperation
-			}
-			txHash := parsedCongressVotingHash[0]
-			if opIndex, err = strconv.Atoi(parsedCongressVotingHash[1]); err != nil {
-				return errors.InvalidOperation
-			}
+	return nil
+}
 
-			if bo, err = block.GetBlockOperationWithIndex(st, txHash, opIndex); err != nil {
-				return err
-			}
+func validatePayment(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.Payment
+	if casted, ok = op.B.(operation.Payment); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	var taccount *block.BlockAccount
+	if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
 
-			if bo.Type != operation.TypeCongressVoting {
-				return errors.InvalidOperation
-			}
-			var operationBody operation.Body
-			if operationBody, err = operation.UnmarshalBodyJSON(bo.Type, bo.Body); err != nil {
-				return err
-			}
+	// If it's a frozen account, it cannot receive payment
+	if taccount.IsFrozen() && !taccount.IsVesting() {
+		return errors.FrozenAccountNoDeposit
+	}
 
-			var o operation.CongressVoting
-			var ok bool
-			if o, ok = operationBody.(operation.CongressVoting); !ok {
-				return errors.TypeOperationBodyNotMatched
-			}
-			congressVoting = o
+	// The source account is frozen account
+	if source.IsFrozen() && !source.IsVesting() {
+		if err = isFrozenPayable(st, source); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+func validateUnfreezeRequest(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if _, ok := op.B.(operation.UnfreezeRequest); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	// Unfreezing should be done from a frozen account
+	if !source.IsFrozen() || source.IsVesting() {
+		return errors.UnfreezingFromInvalidAccount
+	}
+	// Repeated unfreeze request shoud be blocked after unfreeze request saved
+	iterFunc, closeFunc := block.GetBlockOperationsBySource(st, source.Address, nil)
+	bo, _, _ := iterFunc()
+	closeFunc()
+	if bo.Type == operation.TypeUnfreezingRequest {
+		return errors.UnfreezingRequestAlreadyReceived
+	}
+
+	return nil
+}
+
+func validateInflationPF(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var inflationPF operation.InflationPF
+	if inflationPF, ok = op.B.(operation.InflationPF); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	var taccount *block.BlockAccount
+	if taccount, err = block.GetBlockAccount(st, inflationPF.FundingAddress); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	// If it's a frozen account, it cannot receive payment
+	if taccount.IsFrozen() && !taccount.IsVesting() {
+		return errors.FrozenAccountNoDeposit
+	}
+
+	if config.CommonAccountAddress != source.Address {
+		return errors.InvalidOperation
+	}
 
-		if congressVoting.Amount != inflationPF.Amount {
-			return errors.InflationPFAmountMissMatched
+	var cvResult operation.CongressVotingResult
+	{
+		var body operation.Body
+		if body, err = getOperationBodyByIndexHash(st, inflationPF.VotingResult, operation.TypeCongressVotingResult); err != nil {
+			return
 		}
+		cvResult = body.(operation.CongressVotingResult)
+	}
 
-		if congressVoting.FundingAddress != inflationPF.FundingAddress {
-			return errors.InflationPFFundingAddressMissMatched
+	var congressVoting operation.CongressVoting
+	{
+		var body operation.Body
+		if body, err = getOperationBodyByIndexHash(st, cvResult.CongressVotingHash, operation.TypeCongressVoting); err != nil {
+			return
 		}
+		congressVoting = body.(operation.CongressVoting)
+	}
 
-	case operation.TypeCongressVoting:
-		//the CongressAddress is owned by blockchainOS. It is temporally check.
-		//TODO: When a node of BosNet is operated by anonymous then it will be removed.
-		if source.Address != config.CongressAccountAddress {
-			return errors.CongressAddressMisMatched
+	// the voting must be passed by the on-chain tally; the result of voting
+	// without tally was accepted before the votes are cast on chain.
+	var exists bool
+	if exists, err = block.ExistsBlockCongressVotingTally(st, cvResult.CongressVotingHash); err != nil {
+		return
+	} else if exists {
+		var tally *block.BlockCongressVotingTally
+		if tally, err = block.GetBlockCongressVotingTally(st, cvResult.CongressVotingHash); err != nil {
+			return
 		}
-	case operation.TypeCongressVotingResult:
-		//the CongressAddress is owned by blockchainOS. It is temporally check.
-		//TODO: When a node of BosNet is operated by anonymous then it will be removed.
-		if source.Address != config.CongressAccountAddress {
-			return errors.CongressAddressMisMatched
+		if !tally.Closed || !isCongressVotingResultMatched(cvResult, tally) {
+			return errors.CongressVotingResultMissMatched
 		}
+		if !tally.Passed() {
+			return errors.CongressVotingNotPassed
+		}
+	}
+
+	if congressVoting.Amount != inflationPF.Amount {
+		return errors.InflationPFAmountMissMatched
+	}
+
+	if congressVoting.FundingAddress != inflationPF.FundingAddress {
+		return errors.InflationPFFundingAddressMissMatched
+	}
+
+	return nil
+}
 
-		var ok bool
-		var cvResult operation.CongressVotingResult
-		if cvResult, ok = op.B.(operation.CongressVotingResult); !ok {
-			return errors.TypeOperationBodyNotMatched
+func validateManageSigners(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if _, ok := op.B.(operation.ManageSigners); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	// The signers of frozen account can not be changed
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+
+	return nil
+}
+
+func validateSetOptions(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if _, ok := op.B.(operation.SetOptions); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	// The options of frozen account can not be changed
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+
+	return nil
+}
+
+func validateManageData(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.ManageData
+	if casted, ok = op.B.(operation.ManageData); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+	// The frozen account can not have data entries
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+
+	if casted.IsDelete() {
+		var exists bool
+		if exists, err = block.ExistsBlockAccountData(st, source.Address, casted.Key); err != nil {
+			return
+		} else if !exists {
+			return errors.DataEntryDoesNotExists
 		}
+	}
+
+	return nil
+}
+
+func validateHashLockCreate(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.HashLockCreate
+	if casted, ok = op.B.(operation.HashLockCreate); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+	if source.Address == casted.Target {
+		return errors.InvalidOperation
+	}
+
+	var taccount *block.BlockAccount
+	if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	if taccount.IsFrozen() && !taccount.IsVesting() {
+		return errors.FrozenAccountNoDeposit
+	}
+
+	_, err = checkHashLockOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
 
-		var opIndex int
-		parsedCongressVotingResultHash := strings.Split(cvResult.CongressVotingHash, "-") //0:TxHash, 1:Index
-		if len(parsedCongressVotingResultHash) != 2 {
-			return errors.InvalidOperation
+	return
+}
+
+func validateHashLock(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	_, err = checkHashLockOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
+	return
+}
+
+// checkHashLockOperation checks the hash lock operation of source can be
+// included in the block of height and returns the storage key of the lock.
+//  * `operation.HashLockCreate`: the lock must not exist and `Expiry` must be
+//    after the block.
+//  * `operation.HashLockClaim`: the lock for source must be locked and not
+//    expired.
+//  * `operation.HashLockRefund`: the lock by source must be locked and
+//    expired.
+func checkHashLockOperation(st *storage.LevelDBBackend, source string, op operation.Operation, height uint64) (key string, err error) {
+	var lock *block.BlockHashLock
+	switch opb := op.B.(type) {
+	case operation.HashLockCreate:
+		key = block.GetBlockHashLockKey(source, opb.Target, opb.HashLock)
+		var exists bool
+		if exists, err = st.Has(key); err != nil {
+			return
+		} else if exists {
+			err = errors.HashLockAlreadyExists
+			return
+		}
+		if opb.Expiry <= height {
+			err = errors.InvalidHashLock
+		}
+		return
+	case operation.HashLockClaim:
+		key = block.GetBlockHashLockKey(opb.Locker, source, opb.HashLock())
+		if lock, err = block.GetBlockHashLock(st, opb.Locker, source, opb.HashLock()); err != nil {
+			err = errors.HashLockDoesNotExists
+			return
+		}
+		if !lock.IsLocked() {
+			err = errors.HashLockNotLocked
+		} else if lock.IsExpired(height) {
+			err = errors.HashLockExpired
+		}
+		return
+	case operation.HashLockRefund:
+		key = block.GetBlockHashLockKey(source, opb.Target, opb.HashLock)
+		if lock, err = block.GetBlockHashLock(st, source, opb.Target, opb.HashLock); err != nil {
+			err = errors.HashLockDoesNotExists
+			return
 		}
-		txHash := parsedCongressVotingResultHash[0]
-		if opIndex, err = strconv.Atoi(parsedCongressVotingResultHash[1]); err != nil {
-			return errors.InvalidOperation
+		if !lock.IsLocked() {
+			err = errors.HashLockNotLocked
+		} else if !lock.IsExpired(height) {
+			err = errors.HashLockNotExpired
 		}
+		return
+	default:
+		err = errors.TypeOperationBodyNotMatched
+		return
+	}
+}
 
-		if _, err = block.GetBlockOperationWithIndex(st, txHash, opIndex); err != nil {
-			return err
+func validateScheduledPaymentCreate(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.ScheduledPaymentCreate
+	if casted, ok = op.B.(operation.ScheduledPaymentCreate); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+	if source.Address == casted.Target {
+		return errors.InvalidOperation
+	}
+
+	var taccount *block.BlockAccount
+	if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	if taccount.IsFrozen() && !taccount.IsVesting() {
+		return errors.FrozenAccountNoDeposit
+	}
+
+	_, err = checkScheduledPaymentOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
+
+	return
+}
+
+func validateScheduledPaymentCancel(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	_, err = checkScheduledPaymentOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
+	return
+}
+
+// checkScheduledPaymentOperation checks the scheduled payment operation of
+// source can be included in the block of given height and returns the key of
+// the scheduled payment.
+//  * `operation.ScheduledPaymentCreate`: the scheduled payment must not exist
+//  and `Start` must be higher than the height.
+//  * `operation.ScheduledPaymentCancel`: the scheduled payment must be active
+//  and must not be due at the height; the due payment is executed by the
+//  proposer transaction before the transactions of block.
+func checkScheduledPaymentOperation(st *storage.LevelDBBackend, source string, op operation.Operation, height uint64) (key string, err error) {
+	switch opb := op.B.(type) {
+	case operation.ScheduledPaymentCreate:
+		key = block.GetBlockScheduledPaymentKey(source, opb.ID)
+
+		var exists bool
+		if exists, err = block.ExistsBlockScheduledPayment(st, source, opb.ID); err != nil {
+			return
+		} else if exists {
+			err = errors.ScheduledPaymentAlreadyExists
+			return
 		}
+		if opb.Start <= height {
+			err = errors.InvalidScheduledPayment
+		}
+		return
+	case operation.ScheduledPaymentCancel:
+		key = block.GetBlockScheduledPaymentKey(source, opb.ID)
 
+		var payment *block.BlockScheduledPayment
+		if payment, err = block.GetBlockScheduledPayment(st, source, opb.ID); err != nil {
+			err = errors.ScheduledPaymentDoesNotExists
+			return
+		}
+		if !payment.IsActive() {
+			err = errors.ScheduledPaymentNotActive
+		} else if payment.Next <= height {
+			err = errors.InvalidScheduledPayment
+		}
+		return
 	default:
-		return errors.UnknownOperationType
+		err = errors.TypeOperationBodyNotMatched
+		return
+	}
+}
+
+func validateAccountMerge(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.AccountMerge
+	if casted, ok = op.B.(operation.AccountMerge); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	// The accounts of network can not be merged
+	if source.Address == config.CommonAccountAddress || source.Address == config.CongressAccountAddress {
+		return errors.InvalidOperation
+	}
+	if source.Address == casted.Target {
+		return errors.InvalidOperation
+	}
+
+	var taccount *block.BlockAccount
+	if taccount, err = block.GetBlockAccount(st, casted.Target); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+	// If it's a frozen account, it cannot receive payment
+	if taccount.IsFrozen() && !taccount.IsVesting() {
+		return errors.FrozenAccountNoDeposit
+	}
+
+	// The frozen account returns it's balance only to the linked account
+	// after unfreezing
+	if source.IsFrozen() && !source.IsVesting() {
+		if casted.Target != source.Linked {
+			return errors.AccountMergeNotToLinked
+		}
+		if err = isFrozenPayable(st, source); err != nil {
+			return err
+		}
+	}
+
+	// The frozen accounts linked to the source must be unfrozen first
+	if hasFrozenAccounts(st, source.Address) {
+		return errors.AccountMergeHasFrozenAccounts
+	}
+
+	// The locked amount can not be refunded after merge
+	if hasLockedHashLocks(st, source.Address) {
+		return errors.AccountMergeHasHashLocks
 	}
+
+	// The reserved amount can not be refunded after merge
+	if hasActiveScheduledPayments(st, source.Address) {
+		return errors.AccountMergeHasScheduledPayments
+	}
+
+	// The assets can not be held or redeemed after merge
+	if source.Trustlines > 0 || hasIssuedAssets(st, source.Address) {
+		return errors.AccountMergeHasAssets
+	}
+
 	return nil
 }
+
+// hasFrozenAccounts checks there are the frozen accounts, which are linked
+// to the account.
+func hasFrozenAccounts(st *storage.LevelDBBackend, address string) bool {
+	iterFunc, closeFunc := block.GetBlockOperationsByLinked(st, address, nil)
+	defer closeFunc()
+
+	for {
+		bo, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+
+		frozen, err := block.GetBlockAccount(st, bo.Target)
+		if err != nil {
+			// already merged
+			continue
+		}
+		if frozen.IsFrozen() && !frozen.IsVesting() && frozen.Linked == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasLockedHashLocks checks there are the hash locks created by the account,
+// which are not claimed or refunded yet.
+func hasLockedHashLocks(st *storage.LevelDBBackend, address string) bool {
+	iterFunc, closeFunc := block.GetBlockHashLocksBySource(st, address, nil)
+	defer closeFunc()
+
+	for {
+		lock, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		if lock.IsLocked() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasActiveScheduledPayments checks there are the scheduled payments created
+// by the account, which are not finished or cancelled yet.
+func hasActiveScheduledPayments(st *storage.LevelDBBackend, address string) bool {
+	iterFunc, closeFunc := block.GetBlockScheduledPaymentsBySource(st, address, nil)
+	defer closeFunc()
+
+	for {
+		payment, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		if payment.IsActive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasIssuedAssets checks there are the assets issued by the account, which
+// are not redeemed yet.
+func hasIssuedAssets(st *storage.LevelDBBackend, address string) bool {
+	iterFunc, closeFunc := block.GetBlockAssetsByIssuer(st, address, nil)
+	defer closeFunc()
+
+	for {
+		asset, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		if asset.Issued > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateTrust(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.Trust
+	if casted, ok = op.B.(operation.Trust); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	// The frozen account can not hold assets
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+	// The issuer does not need the trustline for it's own asset
+	if source.Address == casted.Asset.Issuer {
+		return errors.InvalidOperation
+	}
+
+	if !casted.IsRemove() {
+		var exists bool
+		if exists, err = block.ExistsBlockAccount(st, casted.Asset.Issuer); err != nil {
+			return
+		} else if !exists {
+			return errors.BlockAccountDoesNotExists
+		}
+	}
+
+	return newAssetState(st, nil).apply(source.Address, op)
+}
+
+func validateIssueAsset(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if _, ok := op.B.(operation.IssueAsset); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	// The frozen account can not issue assets
+	if source.IsFrozen() && !source.IsVesting() {
+		return errors.InvalidOperation
+	}
+
+	return newAssetState(st, nil).apply(source.Address, op)
+}
+
+func validateAssetPayment(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	var ok bool
+	var casted operation.AssetPayment
+	if casted, ok = op.B.(operation.AssetPayment); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	if source.Address == casted.Target {
+		return errors.InvalidOperation
+	}
+
+	var exists bool
+	if exists, err = block.ExistsBlockAccount(st, casted.Target); err != nil {
+		return
+	} else if !exists {
+		return errors.BlockAccountDoesNotExists
+	}
+
+	return newAssetState(st, nil).apply(source.Address, op)
+}
+
+func validateCongressVoting(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	//the CongressAddress is owned by blockchainOS. It is temporally check.
+	//TODO: When a node of BosNet is operated by anonymous then it will be removed.
+	if source.Address != config.CongressAccountAddress {
+		return errors.CongressAddressMisMatched
+	}
+
+	return nil
+}
+
+func validateCongressVotingResult(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	//the CongressAddress is owned by blockchainOS. It is temporally check.
+	//TODO: When a node of BosNet is operated by anonymous then it will be removed.
+	if source.Address != config.CongressAccountAddress {
+		return errors.CongressAddressMisMatched
+	}
+
+	_, err = checkCongressOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
+
+	return
+}
+
+func validateCongressMembership(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if source.Address != config.CongressAccountAddress {
+		return errors.CongressAddressMisMatched
+	}
+
+	var ok bool
+	var casted operation.CongressMembership
+	if casted, ok = op.B.(operation.CongressMembership); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	var exists bool
+	if exists, err = block.ExistsBlockCongressMember(st, casted.Target); err != nil {
+		return
+	}
+
+	if casted.Remove {
+		if !exists {
+			return errors.CongressMemberDoesNotExists
+		}
+		return
+	}
+
+	if exists {
+		return errors.CongressMemberAlreadyExists
+	}
+	if exists, err = block.ExistsBlockAccount(st, casted.Target); err != nil {
+		return
+	} else if !exists {
+		return errors.BlockAccountDoesNotExists
+	}
+
+	return
+}
+
+// validateManageValidator checks the change of validators against the
+// validator set of the activation height; the change must be activated after
+// the block, which includes it.
+func validateManageValidator(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	if source.Address != config.CongressAccountAddress {
+		return errors.CongressAddressMisMatched
+	}
+
+	var ok bool
+	var casted operation.ManageValidator
+	if casted, ok = op.B.(operation.ManageValidator); !ok {
+		return errors.TypeOperationBodyNotMatched
+	}
+
+	if casted.ActivationHeight <= block.GetLatestBlock(st).Height+1 {
+		return errors.InvalidActivationHeight
+	}
+
+	var vs *block.BlockValidatorSet
+	if vs, err = block.GetBlockValidatorSet(st, casted.ActivationHeight); err != nil {
+		return
+	}
+
+	if !casted.Remove {
+		if vs.Has(casted.Target) {
+			return errors.ValidatorAlreadyExists
+		}
+		return
+	}
+
+	if !vs.Has(casted.Target) {
+		return errors.ValidatorDoesNotExists
+	}
+	if len(vs.Validators) < 2 {
+		return errors.ValidatorSetEmpty
+	}
+
+	// the validator sets after the activation height also must not be empty
+	var heights []uint64
+	if heights, err = block.GetBlockValidatorSetHeights(st); err != nil {
+		return
+	}
+	for _, height := range heights {
+		if height <= casted.ActivationHeight {
+			continue
+		}
+		if vs, err = block.GetBlockValidatorSet(st, height); err != nil {
+			return
+		}
+		if len(vs.Validators) < 2 && vs.Has(casted.Target) {
+			return errors.ValidatorSetEmpty
+		}
+	}
+
+	return
+}
+
+func validateCongressVote(st *storage.LevelDBBackend, config common.Config, source *block.BlockAccount, op operation.Operation) (err error) {
+	_, err = checkCongressOperation(st, source.Address, op, block.GetLatestBlock(st).Height+1)
+	return
+}
+
+// checkCongressOperation checks `operation.CongressVote` and
+// `operation.CongressVotingResult` against the voting and it's tally at the
+// block of height. The returned key is unique for the vote of member and the
+// result of voting, so they can be checked in the ballot. The voting without
+// tally was created before the votes are cast on chain; it can not be voted
+// and it's result is accepted without the tally like before.
+func checkCongressOperation(st *storage.LevelDBBackend, source string, op operation.Operation, height uint64) (key string, err error) {
+	var votingHash string
+	switch opb := op.B.(type) {
+	case operation.CongressVote:
+		votingHash = opb.CongressVotingHash
+		key = block.GetBlockCongressVoteKey(votingHash, source)
+	case operation.CongressVotingResult:
+		votingHash = opb.CongressVotingHash
+		key = block.GetBlockCongressVotingTallyKey(votingHash)
+	default:
+		err = errors.TypeOperationBodyNotMatched
+		return
+	}
+
+	var body operation.Body
+	if body, err = getOperationBodyByIndexHash(st, votingHash, operation.TypeCongressVoting); err != nil {
+		return
+	}
+	voting := body.(operation.CongressVoting)
+
+	var exists bool
+	if exists, err = block.ExistsBlockCongressVotingTally(st, votingHash); err != nil {
+		return
+	} else if !exists {
+		if op.H.Type == operation.TypeCongressVote {
+			err = errors.CongressVotingNotTallied
+		}
+		return
+	}
+
+	var tally *block.BlockCongressVotingTally
+	if tally, err = block.GetBlockCongressVotingTally(st, votingHash); err != nil {
+		return
+	}
+
+	switch opb := op.B.(type) {
+	case operation.CongressVote:
+		var exists bool
+		if exists, err = block.ExistsBlockCongressMember(st, source); err != nil {
+			return
+		} else if !exists {
+			err = errors.CongressMemberDoesNotExists
+			return
+		}
+		if height < voting.Voting.Start {
+			err = errors.CongressVotingNotOpened
+			return
+		}
+		if height > voting.Voting.End || tally.Closed {
+			err = errors.CongressVotingClosed
+			return
+		}
+		if exists, err = block.ExistsBlockCongressVote(st, votingHash, source); err != nil {
+			return
+		} else if exists {
+			err = errors.CongressVoteAlreadyCast
+			return
+		}
+	case operation.CongressVotingResult:
+		if height <= voting.Voting.End {
+			err = errors.CongressVotingNotClosed
+			return
+		}
+		if tally.Closed {
+			err = errors.CongressVotingResultAlreadyExists
+			return
+		}
+		if !isCongressVotingResultMatched(opb, tally) {
+			err = errors.CongressVotingResultMissMatched
+			return
+		}
+	}
+
+	return
+}
+
+func isCongressVotingResultMatched(result operation.CongressVotingResult, tally *block.BlockCongressVotingTally) bool {
+	return result.Result.Count == tally.Count() &&
+		result.Result.Yes == tally.Yes &&
+		result.Result.No == tally.No &&
+		result.Result.ABS == tally.ABS
+}
+
+// getOperationBodyByIndexHash returns the body of operation from the
+// `<tx hash>-<operation index>` form of hash. The operation must be the
+// given type.
+func getOperationBodyByIndexHash(st *storage.LevelDBBackend, hash string, ty operation.OperationType) (body operation.Body, err error) {
+	var opIndex int
+	parsed := strings.Split(hash, "-") //0:TxHash, 1:Index
+	if len(parsed) != 2 {
+		return nil, errors.InvalidOperation
+	}
+	if opIndex, err = strconv.Atoi(parsed[1]); err != nil {
+		return nil, errors.InvalidOperation
+	}
+
+	var bo block.BlockOperation
+	if bo, err = block.GetBlockOperationWithIndex(st, parsed[0], opIndex); err != nil {
+		return
+	}
+	if bo.Type != ty {
+		return nil, errors.InvalidOperation
+	}
+
+	return operation.UnmarshalBodyJSON(bo.Type, bo.Body)
+}
@@ -12,8 +12,12 @@
 package runner
 
 import (
+	"encoding/hex"
+	"fmt"
 	"testing"
+	"time"
 
+	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
@@ -315,3 +319,1753 @@ func TestOpsInBalotLimit(t *testing.T) {
 		require.Equal(t, errors.BallotHasOverMaxOperationsInBallot, err)
 	}
 }
+
+// Check the signatures of transaction reach the thresholds of source account
+func TestValidateTxMultipleSigners(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	kpSigner0 := keypair.Random()
+	kpSigner1 := keypair.Random()
+
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	bas := block.BlockAccount{
+		Address: kps.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.SetSigners(
+		[]common.Signer{
+			{Address: kps.Address(), Weight: 1},
+			{Address: kpSigner0.Address(), Weight: 1},
+			{Address: kpSigner1.Address(), Weight: 2},
+		},
+		common.Thresholds{Low: 1, Medium: 2, High: 3},
+	)
+	bat := block.BlockAccount{
+		Address: kpt.Address(),
+		Balance: common.Amount(1 * common.AmountPerCoin),
+	}
+	bas.MustSave(st)
+	bat.MustSave(st)
+
+	payment := func() transaction.Transaction {
+		tx, _ := transaction.NewTransaction(
+			kps.Address(),
+			0,
+			operation.NewFeeSchedule(),
+			operation.Operation{
+				H: operation.Header{Type: operation.TypePayment},
+				B: operation.Payment{Target: kpt.Address(), Amount: common.Amount(10000)},
+			},
+		)
+		tx.H.Version = common.TransactionVersionV2
+		return tx
+	}
+
+	{ // signed only by source; medium threshold is not reached
+		tx := payment()
+		tx.Sign(kps, conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.Equal(t, errors.TransactionInsufficientSignatures, ValidateTx(st, conf, tx))
+
+		tx.AddSignature(kpSigner0, conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+
+	{ // signed without source
+		tx := payment()
+		tx.AddSignature(kpSigner1, conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+
+	{ // signed by unknown signer
+		tx := payment()
+		tx.Sign(kps, conf.NetworkID)
+		tx.AddSignature(kpSigner1, conf.NetworkID)
+		tx.AddSignature(keypair.Random(), conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.Equal(t, errors.TransactionUnknownSigner, ValidateTx(st, conf, tx))
+	}
+
+	{ // managing signers needs high threshold
+		opb := operation.NewManageSigners(nil, common.Thresholds{})
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kps.Address(), 0, operation.NewFeeSchedule(), op)
+		tx.H.Version = common.TransactionVersionV2
+		tx.Sign(kps, conf.NetworkID)
+		tx.AddSignature(kpSigner0, conf.NetworkID)
+		require.NoError(t, tx.IsWellFormed(conf))
+		require.Equal(t, errors.TransactionInsufficientSignatures, ValidateTx(st, conf, tx))
+
+		tx.AddSignature(kpSigner1, conf.NetworkID)
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+
+	{ // without signers, the other signer can not sign
+		tx, _ := transaction.NewTransaction(
+			kpt.Address(),
+			0,
+			operation.NewFeeSchedule(),
+			operation.Operation{
+				H: operation.Header{Type: operation.TypePayment},
+				B: operation.Payment{Target: kps.Address(), Amount: common.Amount(10000)},
+			},
+		)
+		tx.H.Version = common.TransactionVersionV2
+		tx.Sign(kpt, conf.NetworkID)
+		require.NoError(t, ValidateTx(st, conf, tx))
+
+		tx.AddSignature(kpSigner0, conf.NetworkID)
+		require.Equal(t, errors.TransactionUnknownSigner, ValidateTx(st, conf, tx))
+	}
+}
+
+func TestFinishManageSigners(t *testing.T) {
+	kps := keypair.Random()
+	kpSigner := keypair.Random()
+
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+
+	signers := []common.Signer{
+		{Address: kps.Address(), Weight: 1},
+		{Address: kpSigner.Address(), Weight: 1},
+	}
+	thresholds := common.Thresholds{Low: 1, Medium: 1, High: 2}
+	opb := operation.NewManageSigners(signers, thresholds)
+	op, _ := operation.NewOperation(opb)
+	require.NoError(t, finishOperation(st, kps.Address(), op, log))
+
+	ba, err := block.GetBlockAccount(st, kps.Address())
+	require.NoError(t, err)
+	require.Equal(t, signers, ba.GetSigners())
+	require.Equal(t, thresholds, ba.Thresholds)
+
+	// reset signers
+	op, _ = operation.NewOperation(operation.NewManageSigners(nil, common.Thresholds{}))
+	require.NoError(t, finishOperation(st, kps.Address(), op, log))
+
+	ba, err = block.GetBlockAccount(st, kps.Address())
+	require.NoError(t, err)
+	require.False(t, ba.HasSigners())
+	require.Equal(t, []common.Signer{{Address: kps.Address(), Weight: 1}}, ba.GetSigners())
+}
+
+// The time bounds are checked with the height of next block and the proposed
+// time of the latest block.
+func TestValidateTxTimeBounds(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	latest := block.GetLatestBlock(st)
+	latestTime, _ := common.ParseISO8601(latest.ProposedTime)
+
+	makeTx := func(tb transaction.TimeBounds) transaction.Transaction {
+		tx, _ := GetTransaction()
+		tx.H.Version = common.TransactionVersionV2
+		tx.B.TimeBounds = &tb
+		tx.Sign(block.GenesisKP, networkID)
+		return tx
+	}
+
+	// `ValidateTx` rejects only the expired transaction; the transaction,
+	// which is not yet valid, can wait in the pool.
+	cases := []struct {
+		tb        transaction.TimeBounds
+		validated error
+		expected  error
+	}{
+		{transaction.TimeBounds{MinHeight: latest.Height + 1, MaxHeight: latest.Height + 1}, nil, nil},
+		{transaction.TimeBounds{MaxHeight: latest.Height}, errors.TransactionExpired, errors.TransactionExpired},
+		{transaction.TimeBounds{MinHeight: latest.Height + 2}, nil, errors.TransactionNotYetValid},
+		{transaction.TimeBounds{MinTime: latest.ProposedTime}, nil, nil},
+		{transaction.TimeBounds{MaxTime: common.FormatISO8601(latestTime.Add(-time.Second))}, errors.TransactionExpired, errors.TransactionExpired},
+		{transaction.TimeBounds{MinTime: common.FormatISO8601(latestTime.Add(time.Second))}, nil, errors.TransactionNotYetValid},
+	}
+
+	var hashes []string
+	var valids []string
+	for _, c := range cases {
+		tx := makeTx(c.tb)
+		require.Equal(t, c.validated, ValidateTx(st, nr.Conf, tx), "time bounds: %v", c.tb)
+
+		// the transactions have same sequence id, so they can not be in
+		// `Pool` together.
+		block.SaveTransactionPool(st, tx)
+		hashes = append(hashes, tx.GetHash())
+		if c.expected == nil {
+			valids = append(valids, tx.GetHash())
+		}
+	}
+
+	newChecker := func(checkTransactionsOnly bool) *BallotTransactionChecker {
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: []common.CheckerFunc{BallotTransactionsTimeBounds}},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			Transactions:          hashes,
+			ValidTransactions:     hashes,
+			CheckTransactionsOnly: checkTransactionsOnly,
+			transactionCache:      NewTransactionCache(st, nr.TransactionPool),
+		}
+	}
+
+	{ // INIT ballot which has the transactions out of time bounds
+		checker := newChecker(false)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.TransactionExpired, err)
+	}
+
+	{ // only the valid transactions are left
+		checker := newChecker(true)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.NoError(t, err)
+		require.Equal(t, valids, checker.ValidTransactions)
+	}
+}
+
+func TestValidateOpAccountMerge(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+
+	newOp := func(target string) operation.Operation {
+		op, _ := operation.NewOperation(operation.NewAccountMerge(target))
+		return op
+	}
+
+	// target does not exist
+	require.Equal(t, errors.BlockAccountDoesNotExists, ValidateOp(st, conf, bas, newOp(kpt.Address()), transaction.Transaction{}))
+
+	bat := block.NewBlockAccount(kpt.Address(), common.Amount(1*common.AmountPerCoin))
+	bat.MustSave(st)
+	require.NoError(t, ValidateOp(st, conf, bas, newOp(kpt.Address()), transaction.Transaction{}))
+
+	// merge into itself
+	require.Equal(t, errors.InvalidOperation, ValidateOp(st, conf, bas, newOp(kps.Address()), transaction.Transaction{}))
+
+	{ // frozen account can not receive the balance
+		kpFrozen := keypair.Random()
+		frozen := block.NewBlockAccountLinked(kpFrozen.Address(), common.BaseReserve, kps.Address())
+		frozen.MustSave(st)
+		require.Equal(t, errors.FrozenAccountNoDeposit, ValidateOp(st, conf, bas, newOp(kpFrozen.Address()), transaction.Transaction{}))
+	}
+
+	{ // the frozen account linked to the source is not unfrozen yet
+		kpFrozen := keypair.Random()
+		opCreate, _ := operation.NewOperation(operation.NewCreateAccount(kpFrozen.Address(), common.BaseReserve, kps.Address()))
+		tx, _ := transaction.NewTransaction(kps.Address(), 0, operation.NewFeeSchedule(), opCreate)
+		bo, err := block.NewBlockOperationFromOperation(opCreate, tx, 1, 0)
+		require.NoError(t, err)
+		require.NoError(t, bo.Save(st))
+
+		frozen := block.NewBlockAccountLinked(kpFrozen.Address(), common.BaseReserve, kps.Address())
+		frozen.MustSave(st)
+		require.Equal(t, errors.AccountMergeHasFrozenAccounts, ValidateOp(st, conf, bas, newOp(kpt.Address()), transaction.Transaction{}))
+
+		// the frozen account is merged to the linked account
+		require.Equal(t, errors.AccountMergeNotToLinked, ValidateOp(st, conf, frozen, newOp(kpt.Address()), transaction.Transaction{}))
+
+		require.NoError(t, frozen.Remove(st))
+		require.NoError(t, ValidateOp(st, conf, bas, newOp(kpt.Address()), transaction.Transaction{}))
+	}
+}
+
+func TestFinishAccountMerge(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+
+	bas := block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin))
+	bas.MustSave(st)
+	bat := block.NewBlockAccount(kpt.Address(), common.Amount(1*common.AmountPerCoin))
+	bat.MustSave(st)
+
+	op, _ := operation.NewOperation(operation.NewAccountMerge(kpt.Address()))
+	require.NoError(t, finishOperation(st, kps.Address(), op, log))
+
+	exists, err := block.ExistsBlockAccount(st, kps.Address())
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	ba, err := block.GetBlockAccount(st, kpt.Address())
+	require.NoError(t, err)
+	require.Equal(t, common.Amount(2*common.AmountPerCoin), ba.Balance)
+
+	// the history of the merged account is kept
+	_, err = block.GetBlockAccountSequenceID(st, kps.Address(), bas.SequenceID)
+	require.NoError(t, err)
+}
+
+// The transactions, which target the merged account in the same ballot are
+// not allowed.
+func TestBallotTransactionsAccountMerge(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpt := keypair.Random()
+	opMerge, _ := operation.NewOperation(operation.NewAccountMerge(kpt.Address()))
+	txMerge, _ := transaction.NewTransaction(block.GenesisKP.Address(), 0, operation.NewFeeSchedule(), opMerge)
+	txMerge.Sign(block.GenesisKP, networkID)
+
+	kpSource := keypair.Random()
+	opPayment, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1)))
+	txPayment, _ := transaction.NewTransaction(kpSource.Address(), 0, operation.NewFeeSchedule(), opPayment)
+	txPayment.Sign(kpSource, networkID)
+
+	nr.TransactionPool.Add(txMerge)
+	nr.TransactionPool.Add(txPayment)
+
+	hashes := []string{txMerge.GetHash(), txPayment.GetHash()}
+	newChecker := func(checkTransactionsOnly bool) *BallotTransactionChecker {
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: []common.CheckerFunc{BallotTransactionsAccountMerge}},
+			NodeRunner:            nr,
+			LocalNode:             nr.Node(),
+			NetworkID:             networkID,
+			Transactions:          hashes,
+			ValidTransactions:     hashes,
+			CheckTransactionsOnly: checkTransactionsOnly,
+			transactionCache:      NewTransactionCache(st, nr.TransactionPool),
+		}
+	}
+
+	{
+		checker := newChecker(false)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.AccountMergedInBallot, err)
+	}
+
+	{
+		checker := newChecker(true)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.NoError(t, err)
+		require.Equal(t, []string{txMerge.GetHash()}, checker.ValidTransactions)
+	}
+}
+
+func TestValidateTxManageData(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+	config := common.NewTestConfig()
+
+	kp := keypair.Random()
+	ba := block.NewBlockAccount(kp.Address(), common.BaseReserve.MustMult(2))
+	ba.MustSave(st)
+
+	makeTx := func(ops ...operation.Operation) transaction.Transaction {
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), ops...)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	newOp := func(key, value string) operation.Operation {
+		op, _ := operation.NewOperation(operation.NewManageData(key, value))
+		return op
+	}
+
+	// delete unknown entry
+	require.Equal(t, errors.DataEntryDoesNotExists, ValidateTx(st, config, makeTx(newOp("a", ""))))
+
+	// the balance keeps the reserve for one entry, but not with the fee
+	require.Equal(t, errors.DataEntryNotEnoughReserve, ValidateTx(st, config, makeTx(newOp("a", "1"))))
+
+	ba.Balance = ba.Balance.MustAdd(common.BaseFee)
+	ba.MustSave(st)
+	require.NoError(t, ValidateTx(st, config, makeTx(newOp("a", "1"))))
+	require.Equal(t, errors.DataEntryNotEnoughReserve, ValidateTx(st, config, makeTx(newOp("a", "1"), newOp("b", "2"))))
+
+	// set and finish
+	op := newOp("a", "1")
+	require.NoError(t, finishOperation(st, kp.Address(), op, log))
+	ba, _ = block.GetBlockAccount(st, kp.Address())
+	require.Equal(t, uint64(1), ba.DataEntries)
+
+	// update does not need more reserve
+	require.NoError(t, ValidateTx(st, config, makeTx(newOp("a", "2"))))
+
+	// the payment can not take the reserve
+	opPayment, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1)))
+	require.Equal(t, errors.DataEntryNotEnoughReserve, ValidateTx(st, config, makeTx(opPayment)))
+
+	// with deleting the entry, the reserve is released
+	require.NoError(t, ValidateTx(st, config, makeTx(newOp("a", ""), opPayment)))
+
+	require.NoError(t, finishOperation(st, kp.Address(), newOp("a", ""), log))
+	ba, _ = block.GetBlockAccount(st, kp.Address())
+	require.Equal(t, uint64(0), ba.DataEntries)
+	exists, err := block.ExistsBlockAccountData(st, kp.Address(), "a")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+// The transactions of same source can be in one ballot with the consecutive
+// sequence ids, if the source can pay for all of them.
+func TestBallotTransactionsSameSource(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kp := keypair.Random()
+	amount := common.Amount(1 * common.AmountPerCoin)
+	ba := block.NewBlockAccount(kp.Address(), amount.MustMult(2).MustAdd(common.BaseFee.MustMult(2)))
+	ba.MustSave(st)
+
+	makeTx := func(sequenceID uint64) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), amount))
+		tx, _ := transaction.NewTransaction(kp.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		nr.TransactionPool.Add(tx)
+		return tx
+	}
+	tx0, tx1, tx2 := makeTx(0), makeTx(1), makeTx(2)
+
+	newChecker := func(checkTransactionsOnly bool, txs ...transaction.Transaction) *BallotTransactionChecker {
+		var hashes []string
+		for _, tx := range txs {
+			hashes = append(hashes, tx.GetHash())
+		}
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: []common.CheckerFunc{BallotTransactionsSameSource}},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			Transactions:          hashes,
+			ValidTransactions:     hashes,
+			CheckTransactionsOnly: checkTransactionsOnly,
+			transactionCache:      NewTransactionCache(st, nr.TransactionPool),
+		}
+	}
+
+	{ // consecutive sequence ids
+		checker := newChecker(false, tx0, tx1)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{tx0.GetHash(), tx1.GetHash()}, checker.ValidTransactions)
+	}
+
+	{ // sequence ids are not consecutive
+		checker := newChecker(false, tx0, tx2)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.TransactionInvalidSequenceID, err)
+
+		checker = newChecker(false, tx1, tx0)
+		err = common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.TransactionInvalidSequenceID, err)
+	}
+
+	{ // the balance can not pay for the third transaction
+		checker := newChecker(false, tx0, tx1, tx2)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.TransactionExcessAbilityToPay, err)
+
+		checker = newChecker(true, tx0, tx1, tx2)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, []string{tx0.GetHash(), tx1.GetHash()}, checker.ValidTransactions)
+	}
+}
+
+func TestRunningAccountsValidate(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kp := keypair.Random()
+	amount := common.Amount(1 * common.AmountPerCoin)
+	ba := block.NewBlockAccount(kp.Address(), amount.MustAdd(common.BaseFee))
+	ba.MustSave(st)
+
+	makeTx := func(sequenceID uint64, opb operation.Body) transaction.Transaction {
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	accounts := NewRunningAccounts(st, nr.Conf, nr.NetworkParameters())
+	require.NoError(t, accounts.Validate(makeTx(0, operation.NewPayment(block.GenesisKP.Address(), amount))))
+
+	// the balance is spent by the previous transaction
+	tx := makeTx(1, operation.NewPayment(block.GenesisKP.Address(), common.Amount(1)))
+	require.Equal(t, errors.TransactionExcessAbilityToPay, accounts.Validate(tx))
+
+	// the stored account is not changed
+	require.NoError(t, ValidateTx(st, nr.Conf, makeTx(0, operation.NewPayment(block.GenesisKP.Address(), common.Amount(1)))))
+
+	{ // after the account merge, the source can not be used
+		accounts := NewRunningAccounts(st, nr.Conf, nr.NetworkParameters())
+		require.NoError(t, accounts.Validate(makeTx(0, operation.NewAccountMerge(block.GenesisKP.Address()))))
+		require.Equal(t, errors.AccountMergedInBallot, accounts.Validate(makeTx(1, operation.NewManageData("a", "b"))))
+	}
+
+	{ // the reserve of data entries is counted with the previous transactions
+		kp = keypair.Random()
+		block.NewBlockAccount(kp.Address(), common.BaseReserve*2+common.BaseFee*2).MustSave(st)
+
+		accounts := NewRunningAccounts(st, nr.Conf, nr.NetworkParameters())
+		require.NoError(t, accounts.Validate(makeTx(0, operation.NewManageData("a", "b"))))
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(0, operation.NewManageData("c", "d"))))
+		require.Equal(t, errors.DataEntryNotEnoughReserve, accounts.Validate(makeTx(1, operation.NewManageData("c", "d"))))
+	}
+}
+
+func TestFeePayer(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpp := keypair.Random()
+	kpt := keypair.Random()
+	amount := common.Amount(1 * common.AmountPerCoin)
+	block.NewBlockAccount(kps.Address(), amount).MustSave(st)
+	block.NewBlockAccount(kpp.Address(), common.BaseFee).MustSave(st)
+	block.NewBlockAccount(kpt.Address(), common.BaseReserve).MustSave(st)
+
+	makeTx := func(kp *keypair.Full, sequenceID uint64, payer *keypair.Full) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(kpt.Address(), amount))
+		tx, _ := transaction.NewTransaction(kp.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.H.Version = common.TransactionVersionV2
+		tx.Sign(kp, networkID)
+		if payer != nil {
+			// the fee payer wraps the signed transaction
+			tx.SignFeePayer(payer, networkID)
+		}
+		return tx
+	}
+
+	// the source can not pay the fee
+	require.Equal(t, errors.TransactionExcessAbilityToPay, ValidateTx(st, nr.Conf, makeTx(kps, 0, nil)))
+
+	{ // the fee payer does not exist
+		tx := makeTx(kps, 0, keypair.Random())
+		require.Equal(t, errors.BlockAccountDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the fee payer can pay the fee only once
+		kpo := keypair.Random()
+		block.NewBlockAccount(kpo.Address(), amount).MustSave(st)
+
+		accounts := NewRunningAccounts(st, nr.Conf, nr.NetworkParameters())
+		require.NoError(t, accounts.Validate(makeTx(kps, 0, kpp)))
+		require.Equal(t, errors.TransactionExcessAbilityToPay, accounts.Validate(makeTx(kpo, 0, kpp)))
+	}
+
+	{ // the signatures of fee payer must reach the low threshold
+		kpm := keypair.Random()
+		kpa := keypair.Random()
+		kpb := keypair.Random()
+		bam := block.NewBlockAccount(kpm.Address(), common.BaseFee)
+		bam.SetSigners(
+			[]common.Signer{{Address: kpa.Address(), Weight: 1}, {Address: kpb.Address(), Weight: 1}},
+			common.Thresholds{Low: 2, Medium: 2, High: 2},
+		)
+		bam.MustSave(st)
+
+		// the key of fee payer is not the signer
+		require.Equal(t, errors.TransactionUnknownSigner, ValidateTx(st, nr.Conf, makeTx(kps, 0, kpm)))
+
+		tx := makeTx(kps, 0, nil)
+		tx.SetFeePayer(kpm.Address())
+		tx.SignFeePayer(kpa, networkID)
+		require.NoError(t, tx.IsWellFormed(nr.Conf))
+		require.Equal(t, errors.TransactionInsufficientSignatures, ValidateTx(st, nr.Conf, tx))
+
+		tx.SignFeePayer(kpb, networkID)
+		require.NoError(t, tx.IsWellFormed(nr.Conf))
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+	}
+
+	tx := makeTx(kps, 0, kpp)
+	require.NoError(t, ValidateTx(st, nr.Conf, tx))
+	require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+
+	bas, _ := block.GetBlockAccount(st, kps.Address())
+	require.Equal(t, common.Amount(0), bas.Balance)
+	require.Equal(t, uint64(1), bas.SequenceID)
+
+	bap, _ := block.GetBlockAccount(st, kpp.Address())
+	require.Equal(t, common.Amount(0), bap.Balance)
+	require.Equal(t, uint64(0), bap.SequenceID)
+
+	bat, _ := block.GetBlockAccount(st, kpt.Address())
+	require.Equal(t, common.BaseReserve.MustAdd(amount), bat.Balance)
+
+	// the fee is collected from the fee payer
+	bt, err := block.GetBlockTransaction(st, tx.GetHash())
+	require.NoError(t, err)
+	require.Equal(t, kpp.Address(), bt.FeePayer)
+	require.Equal(t, common.BaseFee, bt.Fee)
+}
+
+func TestValidateTxFeeSchedule(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kp := keypair.Random()
+	block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+
+	makeTx := func(fee common.Amount) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1)))
+		tx, _ := transaction.NewTransaction(kp.Address(), 0, operation.NewFeeSchedule(), op)
+		tx.B.Fee = fee
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	// by default, `common.BaseFee`
+	require.NoError(t, ValidateTx(st, nr.Conf, makeTx(common.BaseFee)))
+	require.Equal(t, errors.InvalidFee, ValidateTx(st, nr.Conf, makeTx(common.BaseFee-1)))
+
+	// the fee schedule of genesis block
+	params := operation.NewNetworkParameters()
+	params.FeeSchedule.Operations = map[operation.OperationType]common.Amount{operation.TypePayment: common.BaseFee * 2}
+
+	stf := storage.NewTestStorage()
+	defer stf.Close()
+	genesisAccount := block.NewBlockAccount(block.GenesisKP.Address(), nr.Conf.InitialBalance)
+	genesisAccount.MustSave(stf)
+	commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
+	commonAccount.MustSave(stf)
+	_, err := block.MakeGenesisBlock(stf, *genesisAccount, *commonAccount, params, networkID)
+	require.NoError(t, err)
+	block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(stf)
+
+	require.Equal(t, errors.InvalidFee, ValidateTx(stf, nr.Conf, makeTx(common.BaseFee)))
+	require.NoError(t, ValidateTx(stf, nr.Conf, makeTx(common.BaseFee*2)))
+}
+
+func TestHashLock(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	initialBalance := common.Amount(1 * common.AmountPerCoin)
+	bas := block.NewBlockAccount(kps.Address(), initialBalance)
+	bas.MustSave(st)
+	bat := block.NewBlockAccount(kpt.Address(), initialBalance)
+	bat.MustSave(st)
+
+	amount := common.Amount(1000)
+	preimage := []byte("showmethemoney")
+	hashLock := operation.MakeHashLock(preimage)
+	height := block.GetLatestBlock(st).Height
+
+	makeTx := func(kp *keypair.Full, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) {
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+		require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+	}
+
+	{ // the expiry must be after the next block
+		tx := makeTx(kps, operation.NewHashLockCreate(kpt.Address(), amount, hashLock, height+1))
+		require.Equal(t, errors.InvalidHashLock, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the target must exist
+		tx := makeTx(kps, operation.NewHashLockCreate(keypair.Random().Address(), amount, hashLock, height+3))
+		require.Equal(t, errors.BlockAccountDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // create
+		tx := makeTx(kps, operation.NewHashLockCreate(kpt.Address(), amount, hashLock, height+3))
+		finishTx(tx)
+
+		ba, _ := block.GetBlockAccount(st, kps.Address())
+		require.Equal(t, initialBalance.MustSub(amount).MustSub(tx.B.Fee), ba.Balance)
+
+		lock, err := block.GetBlockHashLock(st, kps.Address(), kpt.Address(), hashLock)
+		require.NoError(t, err)
+		require.Equal(t, amount, lock.Amount)
+		require.True(t, lock.IsLocked())
+
+		tx = makeTx(kps, operation.NewHashLockCreate(kpt.Address(), amount, hashLock, height+3))
+		require.Equal(t, errors.HashLockAlreadyExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the locker can not be merged
+		tx := makeTx(kps, operation.NewAccountMerge(kpt.Address()))
+		require.Equal(t, errors.AccountMergeHasHashLocks, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the wrong preimage
+		tx := makeTx(kpt, operation.NewHashLockClaim(kps.Address(), hex.EncodeToString([]byte("wrong"))))
+		require.Equal(t, errors.HashLockDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // only the target can claim
+		tx := makeTx(block.GenesisKP, operation.NewHashLockClaim(kps.Address(), hex.EncodeToString(preimage)))
+		require.Equal(t, errors.HashLockDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // refund before expiry
+		tx := makeTx(kps, operation.NewHashLockRefund(kpt.Address(), hashLock))
+		require.Equal(t, errors.HashLockNotExpired, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // claim
+		tx := makeTx(kpt, operation.NewHashLockClaim(kps.Address(), hex.EncodeToString(preimage)))
+		finishTx(tx)
+
+		ba, _ := block.GetBlockAccount(st, kpt.Address())
+		require.Equal(t, initialBalance.MustAdd(amount).MustSub(tx.B.Fee), ba.Balance)
+
+		lock, _ := block.GetBlockHashLock(st, kps.Address(), kpt.Address(), hashLock)
+		require.Equal(t, block.HashLockClaimed, lock.State)
+		require.Equal(t, hex.EncodeToString(preimage), lock.Preimage)
+
+		tx = makeTx(kpt, operation.NewHashLockClaim(kps.Address(), hex.EncodeToString(preimage)))
+		require.Equal(t, errors.HashLockNotLocked, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // refund after expiry
+		otherHashLock := operation.MakeHashLock([]byte("other"))
+		finishTx(makeTx(kps, operation.NewHashLockCreate(kpt.Address(), amount, otherHashLock, height+2)))
+		before, _ := block.GetBlockAccount(st, kps.Address())
+
+		latest := block.GetLatestBlock(st)
+		for latest.Height < height+2 {
+			latest = block.TestMakeNewBlockWithPrevBlock(latest, []string{})
+			latest.MustSave(st)
+		}
+
+		tx := makeTx(kpt, operation.NewHashLockClaim(kps.Address(), hex.EncodeToString([]byte("other"))))
+		require.Equal(t, errors.HashLockExpired, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kps, operation.NewHashLockRefund(kpt.Address(), otherHashLock))
+		finishTx(tx)
+
+		ba, _ := block.GetBlockAccount(st, kps.Address())
+		require.Equal(t, before.Balance.MustAdd(amount).MustSub(tx.B.Fee), ba.Balance)
+
+		lock, _ := block.GetBlockHashLock(st, kps.Address(), kpt.Address(), otherHashLock)
+		require.Equal(t, block.HashLockRefunded, lock.State)
+	}
+}
+
+// The same hash lock can not be claimed twice in one ballot.
+func TestBallotTransactionsHashLocks(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpt := keypair.Random()
+	block.NewBlockAccount(kpt.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+
+	preimage := []byte("showmethemoney")
+	lock := block.NewBlockHashLock(
+		block.GenesisKP.Address(),
+		kpt.Address(),
+		common.Amount(1000),
+		operation.MakeHashLock(preimage),
+		block.GetLatestBlock(st).Height+10,
+	)
+	require.NoError(t, lock.Save(st))
+
+	var hashes []string
+	for i := uint64(0); i < 2; i++ {
+		op, _ := operation.NewOperation(operation.NewHashLockClaim(block.GenesisKP.Address(), hex.EncodeToString(preimage)))
+		tx, _ := transaction.NewTransaction(kpt.Address(), i, operation.NewFeeSchedule(), op)
+		tx.Sign(kpt, networkID)
+		nr.TransactionPool.Add(tx)
+		hashes = append(hashes, tx.GetHash())
+	}
+
+	newChecker := func(checkTransactionsOnly bool) *BallotTransactionChecker {
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: []common.CheckerFunc{BallotTransactionsHashLocks}},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			Transactions:          hashes,
+			ValidTransactions:     hashes,
+			CheckTransactionsOnly: checkTransactionsOnly,
+			transactionCache:      NewTransactionCache(st, nr.TransactionPool),
+		}
+	}
+
+	{
+		checker := newChecker(false)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.HashLockNotLocked, err)
+	}
+
+	{
+		checker := newChecker(true)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, hashes[:1], checker.ValidTransactions)
+	}
+}
+
+func TestScheduledPayment(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	initialBalance := common.Amount(1 * common.AmountPerCoin)
+	bas := block.NewBlockAccount(kps.Address(), initialBalance)
+	bas.MustSave(st)
+	bat := block.NewBlockAccount(kpt.Address(), initialBalance)
+	bat.MustSave(st)
+
+	amount := common.Amount(1000)
+	height := block.GetLatestBlock(st).Height
+
+	makeTx := func(kp *keypair.Full, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) {
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+		require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+	}
+	makePtx := func(payments ...operation.ScheduledPaymentExecute) ballot.ProposerTransaction {
+		var ops []operation.Operation
+		for _, opb := range payments {
+			op, _ := operation.NewOperation(opb)
+			ops = append(ops, op)
+		}
+		ptx, _ := ballot.NewProposerTransaction(nr.localNode.Address(), ops...)
+		return ptx
+	}
+	nextBlock := func() {
+		latest := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), []string{})
+		payments, err := NewScheduledPaymentExecutes(st, latest.Height)
+		require.NoError(t, err)
+		latest.MustSave(st)
+		require.NoError(t, FinishScheduledPayments(st, latest, makePtx(payments...), common.NopLogger()))
+	}
+	balance := func(address string) common.Amount {
+		ba, _ := block.GetBlockAccount(st, address)
+		return ba.Balance
+	}
+
+	{ // the start must be after the next block
+		tx := makeTx(kps, operation.NewScheduledPaymentCreate("salary", kpt.Address(), amount, height+1, 2, 3))
+		require.Equal(t, errors.InvalidScheduledPayment, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the target must exist
+		tx := makeTx(kps, operation.NewScheduledPaymentCreate("salary", keypair.Random().Address(), amount, height+2, 2, 3))
+		require.Equal(t, errors.BlockAccountDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the reserved amount can not exceed the balance
+		tx := makeTx(kps, operation.NewScheduledPaymentCreate("salary", kpt.Address(), initialBalance, height+2, 2, 3))
+		require.Equal(t, errors.TransactionExcessAbilityToPay, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // create; executed at height+2, height+4 and height+6
+		tx := makeTx(kps, operation.NewScheduledPaymentCreate("salary", kpt.Address(), amount, height+2, 2, 3))
+		finishTx(tx)
+		require.Equal(t, initialBalance.MustSub(amount.MustMult(3)).MustSub(tx.B.Fee), balance(kps.Address()))
+
+		payment, err := block.GetBlockScheduledPayment(st, kps.Address(), "salary")
+		require.NoError(t, err)
+		require.True(t, payment.IsActive())
+		require.Equal(t, amount.MustMult(3), payment.Reserved())
+
+		tx = makeTx(kps, operation.NewScheduledPaymentCreate("salary", kpt.Address(), amount, height+2, 2, 3))
+		require.Equal(t, errors.ScheduledPaymentAlreadyExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the source can not be merged
+		tx := makeTx(kps, operation.NewAccountMerge(kpt.Address()))
+		require.Equal(t, errors.AccountMergeHasScheduledPayments, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // executed by block height
+		nextBlock() // height+1
+		require.Equal(t, initialBalance, balance(kpt.Address()))
+
+		// the payment, which is due at the next block, can not be cancelled
+		tx := makeTx(kps, operation.NewScheduledPaymentCancel("salary"))
+		require.Equal(t, errors.InvalidScheduledPayment, ValidateTx(st, nr.Conf, tx))
+
+		// the proposer transaction must have the due payment
+		payments, err := NewScheduledPaymentExecutes(st, height+2)
+		require.NoError(t, err)
+		require.Equal(t, []operation.ScheduledPaymentExecute{
+			operation.NewScheduledPaymentExecute(kps.Address(), "salary", kpt.Address(), amount, false),
+		}, payments)
+		latest := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), []string{})
+		require.Equal(t, errors.InvalidProposerTransaction, FinishScheduledPayments(st, latest, makePtx(), common.NopLogger()))
+
+		nextBlock() // height+2
+		require.Equal(t, initialBalance.MustAdd(amount), balance(kpt.Address()))
+
+		nextBlock() // height+3
+		require.Equal(t, initialBalance.MustAdd(amount), balance(kpt.Address()))
+
+		nextBlock() // height+4
+		require.Equal(t, initialBalance.MustAdd(amount.MustMult(2)), balance(kpt.Address()))
+
+		payment, _ := block.GetBlockScheduledPayment(st, kps.Address(), "salary")
+		require.Equal(t, uint64(1), payment.Remaining)
+		require.Equal(t, height+6, payment.Next)
+	}
+
+	{ // cancel refunds the remaining payment
+		before := balance(kps.Address())
+
+		tx := makeTx(kps, operation.NewScheduledPaymentCancel("salary"))
+		finishTx(tx)
+		require.Equal(t, before.MustAdd(amount).MustSub(tx.B.Fee), balance(kps.Address()))
+
+		payment, _ := block.GetBlockScheduledPayment(st, kps.Address(), "salary")
+		require.Equal(t, block.ScheduledPaymentCancelled, payment.State)
+
+		nextBlock() // height+5
+		nextBlock() // height+6
+		require.Equal(t, initialBalance.MustAdd(amount.MustMult(2)), balance(kpt.Address()))
+
+		tx = makeTx(kps, operation.NewScheduledPaymentCancel("salary"))
+		require.Equal(t, errors.ScheduledPaymentNotActive, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kps, operation.NewScheduledPaymentCancel("unknown"))
+		require.Equal(t, errors.ScheduledPaymentDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // finished after the last payment
+		latest := block.GetLatestBlock(st).Height
+		finishTx(makeTx(kps, operation.NewScheduledPaymentCreate("once", kpt.Address(), amount, latest+2, 0, 1)))
+		nextBlock()
+		nextBlock()
+		require.Equal(t, initialBalance.MustAdd(amount.MustMult(3)), balance(kpt.Address()))
+
+		payment, _ := block.GetBlockScheduledPayment(st, kps.Address(), "once")
+		require.Equal(t, block.ScheduledPaymentFinished, payment.State)
+		require.Equal(t, common.Amount(0), payment.Reserved())
+	}
+
+	{ // cancelled if the target was merged
+		kpm := keypair.Random()
+		block.NewBlockAccount(kpm.Address(), initialBalance).MustSave(st)
+
+		latest := block.GetLatestBlock(st).Height
+		finishTx(makeTx(kps, operation.NewScheduledPaymentCreate("merged", kpm.Address(), amount, latest+2, 1, 2)))
+		before := balance(kps.Address())
+
+		finishTx(makeTx(kpm, operation.NewAccountMerge(kpt.Address())))
+		nextBlock()
+
+		payments, err := NewScheduledPaymentExecutes(st, latest+2)
+		require.NoError(t, err)
+		require.Equal(t, []operation.ScheduledPaymentExecute{
+			operation.NewScheduledPaymentExecute(kps.Address(), "merged", kpm.Address(), amount.MustMult(2), true),
+		}, payments)
+
+		nextBlock()
+		require.Equal(t, before.MustAdd(amount.MustMult(2)), balance(kps.Address()))
+
+		payment, _ := block.GetBlockScheduledPayment(st, kps.Address(), "merged")
+		require.Equal(t, block.ScheduledPaymentCancelled, payment.State)
+	}
+
+	{ // cancelled if the flags of target do not accept the payment any more
+		latest := block.GetLatestBlock(st).Height
+		tx := makeTx(kps, operation.NewScheduledPaymentCreate("memo", kpt.Address(), amount, latest+2, 1, 2))
+		tx.H.Version = common.TransactionVersionV2
+		tx.B.Memo = &transaction.Memo{Type: transaction.MemoText, Value: "deposit-1"}
+		tx.Sign(kps, networkID)
+		finishTx(tx)
+		finishTx(makeTx(kps, operation.NewScheduledPaymentCreate("nomemo", kpt.Address(), amount, latest+2, 1, 2)))
+
+		payment, _ := block.GetBlockScheduledPayment(st, kps.Address(), "memo")
+		require.True(t, payment.HasMemo)
+		require.True(t, payment.IsActive())
+
+		bat, _ := block.GetBlockAccount(st, kpt.Address())
+		bat.SetOptions(common.AccountFlagRequireMemo, nil)
+		bat.MustSave(st)
+		nextBlock()
+
+		payments, err := NewScheduledPaymentExecutes(st, latest+2)
+		require.NoError(t, err)
+		require.Equal(t, []operation.ScheduledPaymentExecute{
+			operation.NewScheduledPaymentExecute(kps.Address(), "memo", kpt.Address(), amount, false),
+			operation.NewScheduledPaymentExecute(kps.Address(), "nomemo", kpt.Address(), amount.MustMult(2), true),
+		}, payments)
+		nextBlock()
+
+		bat, _ = block.GetBlockAccount(st, kpt.Address())
+		bat.SetOptions(common.AccountFlagBlockIncoming, nil)
+		bat.MustSave(st)
+
+		payments, err = NewScheduledPaymentExecutes(st, latest+3)
+		require.NoError(t, err)
+		require.Equal(t, []operation.ScheduledPaymentExecute{
+			operation.NewScheduledPaymentExecute(kps.Address(), "memo", kpt.Address(), amount, true),
+		}, payments)
+		nextBlock()
+
+		payment, _ = block.GetBlockScheduledPayment(st, kps.Address(), "memo")
+		require.Equal(t, block.ScheduledPaymentCancelled, payment.State)
+	}
+}
+
+func TestAccountFlags(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	kpa := keypair.Random()
+	initialBalance := common.Amount(1 * common.AmountPerCoin)
+	for _, kp := range []*keypair.Full{kps, kpt, kpa} {
+		block.NewBlockAccount(kp.Address(), initialBalance).MustSave(st)
+	}
+
+	makeTx := func(kp *keypair.Full, memo *transaction.Memo, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		if memo != nil {
+			tx.H.Version = common.TransactionVersionV2
+			tx.B.Memo = memo
+		}
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	payment := operation.NewPayment(kpt.Address(), common.Amount(1000))
+	memo := &transaction.Memo{Type: transaction.MemoText, Value: "deposit-1"}
+
+	{ // set options
+		tx := makeTx(kpt, nil, operation.NewSetOptions(common.AccountFlagRequireMemo, nil))
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+		require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+
+		bat, _ := block.GetBlockAccount(st, kpt.Address())
+		require.True(t, bat.Flags.Has(common.AccountFlagRequireMemo))
+	}
+
+	height := block.GetLatestBlock(st).Height
+	incomings := []operation.Body{
+		payment,
+		operation.NewScheduledPaymentCreate("salary", kpt.Address(), common.Amount(1000), height+2, 1, 2),
+		operation.NewHashLockCreate(kpt.Address(), common.Amount(1000), operation.MakeHashLock([]byte("showmethemoney")), height+3),
+		operation.NewAccountMerge(kpt.Address()),
+	}
+
+	{ // require memo
+		for _, opb := range incomings {
+			require.Equal(t, errors.AccountRequiresMemo, ValidateTx(st, nr.Conf, makeTx(kps, nil, opb)))
+			require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kps, memo, opb)))
+		}
+	}
+
+	{ // block incoming
+		bat, _ := block.GetBlockAccount(st, kpt.Address())
+		bat.SetOptions(common.AccountFlagBlockIncoming, []string{kpa.Address()})
+		bat.MustSave(st)
+
+		for _, opb := range incomings {
+			require.Equal(t, errors.AccountNotAllowed, ValidateTx(st, nr.Conf, makeTx(kps, nil, opb)))
+			require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kpa, nil, opb)))
+		}
+	}
+
+	{ // auth required; only the allowed source can create the frozen account
+		// linked to the account
+		bat, _ := block.GetBlockAccount(st, kpt.Address())
+		bat.SetOptions(common.AccountFlagAuthRequired, []string{kpa.Address()})
+		bat.MustSave(st)
+
+		createFrozen := operation.NewCreateAccount(keypair.Random().Address(), common.BaseReserve, kpt.Address())
+		require.Equal(t, errors.AccountNotAllowed, ValidateTx(st, nr.Conf, makeTx(kps, nil, createFrozen)))
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kpa, nil, createFrozen)))
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kpt, nil, createFrozen)))
+
+		createVesting := operation.NewCreateVestingAccount(keypair.Random().Address(), common.BaseReserve, kpt.Address(), 0, height+10)
+		require.Equal(t, errors.AccountNotAllowed, ValidateTx(st, nr.Conf, makeTx(kps, nil, createVesting)))
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kpa, nil, createVesting)))
+
+		// the other account is not affected
+		create := operation.NewCreateAccount(keypair.Random().Address(), common.BaseReserve, "")
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kps, nil, create)))
+
+		// the payment is not blocked without `common.AccountFlagBlockIncoming`
+		require.NoError(t, ValidateTx(st, nr.Conf, makeTx(kps, nil, payment)))
+	}
+}
+
+func TestVestingAccount(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	kpv := keypair.Random()
+	initialBalance := common.Amount(100 * common.AmountPerCoin)
+	block.NewBlockAccount(kps.Address(), initialBalance).MustSave(st)
+
+	amount := common.BaseReserve * 10
+	height := block.GetLatestBlock(st).Height
+
+	makeTx := func(kp *keypair.Full, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) {
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+		require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+	}
+	newBlocks := func(to uint64) {
+		latest := block.GetLatestBlock(st)
+		for latest.Height < to {
+			latest = block.TestMakeNewBlockWithPrevBlock(latest, []string{})
+			latest.MustSave(st)
+		}
+	}
+
+	{ // the vesting must not be finished at the next block
+		tx := makeTx(kps, operation.NewCreateVestingAccount(kpv.Address(), amount, kps.Address(), 0, height+1))
+		require.Equal(t, errors.InvalidVesting, ValidateTx(st, nr.Conf, tx))
+	}
+
+	cliff, end := height+2, height+12
+	finishTx(makeTx(kps, operation.NewCreateVestingAccount(kpv.Address(), amount, kps.Address(), cliff, end)))
+
+	bav, err := block.GetBlockAccount(st, kpv.Address())
+	require.NoError(t, err)
+	require.Equal(t, amount, bav.Balance)
+	require.Equal(t, kps.Address(), bav.Linked)
+	require.True(t, bav.IsVesting())
+	require.True(t, bav.IsFrozen())
+
+	{ // nothing is vested before cliff
+		tx := makeTx(kpv, operation.NewPayment(kps.Address(), common.Amount(1)))
+		require.Equal(t, errors.VestingNotVested, ValidateTx(st, nr.Conf, tx))
+	}
+
+	// the half is vested
+	newBlocks(cliff + 4)
+	vested := bav.Vesting.Vested(cliff + 5)
+	require.Equal(t, amount/2, vested)
+
+	{ // over the vested
+		tx := makeTx(kpv, operation.NewPayment(kps.Address(), vested))
+		require.Equal(t, errors.VestingNotVested, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the vesting account can not be merged before end
+		tx := makeTx(kpv, operation.NewAccountMerge(kps.Address()))
+		require.Equal(t, errors.VestingNotVested, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // within the vested
+		tx := makeTx(kpv, operation.NewPayment(kps.Address(), vested.MustSub(common.BaseFee)))
+		finishTx(tx)
+	}
+
+	// the whole is vested
+	newBlocks(end)
+	finishTx(makeTx(kpv, operation.NewAccountMerge(kps.Address())))
+
+	_, err = block.GetBlockAccount(st, kpv.Address())
+	require.Equal(t, errors.StorageRecordDoesNotExist, err)
+}
+
+func TestCongressVoting(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpCongress := keypair.Random()
+	kpFunding := keypair.Random()
+	members := []*keypair.Full{keypair.Random(), keypair.Random(), keypair.Random()}
+
+	conf := nr.Conf
+	conf.CongressAccountAddress = kpCongress.Address()
+	conf.CommonAccountAddress = block.CommonKP.Address()
+
+	initialBalance := common.Amount(1 * common.AmountPerCoin)
+	for _, kp := range append([]*keypair.Full{kpCongress, kpFunding}, members...) {
+		block.NewBlockAccount(kp.Address(), initialBalance).MustSave(st)
+	}
+	commonAccount, _ := block.GetBlockAccount(st, block.CommonKP.Address())
+	commonAccount.Balance = initialBalance
+	commonAccount.MustSave(st)
+
+	makeTx := func(kp *keypair.Full, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) string {
+		require.NoError(t, ValidateTx(st, conf, tx))
+		latest := block.GetLatestBlock(st)
+		require.NoError(t, FinishTransactions(latest, []*transaction.Transaction{&tx}, st))
+
+		bo, err := block.NewBlockOperationFromOperation(tx.B.Operations[0], tx, latest.Height, 0)
+		require.NoError(t, err)
+		require.NoError(t, bo.Save(st))
+
+		return fmt.Sprintf("%s-0", tx.GetHash())
+	}
+	newBlocks := func(to uint64) {
+		latest := block.GetLatestBlock(st)
+		for latest.Height < to {
+			latest = block.TestMakeNewBlockWithPrevBlock(latest, []string{})
+			latest.MustSave(st)
+		}
+	}
+
+	{ // only congress can manage the members
+		tx := makeTx(members[0], operation.NewCongressMembership(members[0].Address(), false))
+		require.Equal(t, errors.CongressAddressMisMatched, ValidateTx(st, conf, tx))
+	}
+	for _, kp := range members {
+		finishTx(makeTx(kpCongress, operation.NewCongressMembership(kp.Address(), false)))
+	}
+	{
+		tx := makeTx(kpCongress, operation.NewCongressMembership(members[0].Address(), false))
+		require.Equal(t, errors.CongressMemberAlreadyExists, ValidateTx(st, conf, tx))
+	}
+
+	height := block.GetLatestBlock(st).Height
+	start, end := height+2, height+4
+	fundingAmount := common.Amount(1000)
+	votingHash := finishTx(makeTx(kpCongress, operation.NewCongressVoting("dummy", start, end, fundingAmount, kpFunding.Address())))
+
+	{ // the voting is not opened
+		tx := makeTx(members[0], operation.NewCongressVote(votingHash, operation.VoteYes))
+		require.Equal(t, errors.CongressVotingNotOpened, ValidateTx(st, conf, tx))
+	}
+
+	newBlocks(start - 1)
+
+	{ // not member
+		tx := makeTx(kpFunding, operation.NewCongressVote(votingHash, operation.VoteYes))
+		require.Equal(t, errors.CongressMemberDoesNotExists, ValidateTx(st, conf, tx))
+	}
+
+	finishTx(makeTx(members[0], operation.NewCongressVote(votingHash, operation.VoteYes)))
+	finishTx(makeTx(members[1], operation.NewCongressVote(votingHash, operation.VoteYes)))
+	finishTx(makeTx(members[2], operation.NewCongressVote(votingHash, operation.VoteNo)))
+
+	{ // vote twice
+		tx := makeTx(members[0], operation.NewCongressVote(votingHash, operation.VoteNo))
+		require.Equal(t, errors.CongressVoteAlreadyCast, ValidateTx(st, conf, tx))
+	}
+
+	tally, err := block.GetBlockCongressVotingTally(st, votingHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), tally.Count())
+	require.Equal(t, uint64(2), tally.Yes)
+	require.Equal(t, uint64(1), tally.No)
+	require.True(t, tally.Passed())
+
+	makeResult := func(yes, no, abs uint64) operation.CongressVotingResult {
+		return operation.NewCongressVotingResult(
+			"dummy1", []string{"http://1.1.1.1/a"},
+			"dummy2", []string{"http://1.1.1.1/b"},
+			"dummy3", []string{"http://1.1.1.1/c"},
+			yes+no+abs, yes, no, abs,
+			votingHash,
+		)
+	}
+
+	{ // the voting is not closed
+		tx := makeTx(kpCongress, makeResult(2, 1, 0))
+		require.Equal(t, errors.CongressVotingNotClosed, ValidateTx(st, conf, tx))
+	}
+
+	newBlocks(end)
+
+	{ // the vote after end
+		tx := makeTx(kpCongress, operation.NewCongressMembership(kpFunding.Address(), false))
+		finishTx(tx)
+		tx = makeTx(kpFunding, operation.NewCongressVote(votingHash, operation.VoteNo))
+		require.Equal(t, errors.CongressVotingClosed, ValidateTx(st, conf, tx))
+	}
+
+	{ // the result must be matched with the tally
+		tx := makeTx(kpCongress, makeResult(70, 20, 10))
+		require.Equal(t, errors.CongressVotingResultMissMatched, ValidateTx(st, conf, tx))
+	}
+
+	{ // the inflation pf needs the accepted result
+		resultHash := fmt.Sprintf("%s-0", makeTx(kpCongress, makeResult(2, 1, 0)).GetHash())
+		tx := makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount, resultHash))
+		require.Error(t, ValidateTx(st, conf, tx))
+	}
+
+	resultHash := finishTx(makeTx(kpCongress, makeResult(2, 1, 0)))
+
+	{ // only one result
+		tx := makeTx(kpCongress, makeResult(2, 1, 0))
+		require.Equal(t, errors.CongressVotingResultAlreadyExists, ValidateTx(st, conf, tx))
+	}
+
+	{ // inflation pf
+		tx := makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount+1, resultHash))
+		require.Equal(t, errors.InflationPFAmountMissMatched, ValidateTx(st, conf, tx))
+
+		tx = makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount, resultHash))
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+
+	{ // the rejected voting can not be funded
+		height := block.GetLatestBlock(st).Height
+		votingHash := finishTx(makeTx(kpCongress, operation.NewCongressVoting("rejected", height+1, height+2, fundingAmount, kpFunding.Address())))
+		finishTx(makeTx(members[0], operation.NewCongressVote(votingHash, operation.VoteNo)))
+		newBlocks(height + 2)
+
+		result := makeResult(0, 1, 0)
+		result.CongressVotingHash = votingHash
+		resultHash := finishTx(makeTx(kpCongress, result))
+
+		tx := makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount, resultHash))
+		require.Equal(t, errors.CongressVotingNotPassed, ValidateTx(st, conf, tx))
+	}
+
+	// the voting created before the votes are cast on chain does not have
+	// the tally
+	makeLegacyVoting := func(name string) string {
+		height := block.GetLatestBlock(st).Height
+		votingHash := finishTx(makeTx(kpCongress, operation.NewCongressVoting(name, height+1, height+2, fundingAmount, kpFunding.Address())))
+		require.NoError(t, st.Remove(block.GetBlockCongressVotingTallyKey(votingHash)))
+		newBlocks(height + 2)
+		return votingHash
+	}
+
+	{ // the legacy voting can not be voted, but it's result is accepted
+		votingHash := makeLegacyVoting("legacy")
+
+		tx := makeTx(members[0], operation.NewCongressVote(votingHash, operation.VoteYes))
+		require.Equal(t, errors.CongressVotingNotTallied, ValidateTx(st, conf, tx))
+
+		result := makeResult(70, 20, 10)
+		result.CongressVotingHash = votingHash
+		resultHash := finishTx(makeTx(kpCongress, result))
+
+		// the closed tally is filled by the result
+		tally, err := block.GetBlockCongressVotingTally(st, votingHash)
+		require.NoError(t, err)
+		require.True(t, tally.Closed)
+		require.True(t, isCongressVotingResultMatched(result, tally))
+
+		tx = makeTx(kpCongress, result)
+		require.Equal(t, errors.CongressVotingResultAlreadyExists, ValidateTx(st, conf, tx))
+
+		tx = makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount, resultHash))
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+
+	{ // the result of legacy voting was stored without the tally
+		votingHash := makeLegacyVoting("legacy-result")
+
+		result := makeResult(0, 1, 0)
+		result.CongressVotingHash = votingHash
+		tx := makeTx(kpCongress, result)
+		bo, err := block.NewBlockOperationFromOperation(tx.B.Operations[0], tx, block.GetLatestBlock(st).Height, 0)
+		require.NoError(t, err)
+		require.NoError(t, bo.Save(st))
+
+		resultHash := fmt.Sprintf("%s-0", tx.GetHash())
+		tx = makeTx(block.CommonKP, operation.NewInflationPF(kpFunding.Address(), fundingAmount, resultHash))
+		require.NoError(t, ValidateTx(st, conf, tx))
+	}
+}
+
+func TestAsset(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpi := keypair.Random()
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	initialBalance := common.Amount(1 * common.AmountPerCoin)
+	for _, kp := range []*keypair.Full{kpi, kps, kpt} {
+		block.NewBlockAccount(kp.Address(), initialBalance).MustSave(st)
+	}
+	asset := common.NewAsset("POINT", kpi.Address())
+
+	makeTx := func(kp *keypair.Full, opbs ...operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		var ops []operation.Operation
+		for _, opb := range opbs {
+			op, _ := operation.NewOperation(opb)
+			ops = append(ops, op)
+		}
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), ops...)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) {
+		require.NoError(t, ValidateTx(st, nr.Conf, tx))
+		require.NoError(t, FinishTransactions(block.GetLatestBlock(st), []*transaction.Transaction{&tx}, st))
+	}
+	balance := func(address string) common.Amount {
+		tl, err := block.GetBlockTrustline(st, address, asset)
+		require.NoError(t, err)
+		return tl.Balance
+	}
+	issued := func() common.Amount {
+		ba, err := block.GetOrNewBlockAsset(st, asset)
+		require.NoError(t, err)
+		return ba.Issued
+	}
+
+	{ // the target must trust the asset
+		tx := makeTx(kpi, operation.NewIssueAsset("POINT", kps.Address(), common.Amount(100)))
+		require.Equal(t, errors.TrustlineDoesNotExists, ValidateTx(st, nr.Conf, tx))
+
+		// the issuer can not trust it's own asset
+		tx = makeTx(kpi, operation.NewTrust(asset, common.Amount(1000)))
+		require.Equal(t, errors.InvalidOperation, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // trust
+		finishTx(makeTx(kps, operation.NewTrust(asset, common.Amount(1000))))
+		finishTx(makeTx(kpt, operation.NewTrust(asset, common.Amount(100))))
+
+		bas, _ := block.GetBlockAccount(st, kps.Address())
+		require.Equal(t, uint64(1), bas.Trustlines)
+		require.Equal(t, common.BaseReserve.MustMult(2), bas.Reserve())
+	}
+
+	{ // issue over the limit of trustline
+		tx := makeTx(kpi, operation.NewIssueAsset("POINT", kps.Address(), common.Amount(1001)))
+		require.Equal(t, errors.TrustlineLimitExceeded, ValidateTx(st, nr.Conf, tx))
+
+		// the operations of one transaction are checked together
+		tx = makeTx(
+			kpi,
+			operation.NewIssueAsset("POINT", kps.Address(), common.Amount(600)),
+			operation.NewIssueAsset("POINT", kps.Address(), common.Amount(600)),
+		)
+		require.Equal(t, errors.TrustlineLimitExceeded, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // issue
+		finishTx(makeTx(kpi, operation.NewIssueAsset("POINT", kps.Address(), common.Amount(500))))
+		require.Equal(t, common.Amount(500), balance(kps.Address()))
+		require.Equal(t, common.Amount(500), issued())
+	}
+
+	{ // payment
+		tx := makeTx(kps, operation.NewAssetPayment(kpt.Address(), asset, common.Amount(101)))
+		require.Equal(t, errors.TrustlineLimitExceeded, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kpt, operation.NewAssetPayment(kps.Address(), asset, common.Amount(1)))
+		require.Equal(t, errors.AssetBalanceNotEnough, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kps, operation.NewAssetPayment(keypair.Random().Address(), asset, common.Amount(1)))
+		require.Equal(t, errors.BlockAccountDoesNotExists, ValidateTx(st, nr.Conf, tx))
+
+		finishTx(makeTx(kps, operation.NewAssetPayment(kpt.Address(), asset, common.Amount(100))))
+		require.Equal(t, common.Amount(400), balance(kps.Address()))
+		require.Equal(t, common.Amount(100), balance(kpt.Address()))
+
+		// the native balance is charged only for the fee
+		bas, _ := block.GetBlockAccount(st, kps.Address())
+		require.Equal(t, initialBalance.MustSub(common.BaseFee.MustMult(2)), bas.Balance)
+	}
+
+	{ // redeem to the issuer
+		finishTx(makeTx(kpt, operation.NewAssetPayment(kpi.Address(), asset, common.Amount(100))))
+		require.Equal(t, common.Amount(0), balance(kpt.Address()))
+		require.Equal(t, common.Amount(400), issued())
+	}
+
+	{ // the trustline with balance can not be removed and the limit can not
+		// be lower than the balance
+		tx := makeTx(kps, operation.NewTrust(asset, 0))
+		require.Equal(t, errors.TrustlineHasBalance, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kps, operation.NewTrust(asset, common.Amount(399)))
+		require.Equal(t, errors.TrustlineLimitExceeded, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the account with assets can not be merged
+		tx := makeTx(kps, operation.NewAccountMerge(kpt.Address()))
+		require.Equal(t, errors.AccountMergeHasAssets, ValidateTx(st, nr.Conf, tx))
+
+		tx = makeTx(kpi, operation.NewAccountMerge(kpt.Address()))
+		require.Equal(t, errors.AccountMergeHasAssets, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // remove trustline
+		finishTx(makeTx(kpt, operation.NewTrust(asset, 0)))
+
+		exists, err := block.ExistsBlockTrustline(st, kpt.Address(), asset)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		bat, _ := block.GetBlockAccount(st, kpt.Address())
+		require.Equal(t, uint64(0), bat.Trustlines)
+
+		tx := makeTx(kpt, operation.NewTrust(asset, 0))
+		require.Equal(t, errors.TrustlineDoesNotExists, ValidateTx(st, nr.Conf, tx))
+	}
+
+	{ // the balance must keep the reserve of trustline
+		kpr := keypair.Random()
+		block.NewBlockAccount(kpr.Address(), common.BaseReserve.MustAdd(common.BaseFee)).MustSave(st)
+
+		tx := makeTx(kpr, operation.NewTrust(asset, common.Amount(1000)))
+		require.Equal(t, errors.TrustlineNotEnoughReserve, ValidateTx(st, nr.Conf, tx))
+	}
+}
+
+func TestBallotTransactionsAssets(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpi := keypair.Random()
+	kps := keypair.Random()
+	kpt := keypair.Random()
+	for _, kp := range []*keypair.Full{kpi, kps, kpt} {
+		block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+	}
+
+	asset := common.NewAsset("POINT", kpi.Address())
+	for _, address := range []string{kps.Address(), kpt.Address()} {
+		require.NoError(t, block.NewBlockTrustline(address, asset, common.Amount(1000)).Save(st))
+	}
+	tl, _ := block.GetBlockTrustline(st, kps.Address(), asset)
+	tl.Balance = common.Amount(100)
+	require.NoError(t, tl.Save(st))
+
+	// the second payment spends the balance, which is already spent by the
+	// first one
+	var hashes []string
+	for i := uint64(0); i < 2; i++ {
+		op, _ := operation.NewOperation(operation.NewAssetPayment(kpt.Address(), asset, common.Amount(100)))
+		tx, _ := transaction.NewTransaction(kps.Address(), i, operation.NewFeeSchedule(), op)
+		tx.Sign(kps, networkID)
+		nr.TransactionPool.Add(tx)
+		hashes = append(hashes, tx.GetHash())
+	}
+
+	newChecker := func(checkTransactionsOnly bool) *BallotTransactionChecker {
+		return &BallotTransactionChecker{
+			DefaultChecker:        common.DefaultChecker{Funcs: []common.CheckerFunc{BallotTransactionsAssets}},
+			NodeRunner:            nr,
+			Conf:                  nr.Conf,
+			Transactions:          hashes,
+			ValidTransactions:     hashes,
+			CheckTransactionsOnly: checkTransactionsOnly,
+			transactionCache:      NewTransactionCache(st, nr.TransactionPool),
+		}
+	}
+
+	{
+		checker := newChecker(false)
+		err := common.RunChecker(checker, common.DefaultDeferFunc)
+		require.Equal(t, errors.AssetBalanceNotEnough, err)
+	}
+
+	{
+		checker := newChecker(true)
+		require.NoError(t, common.RunChecker(checker, common.DefaultDeferFunc))
+		require.Equal(t, hashes[:1], checker.ValidTransactions)
+	}
+}
+
+func TestValidateTxVersion(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+
+	op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1000)))
+	tx, _ := transaction.NewTransaction(kps.Address(), 0, operation.NewFeeSchedule(), op)
+	tx.Sign(kps, networkID)
+	require.Equal(t, common.TransactionVersionV1, tx.Version())
+
+	txV2 := tx
+	txV2.H.Version = common.TransactionVersionV2
+	txV2.Sign(kps, networkID)
+
+	// the test config activates V2 from the first block
+	require.NoError(t, ValidateTx(st, nr.Conf, tx))
+	require.NoError(t, ValidateTx(st, nr.Conf, txV2))
+
+	// by default, V2 is not activated
+	conf := nr.Conf
+	conf.TxV2ActivationHeight = 0
+	require.NoError(t, ValidateTx(st, conf, tx))
+	require.Equal(t, errors.InvalidMessageVersion, ValidateTx(st, conf, txV2))
+
+	// the activation height of genesis block has priority over the config
+	params := operation.NewNetworkParameters()
+	params.TxV2ActivationHeight = 10
+
+	stv := storage.NewTestStorage()
+	defer stv.Close()
+	genesisAccount := block.NewBlockAccount(block.GenesisKP.Address(), nr.Conf.InitialBalance)
+	genesisAccount.MustSave(stv)
+	commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
+	commonAccount.MustSave(stv)
+	_, err := block.MakeGenesisBlock(stv, *genesisAccount, *commonAccount, params, networkID)
+	require.NoError(t, err)
+	block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(stv)
+
+	// V2 is not accepted before the activation height
+	require.NoError(t, ValidateTx(stv, nr.Conf, tx))
+	require.Equal(t, errors.InvalidMessageVersion, ValidateTx(stv, nr.Conf, txV2))
+
+	latest := block.GetLatestBlock(stv)
+	for latest.Height < params.TxV2ActivationHeight-1 {
+		latest = block.TestMakeNewBlockWithPrevBlock(latest, []string{})
+		latest.MustSave(stv)
+	}
+	require.NoError(t, ValidateTx(stv, nr.Conf, txV2))
+}
+
+func TestManageValidator(t *testing.T) {
+	nr, localNode := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kpCongress := keypair.Random()
+	kpOther := keypair.Random()
+	for _, kp := range []*keypair.Full{kpCongress, kpOther} {
+		block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+	}
+
+	conf := nr.Conf
+	conf.CongressAccountAddress = kpCongress.Address()
+
+	makeTx := func(kp *keypair.Full, opb operation.Body) transaction.Transaction {
+		ba, _ := block.GetBlockAccount(st, kp.Address())
+		op, _ := operation.NewOperation(opb)
+		tx, _ := transaction.NewTransaction(kp.Address(), ba.SequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	finishTx := func(tx transaction.Transaction) {
+		require.NoError(t, ValidateTx(st, conf, tx))
+		latest := block.GetLatestBlock(st)
+		require.NoError(t, FinishTransactions(latest, []*transaction.Transaction{&tx}, st))
+	}
+	newBlocks := func(to uint64) {
+		latest := block.GetLatestBlock(st)
+		for latest.Height < to {
+			latest = block.TestMakeNewBlockWithPrevBlock(latest, []string{})
+			latest.MustSave(st)
+		}
+	}
+
+	{ // the genesis block does not have the validators, so the validators of
+		// node are the validator set of genesis
+		vs, err := block.GetBlockValidatorSet(st, common.GenesisBlockHeight)
+		require.NoError(t, err)
+		require.Equal(t, []string{localNode.Address()}, vs.Validators)
+	}
+
+	newValidator := keypair.Random().Address()
+	activation := block.GetLatestBlock(st).Height + 3
+
+	{ // only congress can manage the validators
+		tx := makeTx(kpOther, operation.NewManageValidator(newValidator, false, activation))
+		require.Equal(t, errors.CongressAddressMisMatched, ValidateTx(st, conf, tx))
+	}
+	{ // the change must be activated after the next block
+		tx := makeTx(kpCongress, operation.NewManageValidator(newValidator, false, activation-2))
+		require.Equal(t, errors.InvalidActivationHeight, ValidateTx(st, conf, tx))
+	}
+	{ // already validator
+		tx := makeTx(kpCongress, operation.NewManageValidator(localNode.Address(), false, activation))
+		require.Equal(t, errors.ValidatorAlreadyExists, ValidateTx(st, conf, tx))
+	}
+	{ // not validator
+		tx := makeTx(kpCongress, operation.NewManageValidator(newValidator, true, activation))
+		require.Equal(t, errors.ValidatorDoesNotExists, ValidateTx(st, conf, tx))
+	}
+	{ // the last validator can not be removed
+		tx := makeTx(kpCongress, operation.NewManageValidator(localNode.Address(), true, activation))
+		require.Equal(t, errors.ValidatorSetEmpty, ValidateTx(st, conf, tx))
+	}
+
+	finishTx(makeTx(kpCongress, operation.NewManageValidator(newValidator, false, activation)))
+	{
+		tx := makeTx(kpCongress, operation.NewManageValidator(newValidator, false, activation))
+		require.Equal(t, errors.ValidatorAlreadyExists, ValidateTx(st, conf, tx))
+
+		vs, err := block.GetBlockValidatorSet(st, activation-1)
+		require.NoError(t, err)
+		require.Equal(t, []string{localNode.Address()}, vs.Validators)
+
+		vs, err = block.GetBlockValidatorSet(st, activation)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(vs.Validators))
+		require.True(t, vs.Has(newValidator))
+	}
+
+	{ // validators are not switched before the activation height
+		newBlocks(activation - 2)
+		nr.switchValidators()
+		require.Equal(t, 1, len(localNode.GetValidators()))
+		require.Equal(t, 1, nr.Policy().Validators())
+	}
+
+	{ // switched at the activation height
+		newBlocks(activation - 1)
+		nr.switchValidators()
+		require.Equal(t, 2, len(localNode.GetValidators()))
+		require.True(t, localNode.HasValidators(newValidator))
+		require.Equal(t, 2, len(nr.ConnectionManager().AllValidators()))
+		require.Equal(t, 2, nr.Policy().Validators())
+	}
+
+	// the change is also applied to the later validator sets
+	otherValidator := keypair.Random().Address()
+	finishTx(makeTx(kpCongress, operation.NewManageValidator(localNode.Address(), true, activation+2)))
+	finishTx(makeTx(kpCongress, operation.NewManageValidator(otherValidator, false, activation+1)))
+	{
+		vs, err := block.GetBlockValidatorSet(st, activation+2)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(vs.Validators))
+		require.True(t, vs.Has(newValidator))
+		require.True(t, vs.Has(otherValidator))
+	}
+
+	newBlocks(activation + 1)
+	nr.switchValidators()
+	require.Equal(t, 2, len(localNode.GetValidators()))
+	require.False(t, localNode.HasValidators(localNode.Address()))
+	require.Equal(t, 2, nr.Policy().Validators())
+}
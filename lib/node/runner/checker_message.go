@@ -83,24 +83,23 @@ func HasTransaction(c common.Checker, args ...interface{}) (err error) {
 	return nil
 }
 
-// SameSource checks there are transactions which has same source in the
-// `Pool`.
-func MessageHasSameSource(c common.Checker, args ...interface{}) (err error) {
+// MessageValidate validates. If there are the transactions of same source in
+// the `Pool`, the transaction is validated after the ones of lower sequence id,
+// so it must have the next sequence id of them or the same sequence id of the
+// pending transaction, which will be replaced by fee.
+func MessageValidate(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*MessageChecker)
 
-	if checker.TransactionPool.IsSameSource(checker.Transaction.Source()) {
-		err = errors.TransactionSameSourceInPool
-		return
+	accounts := NewRunningAccounts(checker.Storage, checker.Conf, checker.Consensus.NetworkParameters())
+	for _, pending := range checker.TransactionPool.GetAllFromSource(checker.Transaction.Source()) {
+		if pending.B.SequenceID >= checker.Transaction.B.SequenceID {
+			break
+		}
+		// the invalid transactions in the `Pool` are ignored
+		accounts.Validate(pending)
 	}
 
-	return
-}
-
-// MessageValidate validates.
-func MessageValidate(c common.Checker, args ...interface{}) (err error) {
-	checker := c.(*MessageChecker)
-
-	if err = ValidateTx(checker.Storage, checker.Conf, checker.Transaction); err != nil {
+	if err = accounts.Validate(checker.Transaction); err != nil {
 		return
 	}
 
@@ -112,13 +111,7 @@ func MessageValidate(c common.Checker, args ...interface{}) (err error) {
 func PushIntoTransactionPool(c common.Checker, args ...interface{}) error {
 	checker := c.(*MessageChecker)
 
-	tx := checker.Transaction
-	err := checker.TransactionPool.Add(tx)
-	if err == errors.TransactionPoolFull {
-		return err
-	}
-
-	if _, err = block.SaveTransactionPool(checker.Storage, tx); err != nil {
+	if err := pushIntoTransactionPool(checker, checker.TransactionPool.Add); err != nil {
 		return err
 	}
 
@@ -131,13 +124,7 @@ func PushIntoTransactionPool(c common.Checker, args ...interface{}) error {
 func PushIntoTransactionPoolFromClient(c common.Checker, args ...interface{}) error {
 	checker := c.(*MessageChecker)
 
-	tx := checker.Transaction
-	err := checker.TransactionPool.AddFromClient(tx)
-	if err == errors.TransactionPoolFull {
-		return err
-	}
-
-	if _, err = block.SaveTransactionPool(checker.Storage, tx); err != nil {
+	if err := pushIntoTransactionPool(checker, checker.TransactionPool.AddFromClient); err != nil {
 		return err
 	}
 
@@ -150,17 +137,43 @@ func PushIntoTransactionPoolFromClient(c common.Checker, args ...interface{}) er
 func PushIntoTransactionPoolFromNode(c common.Checker, args ...interface{}) error {
 	checker := c.(*MessageChecker)
 
-	tx := checker.Transaction
-	err := checker.TransactionPool.AddFromNode(tx)
-	if err == errors.TransactionPoolFull {
+	if err := pushIntoTransactionPool(checker, checker.TransactionPool.AddFromNode); err != nil {
 		return err
 	}
 
-	if _, err = block.SaveTransactionPool(checker.Storage, tx); err != nil {
+	checker.Log.Debug("push transaction into TransactionPool from node")
+
+	return nil
+}
+
+// pushIntoTransactionPool adds the transaction into `Pool` by `add` and saves
+// it as pending. If it replaces the pending transaction of same sequence id
+// by fee, the replaced one is removed from storage.
+func pushIntoTransactionPool(checker *MessageChecker, add func(transaction.Transaction) error) error {
+	tx := checker.Transaction
+
+	var replaced string
+	for _, pending := range checker.TransactionPool.GetAllFromSource(tx.Source()) {
+		if pending.B.SequenceID == tx.B.SequenceID {
+			replaced = pending.GetHash()
+			break
+		}
+	}
+
+	err := add(tx)
+	if err == errors.TransactionPoolFull || err == errors.TransactionReplaceFeeTooLow || err == errors.TransactionPoolSourceLimit {
 		return err
 	}
 
-	checker.Log.Debug("push transaction into TransactionPool from node")
+	if len(replaced) > 0 && replaced != tx.GetHash() && !checker.TransactionPool.Has(replaced) {
+		if err = deleteRemovedTransactions(checker.Storage, replaced); err != nil {
+			return err
+		}
+	}
+
+	if _, err = block.SavePendingTransactionPool(checker.Storage, tx); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -69,7 +69,8 @@ func TestOnlyValidTransactionInTransactionPool(t *testing.T) {
 		require.True(t, nodeRunner.TransactionPool.Has(tx.GetHash()), "valid transaction must be in `Pool`")
 	}
 
-	{ // invalid transaction: same source already in Pool
+	var replacedHash string
+	{ // invalid transaction: same sequence id of source already in Pool with same fee
 		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
 		targetAccount.MustSave(nodeRunner.Storage())
 
@@ -77,13 +78,44 @@ func TestOnlyValidTransactionInTransactionPool(t *testing.T) {
 		tx.B.SequenceID = rootAccount.SequenceID
 		tx.Sign(rootKP, networkID)
 
-		runChecker(tx, errors.TransactionSameSourceInBallot)
+		runChecker(tx, errors.TransactionReplaceFeeTooLow)
 
 		require.False(
 			t,
 			nodeRunner.TransactionPool.Has(tx.GetHash()),
-			"invalid transaction must not be in `Pool`: same source already in `Pool`",
+			"invalid transaction must not be in `Pool`: same sequence id already in `Pool`",
 		)
+		replacedHash = nodeRunner.TransactionPool.GetAllFromSource(rootKP.Address())[0].GetHash()
+	}
+
+	{ // valid transaction: same sequence id of source already in Pool with higher fee
+		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
+		targetAccount.MustSave(nodeRunner.Storage())
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, rootKP, targetKP)
+		tx.B.SequenceID = rootAccount.SequenceID
+		tx.B.Fee = tx.B.Fee.MustMult(2)
+		tx.Sign(rootKP, networkID)
+
+		runChecker(tx, nil)
+
+		require.True(t, nodeRunner.TransactionPool.Has(tx.GetHash()), "transaction must replace the pending one")
+		require.False(t, nodeRunner.TransactionPool.Has(replacedHash), "replaced transaction must be removed from `Pool`")
+		require.Equal(t, 1, len(nodeRunner.TransactionPool.GetAllFromSource(rootKP.Address())))
+	}
+
+	{ // valid transaction: next sequence id of source in Pool
+		targetAccount, targetKP := TestMakeBlockAccount(common.Amount(10000000000000))
+		targetAccount.MustSave(nodeRunner.Storage())
+
+		tx := transaction.TestMakeTransactionWithKeypair(networkID, 1, rootKP, targetKP)
+		tx.B.SequenceID = rootAccount.SequenceID + 1
+		tx.Sign(rootKP, networkID)
+
+		runChecker(tx, nil)
+
+		require.True(t, nodeRunner.TransactionPool.Has(tx.GetHash()), "valid transaction must be in `Pool`")
+		require.Equal(t, 2, len(nodeRunner.TransactionPool.GetAllFromSource(rootKP.Address())))
 	}
 
 	{ // invalid transaction: source account does not exists
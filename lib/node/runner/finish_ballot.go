@@ -1,10 +1,13 @@
 package runner
 
 import (
+	"fmt"
+
 	logging "github.com/inconshreveable/log15"
 
 	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/storage"
@@ -42,6 +45,18 @@ func finishBallot(nr *NodeRunner, b ballot.Ballot, log logging.Logger) (*block.B
 		return nil, nil, err
 	}
 
+	if err = nr.Consensus().NewProposerRecord(*blk).Save(bs); err != nil {
+		bs.Discard()
+		log.Error("failed to save proposer record", "block", blk.Hash, "error", err)
+		return nil, nil, err
+	}
+
+	if err = saveBlockCertificate(bs, nr.NetworkParameters(), *blk, nr.Consensus().AcceptedBallots(b)); err != nil {
+		bs.Discard()
+		log.Error("failed to save block certificate", "block", blk.Hash, "error", err)
+		return nil, nil, err
+	}
+
 	if err = bs.Commit(); err != nil {
 		if err != errors.NotCommittable {
 			bs.Discard()
@@ -96,6 +111,11 @@ func finishBallotWithProposedTxs(st *storage.LevelDBBackend, b ballot.Ballot, pr
 	metrics.Consensus.SetTotalTxs(blk.TotalTxs)
 	metrics.Consensus.SetTotalOps(blk.TotalOps)
 
+	if err = FinishScheduledPayments(st, *blk, b.ProposerTransaction(), log); err != nil {
+		log.Error("failed to finish scheduled payments", "block", blk.Hash, "error", err)
+		return nil, err
+	}
+
 	if err = FinishTransactions(*blk, proposedTransactions, st); err != nil {
 		return nil, err
 	}
@@ -108,6 +128,25 @@ func finishBallotWithProposedTxs(st *storage.LevelDBBackend, b ballot.Ballot, pr
 	return blk, nil
 }
 
+// saveBlockCertificate saves the ACCEPT ballots, which confirmed the block, as
+// `block.BlockCertificate`. The block, which requires the certificate, can not
+// be saved without the ACCEPT ballots.
+func saveBlockCertificate(st *storage.LevelDBBackend, params operation.NetworkParameters, blk block.Block, ballots []ballot.Ballot) error {
+	if len(ballots) < 1 {
+		if params.IsCertificateRequired(blk.Height) {
+			return errors.BlockCertificateDoesNotExists
+		}
+		return nil
+	}
+
+	bc, err := ballot.NewBlockCertificate(blk, ballots)
+	if err != nil {
+		return err
+	}
+
+	return bc.Save(st)
+}
+
 func getProposedTransactions(st *storage.LevelDBBackend, pTxHashes []string, transactionPool *transaction.Pool) ([]*transaction.Transaction, error) {
 	proposedTransactions := make([]*transaction.Transaction, 0, len(pTxHashes))
 	var err error
@@ -131,20 +170,19 @@ func FinishTransactions(blk block.Block, transactions []*transaction.Transaction
 		if err = bt.Save(st); err != nil {
 			return
 		}
-		for _, op := range tx.B.Operations {
-			if err = finishOperation(st, tx.B.Source, op, log); err != nil {
-				log.Error("failed to finish operation", "block", blk.Hash, "BlockTransaction", bt.Hash, "operation", op, "error", err)
-				return err
-			}
+		if err = block.DeletePendingTransactionPool(st, tx.GetHash()); err != nil {
+			return
 		}
 
+		// The source is withdrawn before the operations, because
+		// `operation.AccountMerge` deletes the source account.
 		var baSource *block.BlockAccount
 		if baSource, err = block.GetBlockAccount(st, tx.B.Source); err != nil {
 			err = errors.BlockAccountDoesNotExists
 			return
 		}
 
-		if err = baSource.Withdraw(tx.TotalAmount(true)); err != nil {
+		if err = baSource.Withdraw(tx.SourceAmount()); err != nil {
 			return
 		}
 
@@ -153,6 +191,39 @@ func FinishTransactions(blk block.Block, transactions []*transaction.Transaction
 		if err = baSource.Save(st); err != nil {
 			return
 		}
+
+		if tx.HasFeePayer() {
+			var baPayer *block.BlockAccount
+			if baPayer, err = block.GetBlockAccount(st, tx.FeePayer()); err != nil {
+				err = errors.BlockAccountDoesNotExists
+				return
+			}
+			if err = baPayer.Withdraw(tx.B.Fee); err != nil {
+				return
+			}
+			if err = baPayer.Save(st); err != nil {
+				return
+			}
+		}
+
+		for i, op := range tx.B.Operations {
+			if err = finishOperation(st, tx.B.Source, op, log); err != nil {
+				log.Error("failed to finish operation", "block", blk.Hash, "BlockTransaction", bt.Hash, "operation", op, "error", err)
+				return err
+			}
+
+			if op.H.Type == operation.TypeCongressVoting {
+				if err = finishCongressVotingTally(st, tx.GetHash(), i); err != nil {
+					return
+				}
+			}
+		}
+
+		if tx.B.Memo != nil {
+			if err = finishScheduledPaymentsMemo(st, *tx); err != nil {
+				return
+			}
+		}
 	}
 
 	return
@@ -160,49 +231,27 @@ func FinishTransactions(blk block.Block, transactions []*transaction.Transaction
 
 // finishOperation do finish the task after consensus by the type of each operation.
 func finishOperation(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
-	switch op.H.Type {
-	case operation.TypeCreateAccount:
-		pop, ok := op.B.(operation.CreateAccount)
-		if !ok {
-			return errors.UnknownOperationType
-		}
-		return finishCreateAccount(st, source, pop, log)
-	case operation.TypePayment:
-		pop, ok := op.B.(operation.Payment)
-		if !ok {
-			return errors.UnknownOperationType
-		}
-		return finishPayment(st, source, pop, log)
-	case operation.TypeCongressVoting, operation.TypeCongressVotingResult:
-		//Nothing to do
-		return
-	case operation.TypeUnfreezingRequest:
-		pop, ok := op.B.(operation.UnfreezeRequest)
-		if !ok {
-			return errors.UnknownOperationType
-		}
-		return finishUnfreezeRequest(st, source, pop, log)
-	case operation.TypeInflationPF:
-		pop, ok := op.B.(operation.InflationPF)
-		if !ok {
-			return errors.UnknownOperationType
-		}
-		return finishInflationPF(st, source, pop, log)
-
-	default:
-		err = errors.UnknownOperationType
-		return
+	handler, found := getOperationHandler(op.H.Type)
+	if !found || handler.Finish == nil {
+		return errors.UnknownOperationType
 	}
+
+	return handler.Finish(st, source, op, log)
 }
 
-func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation.CreateAccount, log logging.Logger) (err error) {
+func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.CreateAccount)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
 	if _, err = block.GetBlockAccount(st, source); err != nil {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
 	var baTarget *block.BlockAccount
-	if baTarget, err = block.GetBlockAccount(st, op.TargetAddress()); err == nil {
+	if baTarget, err = block.GetBlockAccount(st, opb.TargetAddress()); err == nil {
 		err = errors.BlockAccountAlreadyExists
 		return
 	} else {
@@ -210,9 +259,9 @@ func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation
 	}
 
 	baTarget = block.NewBlockAccountLinked(
-		op.TargetAddress(),
-		op.GetAmount(),
-		op.Linked,
+		opb.TargetAddress(),
+		opb.GetAmount(),
+		opb.Linked,
 	)
 	if err = baTarget.Save(st); err != nil {
 		return
@@ -221,19 +270,46 @@ func finishCreateAccount(st *storage.LevelDBBackend, source string, op operation
 	return
 }
 
-func finishPayment(st *storage.LevelDBBackend, source string, op operation.Payment, log logging.Logger) (err error) {
+func finishCreateVestingAccount(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.CreateVestingAccount)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	if exists, err := block.ExistsBlockAccount(st, opb.Target); err != nil {
+		return err
+	} else if exists {
+		return errors.BlockAccountAlreadyExists
+	}
+
+	vesting := opb.Vesting()
+	baTarget := block.NewBlockAccountLinked(opb.Target, opb.Amount, opb.Linked)
+	baTarget.Vesting = &vesting
+	if err = baTarget.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+func finishPayment(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.Payment)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
 	if _, err = block.GetBlockAccount(st, source); err != nil {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
 	var baTarget *block.BlockAccount
-	if baTarget, err = block.GetBlockAccount(st, op.TargetAddress()); err != nil {
+	if baTarget, err = block.GetBlockAccount(st, opb.TargetAddress()); err != nil {
 		err = errors.BlockAccountDoesNotExists
 		return
 	}
 
-	if err = baTarget.Deposit(op.GetAmount()); err != nil {
+	if err = baTarget.Deposit(opb.GetAmount()); err != nil {
 		return
 	}
 	if err = baTarget.Save(st); err != nil {
@@ -243,11 +319,18 @@ func finishPayment(st *storage.LevelDBBackend, source string, op operation.Payme
 	return
 }
 
-func finishUnfreezeRequest(st *storage.LevelDBBackend, source string, opb operation.UnfreezeRequest, log logging.Logger) (err error) {
+func finishUnfreezeRequest(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	if _, ok := op.B.(operation.UnfreezeRequest); !ok {
+		return errors.UnknownOperationType
+	}
 	return
 }
 
-func finishInflationPF(st *storage.LevelDBBackend, source string, opb operation.InflationPF, log logging.Logger) (err error) {
+func finishInflationPF(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.InflationPF)
+	if !ok {
+		return errors.UnknownOperationType
+	}
 
 	if opb.Amount < 1 {
 		return
@@ -269,6 +352,418 @@ func finishInflationPF(st *storage.LevelDBBackend, source string, opb operation.
 	return
 }
 
+func finishManageSigners(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.ManageSigners)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	baSource.SetSigners(opb.Signers, opb.Thresholds)
+	if err = baSource.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+func finishSetOptions(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.SetOptions)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	baSource.SetOptions(opb.Flags, opb.AllowList)
+	if err = baSource.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+func finishManageData(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.ManageData)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	var exists bool
+	if exists, err = block.ExistsBlockAccountData(st, source, opb.Key); err != nil {
+		return
+	}
+
+	data := block.NewBlockAccountData(source, opb.Key, opb.Value)
+	if opb.IsDelete() {
+		if !exists {
+			return errors.DataEntryDoesNotExists
+		}
+		if err = data.Remove(st); err != nil {
+			return
+		}
+		baSource.DataEntries--
+	} else {
+		if err = data.Save(st); err != nil {
+			return
+		}
+		if !exists {
+			baSource.DataEntries++
+		}
+	}
+
+	if err = baSource.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+func finishAccountMerge(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.AccountMerge)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var baSource *block.BlockAccount
+	if baSource, err = block.GetBlockAccount(st, source); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	var baTarget *block.BlockAccount
+	if baTarget, err = block.GetBlockAccount(st, opb.TargetAddress()); err != nil {
+		err = errors.BlockAccountDoesNotExists
+		return
+	}
+
+	if err = baTarget.Deposit(baSource.GetBalance()); err != nil {
+		return
+	}
+	if err = baTarget.Save(st); err != nil {
+		return
+	}
+
+	if err = baSource.Remove(st); err != nil {
+		return
+	}
+
+	return
+}
+
+// finishAssetOperation applies `operation.Trust`, `operation.IssueAsset` and
+// `operation.AssetPayment` to the trustlines and the issued assets.
+func finishAssetOperation(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	state := newAssetState(st, nil)
+	if err = state.apply(source, op); err != nil {
+		return
+	}
+
+	return state.save()
+}
+
+func finishHashLockCreate(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.HashLockCreate)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	// the amount is already withdrawn from the source with the fee
+	var exists bool
+	if exists, err = block.ExistsBlockHashLock(st, source, opb.Target, opb.HashLock); err != nil {
+		return
+	} else if exists {
+		return errors.HashLockAlreadyExists
+	}
+
+	lock := block.NewBlockHashLock(source, opb.Target, opb.Amount, opb.HashLock, opb.Expiry)
+	if err = lock.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+func finishHashLockClaim(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.HashLockClaim)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var lock *block.BlockHashLock
+	if lock, err = block.GetBlockHashLock(st, opb.Locker, source, opb.HashLock()); err != nil {
+		return errors.HashLockDoesNotExists
+	}
+	if !lock.IsLocked() {
+		return errors.HashLockNotLocked
+	}
+
+	lock.State = block.HashLockClaimed
+	lock.Preimage = opb.Preimage
+
+	return releaseHashLock(st, lock, source)
+}
+
+func finishHashLockRefund(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.HashLockRefund)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var lock *block.BlockHashLock
+	if lock, err = block.GetBlockHashLock(st, source, opb.Target, opb.HashLock); err != nil {
+		return errors.HashLockDoesNotExists
+	}
+	if !lock.IsLocked() {
+		return errors.HashLockNotLocked
+	}
+
+	lock.State = block.HashLockRefunded
+
+	return releaseHashLock(st, lock, source)
+}
+
+// releaseHashLock deposits the locked amount to the account and saves the
+// lock with it's new state.
+func releaseHashLock(st *storage.LevelDBBackend, lock *block.BlockHashLock, address string) (err error) {
+	var ba *block.BlockAccount
+	if ba, err = block.GetBlockAccount(st, address); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+
+	if err = ba.Deposit(lock.Amount); err != nil {
+		return
+	}
+	if err = ba.Save(st); err != nil {
+		return
+	}
+
+	return lock.Save(st)
+}
+
+func finishScheduledPaymentCreate(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.ScheduledPaymentCreate)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	// the amount of all the payments is already withdrawn from the source
+	// with the fee
+	var exists bool
+	if exists, err = block.ExistsBlockScheduledPayment(st, source, opb.ID); err != nil {
+		return
+	} else if exists {
+		return errors.ScheduledPaymentAlreadyExists
+	}
+
+	payment := block.NewBlockScheduledPayment(source, opb.ID, opb.Target, opb.Amount, opb.Start, opb.Interval, opb.Count)
+	if err = payment.Save(st); err != nil {
+		return
+	}
+
+	return
+}
+
+// finishScheduledPaymentsMemo marks the scheduled payments created by the
+// transaction, which has the memo. The `Finish` of operation does not know the
+// transaction, so it is done after the operations are finished.
+func finishScheduledPaymentsMemo(st *storage.LevelDBBackend, tx transaction.Transaction) (err error) {
+	for _, op := range tx.B.Operations {
+		opb, ok := op.B.(operation.ScheduledPaymentCreate)
+		if !ok {
+			continue
+		}
+
+		var payment *block.BlockScheduledPayment
+		if payment, err = block.GetBlockScheduledPayment(st, tx.B.Source, opb.ID); err != nil {
+			return errors.ScheduledPaymentDoesNotExists
+		}
+		if err = payment.RemoveDue(st); err != nil {
+			return
+		}
+		payment.HasMemo = true
+		if err = payment.Save(st); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func finishScheduledPaymentCancel(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.ScheduledPaymentCancel)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var payment *block.BlockScheduledPayment
+	if payment, err = block.GetBlockScheduledPayment(st, source, opb.ID); err != nil {
+		return errors.ScheduledPaymentDoesNotExists
+	}
+	if !payment.IsActive() {
+		return errors.ScheduledPaymentNotActive
+	}
+
+	return cancelScheduledPayment(st, payment)
+}
+
+// cancelScheduledPayment refunds the reserved amount of the remaining
+// payments to the source account and saves the payment as cancelled.
+func cancelScheduledPayment(st *storage.LevelDBBackend, payment *block.BlockScheduledPayment) (err error) {
+	var ba *block.BlockAccount
+	if ba, err = block.GetBlockAccount(st, payment.Source); err != nil {
+		return errors.BlockAccountDoesNotExists
+	}
+
+	if err = ba.Deposit(payment.Reserved()); err != nil {
+		return
+	}
+	if err = ba.Save(st); err != nil {
+		return
+	}
+
+	if err = payment.RemoveDue(st); err != nil {
+		return
+	}
+	payment.State = block.ScheduledPaymentCancelled
+
+	return payment.Save(st)
+}
+
+// NewScheduledPaymentExecutes returns the scheduled payments, which are
+// due at the block of given height, upto `common.MaxScheduledPaymentsInBlock`.
+// The proposer includes them in the proposer transaction and the other nodes
+// check the proposer transaction has the same payments. If the target
+// account was merged or frozen, or it's flags do not accept the payment any
+// more, the payment is cancelled and the remaining amount is refunded to the
+// source account.
+func NewScheduledPaymentExecutes(st *storage.LevelDBBackend, height uint64) (opbs []operation.ScheduledPaymentExecute, err error) {
+	var payments []*block.BlockScheduledPayment
+	if payments, err = block.GetBlockScheduledPaymentsDue(st, height); err != nil {
+		return
+	}
+
+	for _, payment := range payments {
+		if len(opbs) >= common.MaxScheduledPaymentsInBlock {
+			break
+		}
+
+		var target *block.BlockAccount
+		if target, err = block.GetBlockAccount(st, payment.Target); err != nil || !isScheduledPaymentAccepted(target, payment) {
+			err = nil
+			opbs = append(opbs, operation.NewScheduledPaymentExecute(payment.Source, payment.ID, payment.Target, payment.Reserved(), true))
+			continue
+		}
+
+		opbs = append(opbs, operation.NewScheduledPaymentExecute(payment.Source, payment.ID, payment.Target, payment.Amount, false))
+	}
+
+	return
+}
+
+// isScheduledPaymentAccepted checks the target still accepts the payment. The
+// flags of target can be changed after the payment is created, so they are
+// checked again like `validateIncomingFlags`.
+func isScheduledPaymentAccepted(target *block.BlockAccount, payment *block.BlockScheduledPayment) bool {
+	if target.IsFrozen() && !target.IsVesting() {
+		return false
+	}
+	if target.Flags.Has(common.AccountFlagRequireMemo) && !payment.HasMemo {
+		return false
+	}
+	if target.Flags.Has(common.AccountFlagBlockIncoming) && !target.IsAllowed(payment.Source) {
+		return false
+	}
+
+	return true
+}
+
+// isScheduledPaymentsMatched checks the scheduled payments of proposer
+// transaction are same with the expected ones in order.
+func isScheduledPaymentsMatched(expected, payments []operation.ScheduledPaymentExecute) bool {
+	if len(expected) != len(payments) {
+		return false
+	}
+	for i := range expected {
+		if expected[i] != payments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FinishScheduledPayments executes the scheduled payments of the proposer
+// transaction. It is called before the transactions of block are finished, so
+// the payments are executed on the state, which the proposer made them from.
+func FinishScheduledPayments(st *storage.LevelDBBackend, blk block.Block, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
+	var expected []operation.ScheduledPaymentExecute
+	if expected, err = NewScheduledPaymentExecutes(st, blk.Height); err != nil {
+		return
+	}
+	if !isScheduledPaymentsMatched(expected, ptx.ScheduledPayments()) {
+		return errors.InvalidProposerTransaction
+	}
+
+	for _, opb := range expected {
+		var payment *block.BlockScheduledPayment
+		if payment, err = block.GetBlockScheduledPayment(st, opb.Source, opb.ID); err != nil {
+			return
+		}
+
+		if opb.Cancelled {
+			log.Debug("scheduled payment cancelled", "source", payment.Source, "id", payment.ID, "target", payment.Target)
+			if err = cancelScheduledPayment(st, payment); err != nil {
+				return
+			}
+			continue
+		}
+
+		var target *block.BlockAccount
+		if target, err = block.GetBlockAccount(st, payment.Target); err != nil {
+			return
+		}
+		if err = target.Deposit(payment.Amount); err != nil {
+			return
+		}
+		if err = target.Save(st); err != nil {
+			return
+		}
+
+		if err = payment.RemoveDue(st); err != nil {
+			return
+		}
+		payment.Remaining--
+		if payment.Remaining < 1 {
+			payment.State = block.ScheduledPaymentFinished
+		} else {
+			payment.Next += payment.Interval
+		}
+		if err = payment.Save(st); err != nil {
+			return
+		}
+
+		log.Debug("scheduled payment executed", "source", payment.Source, "id", payment.ID, "target", payment.Target, "amount", payment.Amount)
+	}
+
+	return
+}
+
 func FinishProposerTransaction(st *storage.LevelDBBackend, blk block.Block, ptx ballot.ProposerTransaction, log logging.Logger) (err error) {
 	if err = ProcessProposerTransaction(st, blk, ptx, log); err != nil {
 		return err
@@ -350,3 +845,118 @@ func finishInflation(st *storage.LevelDBBackend, opb operation.Inflation, log lo
 
 	return
 }
+
+func finishCongressMembership(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.CongressMembership)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	member := block.NewBlockCongressMember(opb.Target)
+	if opb.Remove {
+		return member.Delete(st)
+	}
+
+	return member.Save(st)
+}
+
+// finishManageValidator applies the change of validators to the validator
+// set of the activation height and the later ones. The validator set never
+// becomes empty, even if the multiple removals are finished in one block.
+func finishManageValidator(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.ManageValidator)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var vs *block.BlockValidatorSet
+	if vs, err = block.GetBlockValidatorSet(st, opb.ActivationHeight); err != nil {
+		return
+	}
+	sets := []*block.BlockValidatorSet{block.NewBlockValidatorSet(opb.ActivationHeight, vs.Validators)}
+
+	var heights []uint64
+	if heights, err = block.GetBlockValidatorSetHeights(st); err != nil {
+		return
+	}
+	for _, height := range heights {
+		if height <= opb.ActivationHeight {
+			continue
+		}
+		if vs, err = block.GetBlockValidatorSet(st, height); err != nil {
+			return
+		}
+		sets = append(sets, vs)
+	}
+
+	for _, vs := range sets {
+		switch {
+		case !opb.Remove:
+			vs.Add(opb.Target)
+		case vs.Has(opb.Target) && len(vs.Validators) < 2:
+			log.Error("validator set can not be empty", "height", vs.Height, "validator", opb.Target)
+		default:
+			vs.Remove(opb.Target)
+		}
+
+		if err = vs.Save(st); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// finishCongressVotingTally creates the empty tally of the new voting; the
+// voting hash is `<tx hash>-<operation index>` of `operation.CongressVoting`.
+func finishCongressVotingTally(st *storage.LevelDBBackend, txHash string, opIndex int) error {
+	return block.NewBlockCongressVotingTally(fmt.Sprintf("%s-%d", txHash, opIndex)).Save(st)
+}
+
+// finishCongressVote saves the vote and counts it in the tally of voting.
+func finishCongressVote(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.CongressVote)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	if err = block.NewBlockCongressVote(opb.CongressVotingHash, source, opb.Vote).Save(st); err != nil {
+		return
+	}
+
+	var tally *block.BlockCongressVotingTally
+	if tally, err = block.GetBlockCongressVotingTally(st, opb.CongressVotingHash); err != nil {
+		return
+	}
+	tally.Add(opb.Vote)
+
+	return tally.Save(st)
+}
+
+// finishCongressVotingResult closes the tally of voting, no more result is
+// accepted for the voting. The voting without tally gets the closed tally of
+// the result.
+func finishCongressVotingResult(st *storage.LevelDBBackend, source string, op operation.Operation, log logging.Logger) (err error) {
+	opb, ok := op.B.(operation.CongressVotingResult)
+	if !ok {
+		return errors.UnknownOperationType
+	}
+
+	var exists bool
+	if exists, err = block.ExistsBlockCongressVotingTally(st, opb.CongressVotingHash); err != nil {
+		return
+	}
+
+	var tally *block.BlockCongressVotingTally
+	if tally, err = block.GetBlockCongressVotingTally(st, opb.CongressVotingHash); err != nil {
+		return
+	}
+	if !exists {
+		tally.Yes = opb.Result.Yes
+		tally.No = opb.Result.No
+		tally.ABS = opb.Result.ABS
+	}
+	tally.Closed = true
+
+	return tally.Save(st)
+}
@@ -145,6 +145,8 @@ func testFinishBallot(withBatch bool, numberOfTransactions, numberOfOperations i
 		blt.Sign(proposerNode.Keypair(), conf.NetworkID)
 	}
 
+	VoteAcceptBallot(nr, *blt, proposerNode, conf)
+
 	_, _, err := finishBallot(
 		nr,
 		*blt,
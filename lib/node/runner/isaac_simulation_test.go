@@ -11,7 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"boscoin.io/sebak/lib/ballot"
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -88,3 +91,190 @@ func TestISAACSimulationProposer(t *testing.T) {
 	require.Equal(t, 1, len(block.Transactions))
 	require.Equal(t, tx.GetHash(), block.Transactions[0])
 }
+
+/*
+TestISAACSimulationBlockCertificate indicates the following:
+	1. The node is the proposer of this round and there are 5 nodes.
+	2. The node receives the SIGN, ACCEPT messages of same proposal from the validators.
+	3. The block is confirmed with the certificate, which has the ACCEPT ballots.
+*/
+func TestISAACSimulationBlockCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, nodes, _ := createNodeRunnerForTesting(5, conf, nil)
+	tx, _ := GetTransaction()
+
+	proposer := nr.localNode
+
+	nr.TransactionPool.Add(tx)
+
+	round := uint64(0)
+	_, err := nr.proposeNewBallot(round)
+	require.NoError(t, err)
+
+	b := nr.Consensus().LatestBlock()
+	votingBasis := voting.Basis{
+		Round:     round,
+		Height:    b.Height,
+		BlockHash: b.Hash,
+		TotalTxs:  b.TotalTxs,
+	}
+
+	proposed := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, proposer, conf)
+	vote := func(state ballot.State, sender *node.LocalNode) {
+		nb := *proposed
+		nb.SetVote(state, voting.YES)
+		nb.Sign(sender.Keypair(), networkID)
+		require.NoError(t, ReceiveBallot(nr, &nb))
+	}
+
+	for _, n := range nodes[1:] {
+		vote(ballot.StateSIGN, n)
+	}
+	for _, n := range nodes[:4] {
+		vote(ballot.StateACCEPT, n)
+	}
+
+	blk := nr.Consensus().LatestBlock()
+	require.Equal(t, votingBasis.Height+1, blk.Height)
+
+	bc, err := block.GetBlockCertificate(nr.Storage(), blk.Hash)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(bc.Ballots))
+
+	var validators []string
+	for address := range nr.localNode.GetValidators() {
+		validators = append(validators, address)
+	}
+	err = ballot.VerifyBlockCertificate(bc, blk, networkID, validators, nr.policy.Threshold())
+	require.NoError(t, err)
+}
+
+/*
+TestISAACSimulationEquivocation indicates the following:
+	1. The node is the proposer of this round.
+	2. The node receives the SIGN ballot from the validator.
+	3. The same validator sends the other SIGN ballot with the different vote.
+	4. The conflicting ballot is rejected and the evidence is stored.
+*/
+func TestISAACSimulationEquivocation(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, nodes, _ := createNodeRunnerForTesting(5, conf, nil)
+	tx, _ := GetTransaction()
+
+	proposer := nr.localNode
+	nr.TransactionPool.Add(tx)
+
+	round := uint64(0)
+	_, err := nr.proposeNewBallot(round)
+	require.NoError(t, err)
+
+	b := nr.Consensus().LatestBlock()
+	votingBasis := voting.Basis{
+		Round:     round,
+		Height:    b.Height,
+		BlockHash: b.Hash,
+		TotalTxs:  b.TotalTxs,
+	}
+
+	ballotSIGN1 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[1], conf)
+	require.NoError(t, ReceiveBallot(nr, ballotSIGN1))
+
+	// same ballot is not conflicting
+	require.Equal(t, errors.BallotAlreadyVoted, ReceiveBallot(nr, ballotSIGN1))
+
+	conflicting := *ballotSIGN1
+	conflicting.SetVote(ballot.StateSIGN, voting.NO)
+	conflicting.Sign(nodes[1].Keypair(), networkID)
+	require.Equal(t, errors.BallotEquivocation, ReceiveBallot(nr, &conflicting))
+	require.Equal(t, errors.BallotEquivocation, ReceiveBallot(nr, &conflicting))
+
+	// the first vote is kept
+	rr := nr.Consensus().RunningRounds[votingBasis.Index()]
+	require.Equal(t, voting.YES, rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)[nodes[1].Address()])
+
+	var evidences []block.Evidence
+	iterFunc, closeFunc := block.GetEvidencesBySource(nr.Storage(), nodes[1].Address(), nil)
+	for {
+		e, hasNext, _ := iterFunc()
+		if !hasNext {
+			break
+		}
+		evidences = append(evidences, *e)
+	}
+	closeFunc()
+
+	require.Equal(t, 1, len(evidences))
+	require.Equal(t, votingBasis.Height, evidences[0].Height)
+	require.Equal(t, ballot.StateSIGN.String(), evidences[0].State)
+	require.NoError(t, ballot.VerifyEvidence(evidences[0], networkID))
+}
+
+/*
+TestISAACSimulationExpiredAfterVote indicates the following:
+	1. The node is the proposer of this round and already sent the SIGN ballot.
+	2. The validator sends the SIGN ballot with `voting.YES`.
+	3. The SIGN state is expired; the validators, including the one which
+	   already voted, send the SIGN ballot with `voting.EXP`.
+	4. The `voting.EXP` ballot after the vote is not the equivocation.
+	5. The node does not send the other SIGN ballot in the same round.
+*/
+func TestISAACSimulationExpiredAfterVote(t *testing.T) {
+	conf := common.NewTestConfig()
+	nr, nodes, cm := createNodeRunnerForTesting(5, conf, nil)
+	tx, _ := GetTransaction()
+
+	proposer := nr.localNode
+	nr.TransactionPool.Add(tx)
+
+	round := uint64(0)
+	_, err := nr.proposeNewBallot(round)
+	require.NoError(t, err)
+
+	b := nr.Consensus().LatestBlock()
+	votingBasis := voting.Basis{
+		Round:     round,
+		Height:    b.Height,
+		BlockHash: b.Hash,
+		TotalTxs:  b.TotalTxs,
+	}
+
+	nr.BroadcastBallot(*GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, proposer, conf))
+
+	ballotSIGN1 := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, nodes[1], conf)
+	require.NoError(t, ReceiveBallot(nr, ballotSIGN1))
+
+	expired := func(n *node.LocalNode) *ballot.Ballot {
+		b := GenerateBallot(proposer, votingBasis, tx, ballot.StateSIGN, n, conf)
+		b.SetVote(ballot.StateSIGN, voting.EXP)
+		b.Sign(n.Keypair(), networkID)
+		return b
+	}
+
+	require.Equal(t, errors.BallotAlreadyVoted, ReceiveBallot(nr, expired(nodes[1])))
+
+	// the first vote is kept
+	rr := nr.Consensus().RunningRounds[votingBasis.Index()]
+	require.Equal(t, voting.YES, rr.Voted[proposer.Address()].GetResult(ballot.StateSIGN)[nodes[1].Address()])
+
+	// the YES can not be over threshold; SIGN is expired and the node moves
+	// to the next round
+	for _, n := range nodes[2:4] {
+		require.NoError(t, ReceiveBallot(nr, expired(n)))
+	}
+	require.False(t, nr.Consensus().HasRunningRound(votingBasis.Index()))
+
+	// no evidence
+	iterFunc, closeFunc := block.GetEvidencesBySource(nr.Storage(), nodes[1].Address(), nil)
+	_, hasNext, _ := iterFunc()
+	closeFunc()
+	require.False(t, hasNext)
+
+	// only the first SIGN ballot is sent
+	var sent []voting.Hole
+	for _, m := range cm.Messages() {
+		if b, ok := m.(ballot.Ballot); ok && b.Source() == proposer.Address() && b.State() == ballot.StateSIGN {
+			sent = append(sent, b.Vote())
+		}
+	}
+	require.Equal(t, []voting.Hole{voting.YES}, sent)
+}
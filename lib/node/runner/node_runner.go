@@ -22,18 +22,21 @@ import (
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/metrics"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/network/httpcache"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/node/runner/api"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
 var DefaultHandleBaseBallotCheckerFuncs = []common.CheckerFunc{
 	BallotUnmarshal,
 	BallotNotFromKnownValidators,
+	BallotCheckEquivocation,
 	BallotCheckSYNC,
 	BallotCheckBasis,
 }
@@ -44,6 +47,7 @@ var DefaultHandleINITBallotCheckerFuncs = []common.CheckerFunc{
 	BallotIsSameProposer,
 	BallotValidateOperationBodyCollectTxFee,
 	BallotValidateOperationBodyInflation,
+	BallotValidateOperationBodyScheduledPayments,
 	BallotGetMissingTransaction,
 	INITBallotValidateTransactions,
 	SIGNBallotBroadcast,
@@ -132,6 +136,11 @@ func NewNodeRunner(
 	nr.policy.SetValidators(len(nr.localNode.GetValidators()))
 
 	nr.connectionManager = c.ConnectionManager()
+	if err = nr.initValidatorSet(); err != nil {
+		nr.log.Error("failed to initialize the validator set", "error", err)
+		return
+	}
+
 	nr.savingBlockOperations = NewSavingBlockOperations(
 		nr.Storage(),
 		nr.Log(),
@@ -157,6 +166,11 @@ func NewNodeRunner(
 		nr.log.Debug("common account found", "address", nr.Conf.CommonAccountAddress)
 	}
 
+	if err = RestoreTransactionPool(nr.storage, nr.Conf, nr.NetworkParameters(), nr.TransactionPool, nr.log); err != nil {
+		nr.log.Error("failed to restore TransactionPool", "error", err)
+		return
+	}
+
 	nr.nodeInfo = NewNodeInfo(nr)
 	if conf.JSONRPCEndpoint != nil {
 		nr.jsonrpcServer = newJSONRPCServer(conf.JSONRPCEndpoint, nr.storage)
@@ -287,6 +301,8 @@ func (nr *NodeRunner) Ready() {
 		nr.nodeInfo,
 	)
 	apiHandler.GetLatestBlock = nr.Consensus().LatestBlock
+	apiHandler.GetTransactionVersions = nr.NetworkParameters().TransactionVersions
+	apiHandler.TransactionPool = nr.TransactionPool
 
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetAccountHandlerPattern),
@@ -316,6 +332,22 @@ func (nr *NodeRunner) Ready() {
 		apiHandler.HandlerURLPattern(api.GetAccountFrozenAccountHandlerPattern),
 		apiHandler.GetFrozenAccountsByAccountHandler,
 	).Methods("GET")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountDataHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetAccountDataHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountScheduledPaymentsPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetAccountScheduledPaymentsHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAccountTrustlinesHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetAccountTrustlinesHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetPendingTransactionsHandlerPattern),
+		apiHandler.GetPendingTransactionsHandler,
+	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.GetTransactionByHashHandlerPattern),
 		cache.WrapHandlerFunc(apiHandler.GetTransactionByHashHandler),
@@ -328,6 +360,34 @@ func (nr *NodeRunner) Ready() {
 		apiHandler.HandlerURLPattern(api.GetTransactionStatusHandlerPattern),
 		listCache.WrapHandlerFunc(apiHandler.GetTransactionStatusByHashHandler),
 	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetFeeStatsHandlerPattern),
+		cache.WrapHandlerFunc(apiHandler.GetFeeStatsHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetCongressMembersHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetCongressMembersHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetCongressVotingsHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetCongressVotingsHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetCongressVotesHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetCongressVotesHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetCongressResultsHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetCongressResultsHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetAssetsHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetAssetsHandler),
+	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetEvidencesHandlerPattern),
+		listCache.WrapHandlerFunc(apiHandler.GetEvidencesHandler),
+	).Methods("GET", "OPTIONS")
 	nr.network.AddHandler(
 		apiHandler.HandlerURLPattern(api.PostSubscribePattern),
 		listCache.WrapHandlerFunc(apiHandler.PostSubscribeHandler),
@@ -366,6 +426,10 @@ func (nr *NodeRunner) Ready() {
 		apiHandler.HandlerURLPattern(api.GetBlockHandlerPattern),
 		cache.WrapHandlerFunc(apiHandler.GetBlockHandler),
 	).Methods("GET", "OPTIONS")
+	nr.network.AddHandler(
+		apiHandler.HandlerURLPattern(api.GetBlockCertificateHandlerPattern),
+		cache.WrapHandlerFunc(apiHandler.GetBlockCertificateHandler),
+	).Methods("GET", "OPTIONS")
 
 	// pprof
 	if DebugPProf == true {
@@ -431,6 +495,12 @@ func (nr *NodeRunner) Consensus() *consensus.ISAAC {
 	return nr.consensus
 }
 
+// NetworkParameters returns the `operation.NetworkParameters`, which is
+// loaded by the consensus.
+func (nr *NodeRunner) NetworkParameters() operation.NetworkParameters {
+	return nr.consensus.NetworkParameters()
+}
+
 func (nr *NodeRunner) ConnectionManager() network.ConnectionManager {
 	return nr.connectionManager
 }
@@ -617,41 +687,141 @@ func (nr *NodeRunner) TransitISAACState(basis voting.Basis, ballotState ballot.S
 }
 
 func (nr *NodeRunner) NextHeight() {
+	nr.switchValidators()
 	nr.isaacStateManager.NextHeight()
 }
 
+// initValidatorSet saves `operation.NetworkParameters.Validators` of genesis
+// block as the validator set of genesis, if it is not saved yet, and then the
+// validators are switched to the current validator set. If the genesis block
+// does not have the validators, the validators of node are saved instead, so
+// they can be changed by `ManageValidator`; every validator has the same
+// validators.
+func (nr *NodeRunner) initValidatorSet() (err error) {
+	var exists bool
+	if exists, err = block.ExistsBlockValidatorSet(nr.storage, common.GenesisBlockHeight); err != nil {
+		return
+	} else if !exists {
+		validators := nr.NetworkParameters().Validators
+		if len(validators) < 1 {
+			for address := range nr.localNode.GetValidators() {
+				validators = append(validators, address)
+			}
+		}
+		if len(validators) < 1 {
+			return
+		}
+		if err = block.NewBlockValidatorSet(common.GenesisBlockHeight, validators).Save(nr.storage); err != nil {
+			return
+		}
+	}
+
+	nr.switchValidators()
+
+	return
+}
+
+// switchValidators switches the validators to the validator set of the next
+// block; `LocalNode`, `ConnectionManager` and the threshold policy use the new
+// validators from the activation height.
+func (nr *NodeRunner) switchValidators() {
+	height := block.GetLatestBlock(nr.storage).Height + 1
+
+	vs, err := block.GetBlockValidatorSet(nr.storage, height)
+	if err == errors.BlockValidatorSetDoesNotExists {
+		return
+	} else if err != nil {
+		nr.log.Error("failed to get the validator set", "height", height, "error", err)
+		return
+	}
+
+	if !nr.connectionManager.SetValidators(vs.Validators...) {
+		return
+	}
+	nr.policy.SetValidators(len(vs.Validators))
+
+	nr.log.Info("validators switched", "height", height, "validators", vs.Validators)
+}
+
 func (nr *NodeRunner) RemoveSendRecordsLowerThanOrEqualHeight(height uint64) {
 	nr.ballotSendRecord.RemoveLowerThanOrEqualHeight(height)
 }
 
-var NewBallotTransactionCheckerFuncs = []common.CheckerFunc{
-	IsNew,
-	BallotTransactionsSameSource,
+// removeStaleTransactions removes the transactions of sources from `Pool`,
+// which can not be included in block any more by the sequence id of source.
+func (nr *NodeRunner) removeStaleTransactions(sources ...string) {
+	var removed []string
+	for _, source := range sources {
+		ba, err := block.GetBlockAccount(nr.storage, source)
+		if err != nil { // merged account
+			removed = append(removed, nr.TransactionPool.RemoveFromSources(source)...)
+			continue
+		}
+		removed = append(removed, nr.TransactionPool.RemoveStaleFromSource(source, ba.SequenceID)...)
+	}
+
+	nr.deleteRemovedTransactions(removed...)
 }
 
-func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
-	b := nr.consensus.LatestBlock()
-	basis := voting.Basis{
-		Round:     round,
-		Height:    b.Height,
-		BlockHash: b.Hash,
-		TotalTxs:  b.TotalTxs,
-		TotalOps:  b.TotalOps,
+// deleteRemovedTransactions removes the transactions, which are removed from
+// `Pool`, from storage; see `deleteRemovedTransactions`.
+func (nr *NodeRunner) deleteRemovedTransactions(hashes ...string) {
+	if err := deleteRemovedTransactions(nr.storage, hashes...); err != nil {
+		nr.log.Error("failed to delete removed transactions", "transactions", len(hashes), "error", err)
 	}
+}
 
-	// collect incoming transactions from `Pool`
-	availableTransactions := nr.TransactionPool.AvailableTransactions(nr.Conf.TxsLimit)
-	nr.log.Debug("new round proposed", "block-basis", basis)
+// evictTransactions removes the transactions from `Pool`, which stay longer
+// than `TxPoolTTL` or become invalid by the state of the latest block. Only
+// the transactions of sources, which are changed by the latest block, are
+// validated again; the transaction, which becomes invalid by the other
+// accounts, like the payment to the merged account, is left and removed when
+// it is checked for the next proposal. The evicted transactions are not
+// restored after restart.
+//
+// It must be called in the consensus after the block is committed, not
+// concurrently with proposal, which reads `Pool`.
+func (nr *NodeRunner) evictTransactions(t time.Time, sources ...string) {
+	outdated := nr.TransactionPool.RemoveOutdated(t)
+
+	var invalid []string
+	accounts := NewRunningAccounts(nr.storage, nr.Conf, nr.NetworkParameters())
+	validated := map[string]struct{}{}
+	for _, source := range sources {
+		if _, found := validated[source]; found {
+			continue
+		}
+		validated[source] = struct{}{}
 
+		for _, tx := range nr.TransactionPool.GetAllFromSource(source) {
+			if err := accounts.Validate(tx); err != nil {
+				invalid = append(invalid, tx.GetHash())
+			}
+		}
+	}
+	nr.TransactionPool.Remove(invalid...)
+	metrics.TxPool.AddEvicted(metrics.TxPoolEvictInvalid, len(invalid))
+
+	nr.deleteRemovedTransactions(append(outdated, invalid...)...)
+
+	if len(outdated) > 0 || len(invalid) > 0 {
+		nr.log.Debug("transactions evicted from pool", "outdated", len(outdated), "invalid", len(invalid))
+	}
+}
+
+// checkProposableTransactions validates the transactions together for the
+// new ballot; the valid ones are left in `ValidTransactions` of the returned
+// checker.
+func (nr *NodeRunner) checkProposableTransactions(hashes []string, transactionCache *TransactionCache) *BallotTransactionChecker {
 	transactionsChecker := &BallotTransactionChecker{
 		DefaultChecker:        common.DefaultChecker{Funcs: NewBallotTransactionCheckerFuncs},
 		NodeRunner:            nr,
 		Conf:                  nr.Conf,
 		LocalNode:             nr.localNode,
-		Transactions:          availableTransactions,
+		Transactions:          hashes,
 		CheckTransactionsOnly: true,
 		VotingHole:            voting.NOTYET,
-		transactionCache:      NewTransactionCache(nr.Storage(), nr.TransactionPool),
+		transactionCache:      transactionCache,
 	}
 
 	if err := common.RunChecker(transactionsChecker, common.DefaultDeferFunc); err != nil {
@@ -660,36 +830,49 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 		}
 	}
 
-	// remove invalid transactions
-	if len(transactionsChecker.invalidTransactions()) > 0 {
-		nr.TransactionPool.Remove(transactionsChecker.invalidTransactions()...)
-		nr.log.Debug(
-			"invalid transactions removed from pool",
-			"basis", basis,
-			"invalid-transactions", len(transactionsChecker.invalidTransactions()),
-			"transactionpool", nr.TransactionPool.Len(),
-		)
-	}
+	return transactionsChecker
+}
 
-	var validTransactions []transaction.Transaction
-	var validTransactionHashes []string
+// selectProposableTransactions selects the valid transactions, which can be
+// included in the new ballot by `OpsInBallotLimit` and their time bounds.
+func (nr *NodeRunner) selectProposableTransactions(
+	hashes []string,
+	transactionCache *TransactionCache,
+	timeBoundsHeight uint64,
+	timeBoundsTime time.Time,
+) (selected []string, txs []transaction.Transaction, err error) {
 	var ops int
-	for _, hash := range transactionsChecker.ValidTransactions {
+	// once a transaction is skipped, the next transactions of same source
+	// also must be skipped to keep the sequence ids consecutive.
+	skippedSources := map[string]bool{}
+	for _, hash := range hashes {
 		var tx transaction.Transaction
 		var found bool
-		var err error
-		if tx, found, err = transactionsChecker.transactionCache.Get(hash); err != nil {
-			return ballot.Ballot{}, err
+		if tx, found, err = transactionCache.Get(hash); err != nil {
+			return
 		} else if !found {
-			return ballot.Ballot{}, errors.TransactionNotFound
+			err = errors.TransactionNotFound
+			return
+		}
+
+		if skippedSources[tx.B.Source] {
+			continue
 		}
 
 		if ops+len(tx.B.Operations) > nr.Conf.OpsInBallotLimit {
+			skippedSources[tx.B.Source] = true
+			continue
+		}
+
+		// the transaction, which is not yet valid, is not proposed, but it
+		// stays in the pool until it becomes valid or expired.
+		if tx.CheckTimeBounds(timeBoundsHeight, timeBoundsTime) != nil {
+			skippedSources[tx.B.Source] = true
 			continue
 		}
 
-		validTransactionHashes = append(validTransactionHashes, hash)
-		validTransactions = append(validTransactions, tx)
+		selected = append(selected, hash)
+		txs = append(txs, tx)
 
 		ops += len(tx.B.Operations)
 		if ops == nr.Conf.OpsInBallotLimit {
@@ -697,6 +880,76 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 		}
 	}
 
+	return
+}
+
+var NewBallotTransactionCheckerFuncs = []common.CheckerFunc{
+	IsNew,
+	BallotTransactionsHashLocks,
+	BallotTransactionsCongressVotes,
+	BallotTransactionsScheduledPayments,
+	BallotTransactionsAssets,
+	BallotTransactionsSameSource,
+	BallotTransactionsAccountMerge,
+}
+
+func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
+	b := nr.consensus.LatestBlock()
+	basis := voting.Basis{
+		Round:     round,
+		Height:    b.Height,
+		BlockHash: b.Hash,
+		TotalTxs:  b.TotalTxs,
+		TotalOps:  b.TotalOps,
+	}
+
+	// collect incoming transactions from `Pool`
+	availableTransactions := nr.TransactionPool.AvailableTransactions(nr.Conf.TxsLimit)
+	nr.log.Debug("new round proposed", "block-basis", basis)
+
+	transactionCache := NewTransactionCache(nr.Storage(), nr.TransactionPool)
+	transactionsChecker := nr.checkProposableTransactions(availableTransactions, transactionCache)
+
+	// remove invalid transactions
+	if invalid := transactionsChecker.invalidTransactions(); len(invalid) > 0 {
+		nr.TransactionPool.Remove(invalid...)
+		nr.deleteRemovedTransactions(invalid...)
+		nr.log.Debug(
+			"invalid transactions removed from pool",
+			"basis", basis,
+			"invalid-transactions", len(invalid),
+			"transactionpool", nr.TransactionPool.Len(),
+		)
+	}
+
+	timeBoundsHeight, timeBoundsTime, err := getTimeBoundsBasis(nr.Storage())
+	if err != nil {
+		return ballot.Ballot{}, err
+	}
+
+	var validTransactions []transaction.Transaction
+	var validTransactionHashes []string
+	candidates := transactionsChecker.ValidTransactions
+	for {
+		validTransactionHashes, validTransactions, err = nr.selectProposableTransactions(
+			candidates,
+			transactionCache,
+			timeBoundsHeight,
+			timeBoundsTime,
+		)
+		if err != nil {
+			return ballot.Ballot{}, err
+		}
+		if len(validTransactionHashes) == len(candidates) {
+			break
+		}
+
+		// the selected transactions were validated together with the skipped
+		// ones, so they are validated again without them; the transactions,
+		// which become invalid, are not removed from pool.
+		candidates = nr.checkProposableTransactions(validTransactionHashes, transactionCache).ValidTransactions
+	}
+
 	proposerAddr := nr.consensus.SelectProposer(b.Height, round)
 	blt := ballot.NewBallot(nr.localNode.Address(), proposerAddr, basis, validTransactionHashes)
 	blt.SetVote(ballot.StateINIT, voting.YES)
@@ -711,7 +964,12 @@ func (nr *NodeRunner) proposeNewBallot(round uint64) (ballot.Ballot, error) {
 		return ballot.Ballot{}, err
 	}
 
-	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi)
+	payments, err := NewScheduledPaymentExecutes(nr.storage, b.Height+1)
+	if err != nil {
+		return ballot.Ballot{}, err
+	}
+
+	ptx, err := ballot.NewProposerTransactionFromBallot(*blt, opc, opi, payments...)
 	if err != nil {
 		return ballot.Ballot{}, err
 	}
@@ -16,7 +16,9 @@ import (
 	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 )
 
@@ -205,6 +207,57 @@ func TestCreateNodeRunner(t *testing.T) {
 	require.Equal(t, 3, len(nodeRunners))
 }
 
+// The validator set of genesis is derived from the genesis block, not from the
+// validators of node.
+func TestInitValidatorSet(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	makeNodeRunner := func(params operation.NetworkParameters) *NodeRunner {
+		_, n, localNode := network.CreateMemoryNetwork(nil)
+		localNode.AddValidators(localNode.ConvertToValidator())
+
+		st := storage.NewTestStorage()
+		genesisAccount := block.NewBlockAccount(block.GenesisKP.Address(), conf.InitialBalance)
+		genesisAccount.MustSave(st)
+		commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
+		commonAccount.MustSave(st)
+		_, err := block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, params, conf.NetworkID)
+		require.NoError(t, err)
+
+		policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+		connectionManager := network.NewValidatorConnectionManager(localNode, n, policy, conf)
+		is, _ := consensus.NewISAAC(localNode, policy, connectionManager, st, conf, nil)
+		nr, err := NewNodeRunner(localNode, policy, n, is, st, transaction.NewPool(conf), conf)
+		require.NoError(t, err)
+
+		return nr
+	}
+
+	{ // the genesis block does not have the validators; the validators of
+		// node are saved
+		nr := makeNodeRunner(operation.NewNetworkParameters())
+		defer nr.Storage().Close()
+
+		vs, err := block.GetBlockValidatorSet(nr.Storage(), common.GenesisBlockHeight)
+		require.NoError(t, err)
+		require.Equal(t, block.NewBlockValidatorSet(common.GenesisBlockHeight, []string{nr.Node().Address()}), vs)
+		require.Equal(t, []string{nr.Node().Address()}, nr.ConnectionManager().AllValidators())
+	}
+
+	{ // the validators of genesis block
+		params := operation.NewNetworkParameters()
+		params.Validators = []string{keypair.Random().Address(), keypair.Random().Address()}
+
+		nr := makeNodeRunner(params)
+		defer nr.Storage().Close()
+
+		vs, err := block.GetBlockValidatorSet(nr.Storage(), common.GenesisBlockHeight)
+		require.NoError(t, err)
+		require.Equal(t, block.NewBlockValidatorSet(common.GenesisBlockHeight, params.Validators), vs)
+		require.ElementsMatch(t, vs.Validators, nr.ConnectionManager().AllValidators())
+	}
+}
+
 /*
 func TestNodeRunnerSaveBlock(t *testing.T) {
 	numberOfNodes := 4
@@ -309,6 +362,85 @@ func TestExpiredBallotCheckProposer(t *testing.T) {
 	require.Equal(t, voting.NO, checker.VotingHole)
 }
 
+// makeProposableTransaction makes the transaction with `n` payments and saves
+// it's source and targets, so it can be included in the proposed ballot.
+func makeProposableTransaction(st *storage.LevelDBBackend, n int) (*keypair.Full, transaction.Transaction) {
+	kp, tx := transaction.TestMakeTransaction(networkID, n)
+	for _, op := range tx.B.Operations {
+		block.NewBlockAccount(op.B.(operation.Payment).Target, common.BaseReserve).MustSave(st)
+	}
+	block.NewBlockAccount(kp.Address(), tx.TotalAmount(true).MustAdd(common.BaseReserve)).MustSave(st)
+
+	return kp, tx
+}
+
+// The transaction, which is not yet valid by it's time bounds, is not
+// proposed, but it stays in the pool.
+func TestProposeNewBallotNotYetValidTransaction(t *testing.T) {
+	nr, _, _ := createNodeRunnerForTesting(1, common.NewTestConfig(), nil)
+
+	latest := block.GetLatestBlock(nr.Storage())
+
+	kp, tx := makeProposableTransaction(nr.Storage(), 1)
+	tx.H.Version = common.TransactionVersionV2
+	tx.B.TimeBounds = &transaction.TimeBounds{MinHeight: latest.Height + 2}
+	tx.Sign(kp, networkID)
+	require.NoError(t, ValidateTx(nr.Storage(), nr.Conf, tx))
+	nr.TransactionPool.Add(tx)
+
+	blt, err := nr.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(blt.Transactions()))
+	require.True(t, nr.TransactionPool.Has(tx.GetHash()))
+}
+
+// The transactions selected under `OpsInBallotLimit` are validated again
+// without the skipped ones; the skipped transaction stays in the pool.
+func TestProposeNewBallotSkippedByOpsInBallotLimit(t *testing.T) {
+	config := common.NewTestConfig()
+	config.OpsInBallotLimit = 2
+	nr, _, _ := createNodeRunnerForTesting(1, config, nil)
+	st := nr.Storage()
+
+	kps, kpt := keypair.Random(), keypair.Random()
+	for _, kp := range []*keypair.Full{kps, kpt} {
+		block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+	}
+
+	makeTx := func(kp *keypair.Full, feeRate int, opbs ...operation.Body) transaction.Transaction {
+		var ops []operation.Operation
+		for _, opb := range opbs {
+			op, _ := operation.NewOperation(opb)
+			ops = append(ops, op)
+		}
+		tx, _ := transaction.NewTransaction(kp.Address(), 0, operation.NewFeeSchedule(), ops...)
+		tx.B.Fee = tx.B.Fee.MustMult(feeRate)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	// `skipped` has the higher fee, but it's 3 operations are over the limit.
+	skipped := makeTx(
+		kpt,
+		2,
+		operation.NewPayment(kps.Address(), common.Amount(1)),
+		operation.NewPayment(kps.Address(), common.Amount(1)),
+		operation.NewPayment(kps.Address(), common.Amount(1)),
+	)
+	selected := makeTx(kps, 1, operation.NewPayment(kpt.Address(), common.Amount(10)))
+	nr.TransactionPool.Add(skipped)
+	nr.TransactionPool.Add(selected)
+	require.Equal(t, []string{skipped.GetHash(), selected.GetHash()}, nr.TransactionPool.AvailableTransactions(config.TxsLimit))
+
+	blt, err := nr.proposeNewBallot(0)
+	require.NoError(t, err)
+	require.Equal(t, []string{selected.GetHash()}, blt.Transactions())
+	require.True(t, nr.TransactionPool.Has(skipped.GetHash()))
+
+	checker := nr.checkProposableTransactions(blt.Transactions(), NewTransactionCache(st, nr.TransactionPool))
+	require.Equal(t, blt.Transactions(), checker.ValidTransactions)
+}
+
 // NodeRunner must propose new ballot by common.Config.OpsInBallotLimit.
 func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 	{ // limit=100 tx0=50, tx1=50; tx0 and tx1 will be in ballot
@@ -320,10 +452,10 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		var txs []string
 
-		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
+		_, tx0 := makeProposableTransaction(nr.Storage(), 50)
 		txs = append(txs, tx0.GetHash())
 		nr.TransactionPool.Add(tx0)
-		_, tx1 := transaction.TestMakeTransaction(networkID, 50)
+		_, tx1 := makeProposableTransaction(nr.Storage(), 50)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 
@@ -353,10 +485,10 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		var txs []string
 
-		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
+		_, tx0 := makeProposableTransaction(nr.Storage(), 50)
 		txs = append(txs, tx0.GetHash())
 		nr.TransactionPool.Add(tx0)
-		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		_, tx1 := makeProposableTransaction(nr.Storage(), 51)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
 
@@ -386,13 +518,13 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		var txs []string
 
-		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
+		_, tx0 := makeProposableTransaction(nr.Storage(), 50)
 		txs = append(txs, tx0.GetHash())
 		nr.TransactionPool.Add(tx0)
-		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		_, tx1 := makeProposableTransaction(nr.Storage(), 51)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
-		_, tx2 := transaction.TestMakeTransaction(networkID, 10)
+		_, tx2 := makeProposableTransaction(nr.Storage(), 10)
 		nr.TransactionPool.Add(tx2)
 		txs = append(txs, tx2.GetHash())
 
@@ -424,16 +556,16 @@ func TestProposedBallotByOpsInBallotLimit(t *testing.T) {
 
 		var txs []string
 
-		_, tx0 := transaction.TestMakeTransaction(networkID, 50)
+		_, tx0 := makeProposableTransaction(nr.Storage(), 50)
 		txs = append(txs, tx0.GetHash())
 		nr.TransactionPool.Add(tx0)
-		_, tx1 := transaction.TestMakeTransaction(networkID, 51)
+		_, tx1 := makeProposableTransaction(nr.Storage(), 51)
 		nr.TransactionPool.Add(tx1)
 		txs = append(txs, tx1.GetHash())
-		_, tx2 := transaction.TestMakeTransaction(networkID, 10)
+		_, tx2 := makeProposableTransaction(nr.Storage(), 10)
 		nr.TransactionPool.Add(tx2)
 		txs = append(txs, tx2.GetHash())
-		_, tx3 := transaction.TestMakeTransaction(networkID, 40)
+		_, tx3 := makeProposableTransaction(nr.Storage(), 40)
 		nr.TransactionPool.Add(tx3)
 		txs = append(txs, tx3.GetHash())
 
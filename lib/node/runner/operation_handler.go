@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"fmt"
+
+	logging "github.com/inconshreveable/log15"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// OperationHandler has the hooks of operation, which need the state of
+// storage. The operation type itself must be registered by
+// `operation.Register`.
+type OperationHandler struct {
+	// Validate checks the operation against the current state, it is called
+	// by `ValidateOp`. The storage must not be written to.
+	Validate func(*storage.LevelDBBackend, common.Config, *block.BlockAccount, operation.Operation) error
+
+	// Finish applies the operation to the state after consensus, it is
+	// called by `finishOperation`.
+	Finish func(*storage.LevelDBBackend, string, operation.Operation, logging.Logger) error
+}
+
+var operationHandlers = map[operation.OperationType]OperationHandler{}
+
+// RegisterOperationHandler registers the `OperationHandler` of operation
+// type. It panics if the type is not registered by `operation.Register` or
+// it's handler is already registered.
+func RegisterOperationHandler(t operation.OperationType, handler OperationHandler) {
+	if _, found := operation.GetDefinition(t); !found {
+		panic(fmt.Sprintf("operation %d: not registered", t))
+	}
+	if _, found := operationHandlers[t]; found {
+		panic(fmt.Sprintf("operation %q: handler already registered", t))
+	}
+
+	operationHandlers[t] = handler
+}
+
+func getOperationHandler(t operation.OperationType) (OperationHandler, bool) {
+	handler, found := operationHandlers[t]
+	return handler, found
+}
+
+// finishNothing is used for the operations, which does not change the state.
+func finishNothing(*storage.LevelDBBackend, string, operation.Operation, logging.Logger) error {
+	return nil
+}
+
+// The operations in the proposer transaction, `operation.CollectTxFee` and
+// `operation.Inflation` are handled by `ProcessProposerTransaction` and
+// `operation.ScheduledPaymentExecute` by `FinishScheduledPayments`. The
+// `operation.NetworkParameters` is only in the genesis block.
+func init() {
+	RegisterOperationHandler(operation.TypeCreateAccount, OperationHandler{
+		Validate: validateCreateAccount,
+		Finish:   finishCreateAccount,
+	})
+	RegisterOperationHandler(operation.TypePayment, OperationHandler{
+		Validate: validatePayment,
+		Finish:   finishPayment,
+	})
+	RegisterOperationHandler(operation.TypeCongressVoting, OperationHandler{
+		Validate: validateCongressVoting,
+		Finish:   finishNothing,
+	})
+	RegisterOperationHandler(operation.TypeCongressVotingResult, OperationHandler{
+		Validate: validateCongressVotingResult,
+		Finish:   finishCongressVotingResult,
+	})
+	RegisterOperationHandler(operation.TypeUnfreezingRequest, OperationHandler{
+		Validate: validateUnfreezeRequest,
+		Finish:   finishUnfreezeRequest,
+	})
+	RegisterOperationHandler(operation.TypeInflationPF, OperationHandler{
+		Validate: validateInflationPF,
+		Finish:   finishInflationPF,
+	})
+	RegisterOperationHandler(operation.TypeManageSigners, OperationHandler{
+		Validate: validateManageSigners,
+		Finish:   finishManageSigners,
+	})
+	RegisterOperationHandler(operation.TypeAccountMerge, OperationHandler{
+		Validate: validateAccountMerge,
+		Finish:   finishAccountMerge,
+	})
+	RegisterOperationHandler(operation.TypeManageData, OperationHandler{
+		Validate: validateManageData,
+		Finish:   finishManageData,
+	})
+	RegisterOperationHandler(operation.TypeHashLockCreate, OperationHandler{
+		Validate: validateHashLockCreate,
+		Finish:   finishHashLockCreate,
+	})
+	RegisterOperationHandler(operation.TypeHashLockClaim, OperationHandler{
+		Validate: validateHashLock,
+		Finish:   finishHashLockClaim,
+	})
+	RegisterOperationHandler(operation.TypeHashLockRefund, OperationHandler{
+		Validate: validateHashLock,
+		Finish:   finishHashLockRefund,
+	})
+	RegisterOperationHandler(operation.TypeCreateVestingAccount, OperationHandler{
+		Validate: validateCreateVestingAccount,
+		Finish:   finishCreateVestingAccount,
+	})
+	RegisterOperationHandler(operation.TypeCongressMembership, OperationHandler{
+		Validate: validateCongressMembership,
+		Finish:   finishCongressMembership,
+	})
+	RegisterOperationHandler(operation.TypeCongressVote, OperationHandler{
+		Validate: validateCongressVote,
+		Finish:   finishCongressVote,
+	})
+	RegisterOperationHandler(operation.TypeScheduledPaymentCreate, OperationHandler{
+		Validate: validateScheduledPaymentCreate,
+		Finish:   finishScheduledPaymentCreate,
+	})
+	RegisterOperationHandler(operation.TypeScheduledPaymentCancel, OperationHandler{
+		Validate: validateScheduledPaymentCancel,
+		Finish:   finishScheduledPaymentCancel,
+	})
+	RegisterOperationHandler(operation.TypeSetOptions, OperationHandler{
+		Validate: validateSetOptions,
+		Finish:   finishSetOptions,
+	})
+	RegisterOperationHandler(operation.TypeTrust, OperationHandler{
+		Validate: validateTrust,
+		Finish:   finishAssetOperation,
+	})
+	RegisterOperationHandler(operation.TypeIssueAsset, OperationHandler{
+		Validate: validateIssueAsset,
+		Finish:   finishAssetOperation,
+	})
+	RegisterOperationHandler(operation.TypeAssetPayment, OperationHandler{
+		Validate: validateAssetPayment,
+		Finish:   finishAssetOperation,
+	})
+	RegisterOperationHandler(operation.TypeManageValidator, OperationHandler{
+		Validate: validateManageValidator,
+		Finish:   finishManageValidator,
+	})
+}
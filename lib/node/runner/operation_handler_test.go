@@ -0,0 +1,42 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// Every operation, which can be in the normal transaction must have it's
+// handler.
+func TestOperationHandlerRegistered(t *testing.T) {
+	for _, ty := range operation.Types() {
+		if !operation.IsNormalOperation(ty) {
+			continue
+		}
+
+		handler, found := getOperationHandler(ty)
+		require.True(t, found, "handler of %q is missing", ty)
+		require.NotNil(t, handler.Validate)
+		require.NotNil(t, handler.Finish)
+	}
+
+	require.Panics(t, func() {
+		RegisterOperationHandler(operation.TypePayment, OperationHandler{})
+	})
+}
+
+func TestOperationHandlerUnknown(t *testing.T) {
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	// the operations of proposer transaction are not handled by `ValidateOp`
+	op, _ := operation.NewOperation(operation.CollectTxFee{})
+	require.Equal(t, errors.UnknownOperationType, ValidateOp(st, common.NewTestConfig(), nil, op, transaction.Transaction{}))
+	require.Equal(t, errors.UnknownOperationType, finishOperation(st, "", op, log))
+}
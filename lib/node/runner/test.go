@@ -126,6 +126,18 @@ func ReceiveBallot(nodeRunner *NodeRunner, ballot *ballot.Ballot) error {
 	return nodeRunner.handleBallotMessage(ballotMessage)
 }
 
+// VoteAcceptBallot votes the YES ACCEPT ballot of `b` by `sender`, so the
+// block of `b` can be finished with the certificate.
+func VoteAcceptBallot(nodeRunner *NodeRunner, b ballot.Ballot, sender *node.LocalNode, conf common.Config) {
+	b.SetSource(sender.Address())
+	b.SetVote(ballot.StateACCEPT, voting.YES)
+	b.Sign(sender.Keypair(), conf.NetworkID)
+
+	if _, err := nodeRunner.Consensus().Vote(b); err != nil {
+		panic(err)
+	}
+}
+
 func createNodeRunnerForTesting(n int, conf common.Config, recv chan struct{}) (*NodeRunner, []*node.LocalNode, *TestConnectionManager) {
 	var ns []*network.MemoryNetwork
 	var net *network.MemoryNetwork
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"sort"
+
+	logging "github.com/inconshreveable/log15"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+// RestoreTransactionPool rebuilds `transaction.Pool` from the pending
+// transactions of `block.TransactionPool`. The transactions are validated
+// again in order of sequence id like `ValidateTx`; the confirmed ones are
+// unmarked and the invalid ones are removed from storage.
+func RestoreTransactionPool(st *storage.LevelDBBackend, config common.Config, params operation.NetworkParameters, pool *transaction.Pool, log logging.Logger) (err error) {
+	var txs []transaction.Transaction
+	for _, hash := range block.GetPendingTransactionPoolHashes(st) {
+		var exists bool
+		if exists, err = block.ExistsBlockTransaction(st, hash); err != nil {
+			return
+		} else if exists {
+			if err = block.DeletePendingTransactionPool(st, hash); err != nil {
+				return
+			}
+			continue
+		}
+
+		var tp block.TransactionPool
+		if tp, err = block.GetTransactionPool(st, hash); err != nil {
+			log.Debug("pending transaction is not found in TransactionPool", "transaction", hash)
+			if err = block.DeletePendingTransactionPool(st, hash); err != nil {
+				return
+			}
+			continue
+		}
+
+		txs = append(txs, tp.Transaction())
+	}
+
+	// the replaced transaction of same sequence id is validated after the
+	// one of higher fee, so it becomes stale.
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Source() != txs[j].Source() {
+			return txs[i].Source() < txs[j].Source()
+		}
+		if txs[i].B.SequenceID != txs[j].B.SequenceID {
+			return txs[i].B.SequenceID < txs[j].B.SequenceID
+		}
+		return txs[i].FeePerOperation() > txs[j].FeePerOperation()
+	})
+
+	accounts := NewRunningAccounts(st, config, params)
+	for _, tx := range txs {
+		hash := tx.GetHash()
+		if verr := accounts.Validate(tx); verr != nil {
+			log.Debug("stale transaction is removed from TransactionPool", "transaction", hash, "error", verr)
+			if err = block.DeletePendingTransactionPool(st, hash); err != nil {
+				return
+			}
+			if err = block.DeleteTransactionPool(st, hash); err != nil {
+				return
+			}
+			continue
+		}
+
+		if err = pool.Add(tx); err != nil {
+			return
+		}
+	}
+
+	log.Debug("TransactionPool restored", "transactions", pool.Len(), "stored", len(txs))
+
+	return
+}
+
+// deleteRemovedTransactions removes the transactions, which are removed from
+// `transaction.Pool`, from storage. The pending marks are removed and the
+// `block.TransactionPool` of the unconfirmed ones are also removed, so they
+// are not restored after restart.
+func deleteRemovedTransactions(st *storage.LevelDBBackend, hashes ...string) (err error) {
+	for _, hash := range hashes {
+		if err = block.DeletePendingTransactionPool(st, hash); err != nil {
+			return
+		}
+
+		var exists bool
+		if exists, err = block.ExistsBlockTransaction(st, hash); err != nil {
+			return
+		} else if exists {
+			continue
+		}
+
+		if exists, err = block.ExistsTransactionPool(st, hash); err != nil {
+			return
+		} else if !exists {
+			continue
+		}
+		if err = block.DeleteTransactionPool(st, hash); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/block"
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+)
+
+func TestRestoreTransactionPool(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kps := keypair.Random()
+	block.NewBlockAccount(kps.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+
+	makeTx := func(sequenceID uint64, fee common.Amount) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1000)))
+		tx, _ := transaction.NewTransaction(kps.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.B.Fee = fee
+		tx.Sign(kps, networkID)
+		return tx
+	}
+
+	first := makeTx(0, common.BaseFee*2)
+	replaced := makeTx(0, common.BaseFee)
+	second := makeTx(1, common.BaseFee)
+	gap := makeTx(3, common.BaseFee)
+	for _, tx := range []transaction.Transaction{first, replaced, second, gap} {
+		_, err := block.SavePendingTransactionPool(st, tx)
+		require.NoError(t, err)
+	}
+
+	// confirmed transaction
+	confirmed := makeTx(0, common.BaseFee*3)
+	_, err := block.SavePendingTransactionPool(st, confirmed)
+	require.NoError(t, err)
+	blk := block.GetLatestBlock(st)
+	bt := block.NewBlockTransactionFromTransaction(blk.Hash, blk.Height, blk.ProposedTime, confirmed)
+	require.NoError(t, bt.Save(st))
+	require.Equal(t, 5, len(block.GetPendingTransactionPoolHashes(st)))
+
+	pool := transaction.NewPool(nr.Conf)
+	require.NoError(t, RestoreTransactionPool(st, nr.Conf, nr.NetworkParameters(), pool, nr.Log()))
+
+	require.Equal(t, 2, pool.Len())
+	require.True(t, pool.Has(first.GetHash()))
+	require.True(t, pool.Has(second.GetHash()))
+
+	hashes := block.GetPendingTransactionPoolHashes(st)
+	require.Equal(t, 2, len(hashes))
+	require.Contains(t, hashes, first.GetHash())
+	require.Contains(t, hashes, second.GetHash())
+
+	// stale transactions are removed, but the confirmed one is kept
+	for _, tx := range []transaction.Transaction{replaced, gap} {
+		exists, err := block.ExistsTransactionPool(st, tx.GetHash())
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+	exists, err := block.ExistsTransactionPool(st, confirmed.GetHash())
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestEvictTransactions(t *testing.T) {
+	nr, _ := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	makeTx := func(kp *keypair.Full, sequenceID uint64) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1000)))
+		tx, _ := transaction.NewTransaction(kp.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	kp := keypair.Random()
+	ba := block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin))
+	ba.MustSave(st)
+	kpOther := keypair.Random()
+	block.NewBlockAccount(kpOther.Address(), common.Amount(1*common.AmountPerCoin)).MustSave(st)
+
+	valid := makeTx(kp, 0)
+	next := makeTx(kp, 1)
+	other := makeTx(kpOther, 0)
+	for _, tx := range []transaction.Transaction{valid, next, other} {
+		require.NoError(t, nr.TransactionPool.Add(tx))
+		_, err := block.SavePendingTransactionPool(st, tx)
+		require.NoError(t, err)
+	}
+
+	{ // nothing is evicted
+		nr.evictTransactions(time.Now(), kp.Address(), kpOther.Address())
+		require.Equal(t, 3, nr.TransactionPool.Len())
+	}
+
+	{ // the balance of source is not enough for the second one
+		ba.Balance = common.BaseFee.MustMult(2) + common.Amount(1000)
+		ba.MustSave(st)
+
+		// the source is not changed by block, so it is not validated
+		nr.evictTransactions(time.Now(), kpOther.Address())
+		require.Equal(t, 3, nr.TransactionPool.Len())
+
+		nr.evictTransactions(time.Now(), kp.Address())
+		require.Equal(t, 2, nr.TransactionPool.Len())
+		require.True(t, nr.TransactionPool.Has(valid.GetHash()))
+		require.False(t, nr.TransactionPool.Has(next.GetHash()))
+		require.NotContains(t, block.GetPendingTransactionPoolHashes(st), next.GetHash())
+		exists, err := block.ExistsTransactionPool(st, next.GetHash())
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	{ // outdated by TTL
+		nr.evictTransactions(time.Now().Add(nr.Conf.TxPoolTTL + time.Second))
+		require.Equal(t, 0, nr.TransactionPool.Len())
+		require.Empty(t, block.GetPendingTransactionPoolHashes(st))
+	}
+}
+
+func TestRemovedTransactionsFromStorage(t *testing.T) {
+	nr, localNode := MakeNodeRunner()
+	st := nr.Storage()
+	defer st.Close()
+
+	kp := keypair.Random()
+	ba := block.NewBlockAccount(kp.Address(), common.Amount(1*common.AmountPerCoin))
+	ba.MustSave(st)
+
+	makeTx := func(sequenceID uint64, fee common.Amount) transaction.Transaction {
+		op, _ := operation.NewOperation(operation.NewPayment(block.GenesisKP.Address(), common.Amount(1000)))
+		tx, _ := transaction.NewTransaction(kp.Address(), sequenceID, operation.NewFeeSchedule(), op)
+		tx.B.Fee = fee
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	requireRemoved := func(hash string) {
+		require.NotContains(t, block.GetPendingTransactionPoolHashes(st), hash)
+		exists, err := block.ExistsTransactionPool(st, hash)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+
+	checker := &MessageChecker{
+		Storage:         st,
+		TransactionPool: nr.TransactionPool,
+		LocalNode:       localNode,
+		Log:             nr.Log(),
+		Conf:            nr.Conf,
+	}
+
+	push := func(tx transaction.Transaction) {
+		checker.Transaction = tx
+		require.NoError(t, PushIntoTransactionPool(checker))
+	}
+
+	first := makeTx(0, common.BaseFee)
+	second := makeTx(1, common.BaseFee)
+	push(first)
+	push(second)
+	require.Equal(t, 2, len(block.GetPendingTransactionPoolHashes(st)))
+
+	{ // replaced by fee
+		replacing := makeTx(0, common.BaseFee*2)
+		push(replacing)
+		require.False(t, nr.TransactionPool.Has(first.GetHash()))
+		requireRemoved(first.GetHash())
+		require.Contains(t, block.GetPendingTransactionPoolHashes(st), replacing.GetHash())
+
+		first = replacing
+	}
+
+	{ // stale by the sequence id of source
+		ba.SequenceID = 1
+		ba.MustSave(st)
+
+		nr.removeStaleTransactions(kp.Address())
+		require.False(t, nr.TransactionPool.Has(first.GetHash()))
+		requireRemoved(first.GetHash())
+		require.Contains(t, block.GetPendingTransactionPoolHashes(st), second.GetHash())
+	}
+
+	{ // removed as invalid
+		nr.TransactionPool.Remove(second.GetHash())
+		nr.deleteRemovedTransactions(second.GetHash())
+		requireRemoved(second.GetHash())
+	}
+}
@@ -28,7 +28,7 @@ func GetGenesisTransaction(st *storage.LevelDBBackend) (bt block.BlockTransactio
 		return
 	}
 
-	if len(bt.Operations) != 2 {
+	if len(bt.Operations) < 2 {
 		err = errors.WrongBlockFound
 		return
 	}
@@ -115,11 +115,14 @@ func NewNodeInfo(nr *NodeRunner) node.NodeInfo {
 		Validators: localNode.GetValidators(),
 	}
 
+	params := nr.NetworkParameters()
+
 	policy := node.NodePolicy{
 		NetworkID:                 string(nr.NetworkID()),
 		InitialBalance:            nr.Conf.InitialBalance,
 		BaseReserve:               common.BaseReserve,
-		BaseFee:                   common.BaseFee,
+		BaseFee:                   params.FeeSchedule.Base,
+		FeeSchedule:               params.FeeSchedule,
 		BlockTime:                 nr.Conf.BlockTime,
 		BlockTimeDelta:            nr.Conf.BlockTimeDelta,
 		TimeoutINIT:               nr.Conf.TimeoutINIT,
@@ -135,6 +138,8 @@ func NewNodeInfo(nr *NodeRunner) node.NodeInfo {
 		InflationRatio:            common.InflationRatioString,
 		UnfreezingPeriod:          common.UnfreezingPeriod,
 		BlockHeightEndOfInflation: common.BlockHeightEndOfInflation,
+		TransactionVersions:       params.TransactionVersions(block.GetLatestBlock(nr.storage).Height + 1),
+		TxV2ActivationHeight:      params.TxV2ActivationHeight,
 	}
 
 	return node.NodeInfo{
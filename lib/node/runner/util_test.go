@@ -6,6 +6,7 @@ import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/storage"
+	"boscoin.io/sebak/lib/transaction/operation"
 
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +20,7 @@ func TestGetGenesisAccount(t *testing.T) {
 	commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
 	commonAccount.MustSave(st)
 
-	block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, networkID)
+	block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, operation.NewNetworkParameters(), networkID)
 
 	fetchedGenesisAccount, err := GetGenesisAccount(st)
 	require.NoError(t, err)
@@ -44,7 +45,7 @@ func TestGetInitialBalance(t *testing.T) {
 	commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
 	commonAccount.MustSave(st)
 
-	block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, networkID)
+	block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, operation.NewNetworkParameters(), networkID)
 
 	fetchedInitialBalance, err := GetGenesisBalance(st)
 	require.NoError(t, err)
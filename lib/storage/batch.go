@@ -16,6 +16,7 @@ type BatchCore struct {
 	batch *leveldb.Batch
 
 	inserted map[string][]byte
+	deleted  map[string]bool
 }
 
 func NewBatchCore(core LevelDBCore) *BatchCore {
@@ -23,6 +24,7 @@ func NewBatchCore(core LevelDBCore) *BatchCore {
 		core:     core,
 		batch:    &leveldb.Batch{},
 		inserted: map[string][]byte{},
+		deleted:  map[string]bool{},
 	}
 }
 
@@ -34,6 +36,9 @@ func (bb *BatchCore) Has(key []byte, opt *leveldbOpt.ReadOptions) (bool, error)
 	if _, found = bb.inserted[string(key)]; found {
 		return true, nil
 	}
+	if _, found = bb.deleted[string(key)]; found {
+		return false, nil
+	}
 
 	return bb.core.Has(key, opt)
 }
@@ -46,6 +51,10 @@ func (bb *BatchCore) Get(key []byte, opt *leveldbOpt.ReadOptions) (b []byte, err
 	if b, found = bb.inserted[string(key)]; found {
 		return
 	}
+	if _, found = bb.deleted[string(key)]; found {
+		err = leveldb.ErrNotFound
+		return
+	}
 
 	return bb.core.Get(key, opt)
 }
@@ -60,6 +69,7 @@ func (bb *BatchCore) Put(key []byte, v []byte, opt *leveldbOpt.WriteOptions) err
 	defer bb.Unlock()
 
 	bb.inserted[string(key)] = v
+	delete(bb.deleted, string(key))
 	bb.batch.Put(key, v)
 
 	return nil
@@ -108,6 +118,7 @@ func (bb *BatchCore) Delete(key []byte, opt *leveldbOpt.WriteOptions) error {
 	defer bb.Unlock()
 
 	delete(bb.inserted, string(key))
+	bb.deleted[string(key)] = true
 	bb.batch.Delete(key)
 
 	return nil
@@ -123,4 +134,5 @@ func (bb *BatchCore) Dump() []byte {
 func (bb *BatchCore) clear() {
 	bb.batch = &leveldb.Batch{}
 	bb.inserted = map[string][]byte{}
+	bb.deleted = map[string]bool{}
 }
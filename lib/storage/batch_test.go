@@ -99,6 +99,15 @@ func TestBatchBackendDelete(t *testing.T) {
 		require.True(t, reflect.DeepEqual(input, fetched))
 	}
 
+	{ // in BatchBackend, it is already removed
+		exists, err := bt.Has(key)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		err = bt.Get(key, &fetched)
+		require.Equal(t, errors.StorageRecordDoesNotExist, err)
+	}
+
 	err := bt.Commit()
 	require.NoError(t, err)
 
@@ -3,12 +3,16 @@ package sync
 import (
 	"time"
 
+	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/node/runner"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/inconshreveable/log15"
 )
 
@@ -27,9 +31,12 @@ type Config struct {
 	connectionManager network.ConnectionManager
 	tp                *transaction.Pool
 	localNode         *node.LocalNode
+	policy            voting.ThresholdPolicy
 	nodelist          *NodeList
 	logger            log15.Logger
 	commonCfg         common.Config
+	params            operation.NetworkParameters
+	proposerSelector  consensus.ProposerSelector
 
 	SyncPoolSize             uint64
 	FetchTimeout             time.Duration
@@ -44,6 +51,7 @@ func NewConfig(localNode *node.LocalNode,
 	nt network.Network,
 	cm network.ConnectionManager,
 	tp *transaction.Pool,
+	policy voting.ThresholdPolicy,
 	cfg common.Config) (*Config, error) {
 	c := &Config{
 		storage:           st,
@@ -53,6 +61,7 @@ func NewConfig(localNode *node.LocalNode,
 		logger:            log.New(log15.Ctx{"node": localNode.Alias()}),
 		commonCfg:         cfg,
 		localNode:         localNode,
+		policy:            policy,
 		nodelist:          &NodeList{},
 
 		SyncPoolSize:             SyncPoolSize,
@@ -65,6 +74,14 @@ func NewConfig(localNode *node.LocalNode,
 		return nil, err
 	}
 	c.commonCfg.CommonAccountAddress = commonAccountAddress
+
+	if c.params, err = block.GetNetworkParameters(st); err != nil {
+		return nil, err
+	}
+	c.params = c.params.Upgrade(cfg)
+	if c.proposerSelector, err = consensus.NewNetworkProposerSelector(cfg, c.params, cm, st); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -104,9 +121,13 @@ func (c *Config) NewValidator() Validator {
 		c.network,
 		c.storage,
 		c.tp,
+		c.localNode,
+		c.policy,
 		c.commonCfg,
+		c.params,
 		func(v *BlockValidator) {
 			v.prevBlockWaitTimeout = c.CheckPrevBlockInterval
+			v.proposerSelector = c.proposerSelector
 			v.logger = c.logger.New("submodule", "validator")
 		})
 	return v
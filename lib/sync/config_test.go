@@ -7,6 +7,7 @@ import (
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/network"
 	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/transaction"
@@ -26,7 +27,9 @@ func TestNewConfig(t *testing.T) {
 
 	node, _ := node.NewLocalNode(keypair.Random(), endpoint, "")
 
-	cfg, err := NewConfig(node, st, nt, cm, tp, conf)
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+
+	cfg, err := NewConfig(node, st, nt, cm, tp, policy, conf)
 	require.NoError(t, err)
 	cfg.SyncPoolSize = 100
 	cfg.logger = common.NopLogger()
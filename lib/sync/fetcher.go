@@ -98,6 +98,7 @@ func (f *BlockFetcher) fetch(ctx context.Context, si *SyncInfo) error {
 		nodeAddrs = si.NodeAddrs()
 	)
 	si.Bts = si.Bts[:0]
+	si.Certificate = nil
 	f.logger.Debug("start fetch", "height", height, "nodes", nodeAddrs)
 
 	if len(nodeAddrs) <= 0 {
@@ -161,6 +162,14 @@ func (f *BlockFetcher) fetch(ctx context.Context, si *SyncInfo) error {
 	blk := blocks[0].(block.Block)
 	si.Block = &blk
 
+	if bcs, ok := items[runner.NodeItemBlockCertificate]; ok && len(bcs) > 0 {
+		bc, ok := bcs[0].(block.BlockCertificate)
+		if !ok || bc.Hash != blk.Hash {
+			return errors.InvalidBlockCertificate
+		}
+		si.Certificate = &bc
+	}
+
 	{
 		btmap := make(map[string]*block.BlockTransaction) // For ordering txs by block.Transactions
 
@@ -54,6 +54,8 @@ func TestBlockFetcher(t *testing.T) {
 	require.NoError(t, err)
 	bt.Message = tp.Message
 
+	bc := block.NewBlockCertificate(bk, []json.RawMessage{json.RawMessage(`{"H":{"hash":"a"}}`)})
+
 	apiHandlerFunc := func(req *http.Request) (*http.Response, error) {
 		w := httptest.NewRecorder()
 		renderNodeItem(w, runner.NodeItemBlock, bk)
@@ -62,6 +64,7 @@ func TestBlockFetcher(t *testing.T) {
 		bt.Message = tp.Message
 
 		renderNodeItem(w, runner.NodeItemBlockTransaction, bt)
+		renderNodeItem(w, runner.NodeItemBlockCertificate, bc)
 		resp := w.Result()
 		return resp, nil
 	}
@@ -80,6 +83,9 @@ func TestBlockFetcher(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, bk.Hash, si.Block.Hash)
 	require.Equal(t, bk.TransactionsRoot, si.Block.TransactionsRoot)
+	require.NotNil(t, si.Certificate)
+	require.Equal(t, bk.Hash, si.Certificate.Hash)
+	require.Equal(t, 1, len(si.Certificate.Ballots))
 }
 
 func TestLargeFetch(t *testing.T) {
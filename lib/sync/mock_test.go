@@ -35,6 +35,11 @@ func (m *mockConnectionManager) AllValidators() []string {
 	return m.allValidators
 }
 
+func (m *mockConnectionManager) SetValidators(addresses ...string) bool {
+	m.allValidators = addresses
+	return true
+}
+
 func (m *mockConnectionManager) CountConnected() int {
 	return len(m.allConnected)
 }
@@ -25,6 +25,9 @@ type SyncInfo struct {
 	Bts    []*block.BlockTransaction
 	Ptx    *ballot.ProposerTransaction
 
+	// Certificate has the ACCEPT ballots, which confirmed the block.
+	Certificate *block.BlockCertificate
+
 	// Fetching target node addresses, NodeList is  the validators which
 	// participated and confirmed the consensus of latest ballot.
 	NodeList *NodeList
@@ -5,14 +5,18 @@ import (
 	"strconv"
 	"time"
 
+	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/observer"
+	"boscoin.io/sebak/lib/consensus"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
 	"boscoin.io/sebak/lib/node/runner"
 	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
 	"boscoin.io/sebak/lib/voting"
 
 	"github.com/inconshreveable/log15"
@@ -24,7 +28,14 @@ type BlockValidator struct {
 	network   network.Network
 	storage   *storage.LevelDBBackend
 	txpool    *transaction.Pool
+	localNode *node.LocalNode
+	policy    voting.ThresholdPolicy
 	commonCfg common.Config
+	params    operation.NetworkParameters
+
+	// proposerSelector makes the `block.ProposerRecord` of the synced block;
+	// see `consensus.NewProposerRecord`.
+	proposerSelector consensus.ProposerSelector
 
 	prevBlockWaitTimeout time.Duration // Waiting prev block if is doesn't exist
 	logger               log15.Logger
@@ -32,13 +43,16 @@ type BlockValidator struct {
 
 type BlockValidatorOption func(*BlockValidator)
 
-func NewBlockValidator(nw network.Network, ldb *storage.LevelDBBackend, tp *transaction.Pool, cfg common.Config, opts ...BlockValidatorOption) *BlockValidator {
+func NewBlockValidator(nw network.Network, ldb *storage.LevelDBBackend, tp *transaction.Pool, localNode *node.LocalNode, policy voting.ThresholdPolicy, cfg common.Config, params operation.NetworkParameters, opts ...BlockValidatorOption) *BlockValidator {
 	v := &BlockValidator{
 		network:              nw,
 		storage:              ldb,
 		txpool:               tp,
+		localNode:            localNode,
+		policy:               policy,
 		prevBlockWaitTimeout: CheckPrevBlockInterval,
 		commonCfg:            cfg,
+		params:               params,
 
 		logger: common.NopLogger(),
 	}
@@ -92,6 +106,10 @@ func (v *BlockValidator) validate(ctx context.Context, syncInfo *SyncInfo) error
 		return err
 	}
 
+	if err := v.validateCertificate(ctx, syncInfo); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -119,12 +137,32 @@ func (v *BlockValidator) finishBlock(ctx context.Context, syncInfo *SyncInfo) er
 		return err
 	}
 
+	// the block before `NetworkParameters.CertificateActivationHeight` may
+	// not have the certificate
+	if syncInfo.Certificate != nil {
+		if err := syncInfo.Certificate.Save(bs); err != nil {
+			bs.Discard()
+			return err
+		}
+	}
+
+	if err := consensus.NewProposerRecord(v.proposerSelector, blk).Save(bs); err != nil {
+		bs.Discard()
+		return err
+	}
+
 	var txs []*transaction.Transaction
 	for _, bt := range syncInfo.Bts {
 		tx := bt.Transaction()
 		txs = append(txs, &tx)
 	}
 
+	// the scheduled payments are executed before the transactions
+	if err := runner.FinishScheduledPayments(bs, blk, *syncInfo.Ptx, v.logger); err != nil {
+		bs.Discard()
+		return err
+	}
+
 	if err := runner.FinishTransactions(blk, txs, bs); err != nil {
 		bs.Discard()
 		return err
@@ -216,6 +254,48 @@ func (v *BlockValidator) validateBlock(ctx context.Context, si *SyncInfo, prevBl
 	return nil
 }
 
+// validateCertificate checks the block is confirmed by the ACCEPT ballots of
+// the validators, which are active at the height of block. The certificate is
+// required from `NetworkParameters.CertificateActivationHeight`; if it is 0,
+// the certificate is validated only if the block has it.
+func (v *BlockValidator) validateCertificate(ctx context.Context, si *SyncInfo) error {
+	v.logger.Debug("start validate certificate", "height", si.Height)
+	if si.Certificate == nil {
+		if v.params.IsCertificateRequired(si.Height) {
+			return errors.BlockCertificateDoesNotExists
+		}
+
+		// the block before the activation height or of the network, which
+		// does not require it, may not have the certificate; it is validated
+		// only by the previous block in `validateBlock`.
+		v.logger.Debug("skip validate certificate", "height", si.Height)
+		return nil
+	}
+
+	var validators []string
+	if vs, err := block.GetBlockValidatorSet(v.storage, si.Height); err == nil {
+		validators = vs.Validators
+	} else {
+		for address := range v.localNode.GetValidators() {
+			validators = append(validators, address)
+		}
+	}
+
+	err := ballot.VerifyBlockCertificate(
+		*si.Certificate,
+		*si.Block,
+		v.commonCfg.NetworkID,
+		validators,
+		v.policy.ThresholdOf(len(validators)),
+	)
+	if err != nil {
+		return err
+	}
+
+	v.logger.Debug("end validate certificate", "height", si.Height)
+	return nil
+}
+
 func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 	v.logger.Debug("start validate txs", "height", si.Height)
 	// proposer transaction
@@ -224,10 +304,11 @@ func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 			return err
 		}
 	}
-	// transactions
+	// transactions; the transactions of same source are validated in order
+	accounts := runner.NewRunningAccounts(v.storage, v.commonCfg, v.params)
 	for _, bt := range si.Bts {
 		tx := bt.Transaction()
-		hash := tx.B.MakeHashString()
+		hash := tx.MakeHashString()
 		if hash != tx.H.Hash {
 			err := errors.HashDoesNotMatch
 			return err
@@ -237,7 +318,7 @@ func (v *BlockValidator) validateTxs(ctx context.Context, si *SyncInfo) error {
 			return err
 		}
 
-		if err := runner.ValidateTx(v.storage, v.commonCfg, tx); err != nil {
+		if err := accounts.Validate(tx); err != nil {
 			return err
 		}
 	}
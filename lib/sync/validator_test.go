@@ -4,10 +4,19 @@ import (
 	"context"
 	"testing"
 
+	"boscoin.io/sebak/lib/ballot"
 	"boscoin.io/sebak/lib/block"
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/consensus"
+	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/network"
+	"boscoin.io/sebak/lib/node"
+	"boscoin.io/sebak/lib/node/runner"
+	"boscoin.io/sebak/lib/storage"
 	"boscoin.io/sebak/lib/transaction"
+	"boscoin.io/sebak/lib/transaction/operation"
+	"boscoin.io/sebak/lib/voting"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,10 +24,11 @@ func TestValidator(t *testing.T) {
 	conf := common.NewTestConfig()
 	st := block.InitTestBlockchain()
 	defer st.Close()
-	_, nw, _ := network.CreateMemoryNetwork(nil)
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
 	tp := transaction.NewPool(conf)
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
 
-	v := NewBlockValidator(nw, st, tp, conf)
+	v := NewBlockValidator(nw, st, tp, localNode, policy, conf, operation.NewNetworkParameters())
 
 	ctx := context.Background()
 
@@ -35,3 +45,169 @@ func TestValidator(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestValidatorCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	tp := transaction.NewPool(conf)
+
+	nodes := []*node.LocalNode{localNode}
+	for i := 0; i < 2; i++ {
+		n, _ := node.NewLocalNode(keypair.Random(), localNode.Endpoint(), "")
+		localNode.AddValidators(n.ConvertToValidator())
+		nodes = append(nodes, n)
+	}
+
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+	policy.SetValidators(len(localNode.GetValidators()))
+	require.Equal(t, 2, policy.Threshold())
+
+	v := NewBlockValidator(nw, st, tp, localNode, policy, conf, operation.NewNetworkParameters().Upgrade(conf))
+
+	prev := block.GetLatestBlock(st)
+	basis := voting.Basis{
+		Height:    prev.Height,
+		BlockHash: prev.Hash,
+		TotalTxs:  prev.TotalTxs,
+	}
+	tx, _ := runner.GetTransaction()
+	proposer := nodes[0]
+	proposed := runner.GenerateBallot(proposer, basis, tx, ballot.StateSIGN, proposer, conf)
+
+	var ballots []ballot.Ballot
+	for _, n := range nodes {
+		b := *proposed
+		b.SetVote(ballot.StateACCEPT, voting.YES)
+		b.Sign(n.Keypair(), conf.NetworkID)
+		ballots = append(ballots, b)
+	}
+
+	r := basis
+	r.Height++
+	r.TotalTxs += 2
+	blk := *block.NewBlock(proposer.Address(), r, proposed.ProposerTransaction().GetHash(), proposed.Transactions(), proposed.ProposerConfirmed())
+
+	si := &SyncInfo{
+		Height: blk.Height,
+		Block:  &blk,
+	}
+
+	ctx := context.Background()
+	{ // without certificate
+		require.Equal(t, errors.BlockCertificateDoesNotExists, v.validateCertificate(ctx, si))
+	}
+
+	{ // not enough ballots
+		bc, err := ballot.NewBlockCertificate(blk, ballots[:1])
+		require.NoError(t, err)
+		si.Certificate = &bc
+		require.Equal(t, errors.InvalidBlockCertificate, v.validateCertificate(ctx, si))
+	}
+
+	{ // ballot of unknown validator
+		unknown, _ := node.NewLocalNode(keypair.Random(), localNode.Endpoint(), "")
+		b := *proposed
+		b.SetVote(ballot.StateACCEPT, voting.YES)
+		b.Sign(unknown.Keypair(), conf.NetworkID)
+
+		bc, err := ballot.NewBlockCertificate(blk, []ballot.Ballot{ballots[0], b})
+		require.NoError(t, err)
+		si.Certificate = &bc
+		require.Equal(t, errors.InvalidBlockCertificate, v.validateCertificate(ctx, si))
+	}
+
+	{ // valid
+		bc, err := ballot.NewBlockCertificate(blk, ballots[:2])
+		require.NoError(t, err)
+		si.Certificate = &bc
+		require.NoError(t, v.validateCertificate(ctx, si))
+	}
+}
+
+func TestValidatorCertificateActivationHeight(t *testing.T) {
+	conf := common.NewTestConfig()
+	st := storage.NewTestStorage()
+	defer st.Close()
+
+	genesisAccount := block.NewBlockAccount(block.GenesisKP.Address(), conf.InitialBalance)
+	genesisAccount.MustSave(st)
+	commonAccount := block.NewBlockAccount(block.CommonKP.Address(), 0)
+	commonAccount.MustSave(st)
+
+	params := operation.NewNetworkParameters()
+	params.CertificateActivationHeight = 10
+	_, err := block.MakeGenesisBlock(st, *genesisAccount, *commonAccount, params, conf.NetworkID)
+	require.NoError(t, err)
+
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+	v := NewBlockValidator(nw, st, transaction.NewPool(conf), localNode, policy, conf, params)
+
+	ctx := context.Background()
+	{ // before activation height, the certificate is not required
+		blk := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), nil)
+		require.NoError(t, v.validateCertificate(ctx, &SyncInfo{Height: blk.Height, Block: &blk}))
+	}
+
+	{ // from activation height
+		blk := block.TestMakeNewBlockWithPrevBlock(block.GetLatestBlock(st), nil)
+		blk.Height = params.CertificateActivationHeight
+		require.Equal(
+			t,
+			errors.BlockCertificateDoesNotExists,
+			v.validateCertificate(ctx, &SyncInfo{Height: blk.Height, Block: &blk}),
+		)
+	}
+}
+
+// TestValidatorWithoutCertificate checks the blocks of the network, which
+// does not require the certificate, are synced without it.
+func TestValidatorWithoutCertificate(t *testing.T) {
+	conf := common.NewTestConfig()
+	conf.CertificateActivationHeight = 0
+
+	// the genesis block does not have `operation.NetworkParameters`
+	st := block.InitTestBlockchain()
+	defer st.Close()
+	_, nw, localNode := network.CreateMemoryNetwork(nil)
+	policy, _ := consensus.NewDefaultVotingThresholdPolicy(66)
+
+	cfg, err := NewConfig(localNode, st, nw, &mockConnectionManager{}, transaction.NewPool(conf), policy, conf)
+	require.NoError(t, err)
+	v := cfg.NewValidator()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		prev := block.GetLatestBlock(st)
+		basis := voting.Basis{
+			Height:    prev.Height,
+			BlockHash: prev.Hash,
+			TotalTxs:  prev.TotalTxs,
+			TotalOps:  prev.TotalOps,
+		}
+		proposed := runner.GenerateEmptyTxBallot(localNode, basis, ballot.StateSIGN, localNode, conf)
+		ptx := proposed.ProposerTransaction()
+
+		r := basis
+		r.Height++
+		r.TotalTxs++
+		r.TotalOps += uint64(len(ptx.B.Operations))
+		blk := *block.NewBlock(localNode.Address(), r, ptx.GetHash(), []string{}, proposed.ProposerConfirmed())
+
+		si := &SyncInfo{
+			Height: blk.Height,
+			Block:  &blk,
+			Ptx:    &ptx,
+		}
+		require.NoError(t, v.Validate(ctx, si))
+
+		synced, err := block.GetBlockByHeight(st, blk.Height)
+		require.NoError(t, err)
+		require.Equal(t, blk.Hash, synced.Hash)
+
+		_, err = block.GetBlockCertificate(st, blk.Hash)
+		require.Equal(t, errors.BlockCertificateDoesNotExists, err)
+	}
+}
@@ -19,6 +19,24 @@ type Checker struct {
 	Conf        common.Config
 }
 
+// CheckVersion checks the version of transaction is known and the
+// `common.TransactionVersionV1` transaction does not have the fields of
+// `common.TransactionVersionV2`. Whether the version is accepted at the
+// current block is checked with the state.
+func CheckVersion(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+
+	tx := checker.Transaction
+	if !common.IsKnownTransactionVersion(tx.H.Version) {
+		return errors.InvalidMessageVersion
+	}
+	if tx.IsValidVersion(common.TransactionVersionV1) && tx.HasV2Fields() {
+		return errors.TransactionRequiresV2
+	}
+
+	return
+}
+
 func CheckSource(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 	if _, err = keypair.Parse(checker.Transaction.B.Source); err != nil {
@@ -29,6 +47,16 @@ func CheckSource(c common.Checker, args ...interface{}) (err error) {
 	return
 }
 
+func CheckBaseFee(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	if checker.Transaction.B.Fee < checker.Transaction.TotalBaseFee() {
+		err = errors.InvalidFee
+		return
+	}
+
+	return
+}
+
 func CheckOverOperationsLimit(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
@@ -40,13 +68,60 @@ func CheckOverOperationsLimit(c common.Checker, args ...interface{}) (err error)
 	return
 }
 
-func CheckBaseFee(c common.Checker, args ...interface{}) (err error) {
+func CheckTimeBounds(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
-	if checker.Transaction.B.Fee < checker.Transaction.TotalBaseFee() {
-		err = errors.InvalidFee
+	if checker.Transaction.B.TimeBounds == nil {
 		return
 	}
 
+	return checker.Transaction.B.TimeBounds.IsWellFormed()
+}
+
+func CheckMemo(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+	if checker.Transaction.B.Memo == nil {
+		return
+	}
+
+	return checker.Transaction.B.Memo.IsWellFormed()
+}
+
+// CheckFeePayer checks the fee payer of `FeeBump` envelope is not the source
+// and the signatures of envelope are valid. Whether the signatures reach the
+// threshold of fee payer is checked with the stored account.
+func CheckFeePayer(c common.Checker, args ...interface{}) (err error) {
+	checker := c.(*Checker)
+
+	tx := checker.Transaction
+	if !tx.HasFeePayer() {
+		return
+	}
+
+	if _, err = keypair.Parse(tx.F.FeePayer); err != nil {
+		return errors.InvalidFeePayer
+	}
+	if tx.F.FeePayer == tx.B.Source {
+		return errors.InvalidFeePayer
+	}
+	if len(tx.F.Signatures) < 1 {
+		return errors.SignatureVerificationFailed
+	}
+	if len(tx.F.Signatures) > common.MaxSignersInAccount {
+		return errors.TransactionHasOverMaxSignatures
+	}
+
+	signers := map[string]bool{}
+	for _, s := range tx.F.Signatures {
+		if _, found := signers[s.Signer]; found {
+			return errors.DuplicatedSigner
+		}
+		signers[s.Signer] = true
+
+		if err = verifySignature(checker.NetworkID, tx.H.Hash, s.Signer, s.Signature); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
@@ -71,7 +146,37 @@ func CheckOperations(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
 	var hashes []string
-	for _, op := range checker.Transaction.B.Operations {
+	ops := checker.Transaction.B.Operations
+	for i, op := range ops {
+		if _, ok := op.B.(operation.Payable); !ok && operation.IsCheckedOperation(op.H.Type) {
+			if err = op.IsWellFormed(checker.Conf); err != nil {
+				return
+			}
+		}
+
+		// after account merge, the source account does not exist
+		if op.H.Type == operation.TypeAccountMerge {
+			if i != len(ops)-1 {
+				err = errors.InvalidOperation
+				return
+			}
+			if checker.Transaction.B.Source == op.B.(operation.AccountMerge).TargetAddress() {
+				err = errors.InvalidOperation
+				return
+			}
+		}
+
+		// the same key can not be managed twice in one transaction
+		if md, ok := op.B.(operation.ManageData); ok {
+			u := fmt.Sprintf("%s-%s", op.H.Type, md.Key)
+			if _, found := common.InStringArray(hashes, u); found {
+				err = errors.DuplicatedOperation
+				return
+			}
+
+			hashes = append(hashes, u)
+		}
+
 		if pop, ok := op.B.(operation.Payable); ok {
 			if checker.Transaction.B.Source == pop.TargetAddress() {
 				err = errors.InvalidOperation
@@ -98,16 +203,51 @@ func CheckOperations(c common.Checker, args ...interface{}) (err error) {
 func CheckVerifySignature(c common.Checker, args ...interface{}) (err error) {
 	checker := c.(*Checker)
 
-	var kp keypair.KP
-	if kp, err = keypair.Parse(checker.Transaction.B.Source); err != nil {
+	tx := checker.Transaction
+	if len(tx.H.Signature) < 1 && len(tx.H.Signatures) < 1 {
+		err = errors.SignatureVerificationFailed
 		return
 	}
-	err = kp.Verify(
-		append(checker.NetworkID, []byte(checker.Transaction.H.Hash)...),
-		base58.Decode(checker.Transaction.H.Signature),
-	)
-	if err != nil {
+	if len(tx.H.Signatures) > common.MaxSignersInAccount {
+		err = errors.TransactionHasOverMaxSignatures
 		return
 	}
+
+	// the signers of source sign only the `Body`
+	hash := tx.BodyHash()
+	if len(tx.H.Signature) > 0 {
+		if err = verifySignature(checker.NetworkID, hash, tx.B.Source, tx.H.Signature); err != nil {
+			return
+		}
+	}
+
+	signers := map[string]bool{}
+	if len(tx.H.Signature) > 0 {
+		signers[tx.B.Source] = true
+	}
+	for _, s := range tx.H.Signatures {
+		if _, found := signers[s.Signer]; found {
+			err = errors.DuplicatedSigner
+			return
+		}
+		signers[s.Signer] = true
+
+		if err = verifySignature(checker.NetworkID, hash, s.Signer, s.Signature); err != nil {
+			return
+		}
+	}
+
 	return
 }
+
+func verifySignature(networkID []byte, hash, signer, signature string) (err error) {
+	var kp keypair.KP
+	if kp, err = keypair.Parse(signer); err != nil {
+		return
+	}
+
+	return kp.Verify(
+		append(networkID, []byte(hash)...),
+		base58.Decode(signature),
+	)
+}
@@ -0,0 +1,34 @@
+package transaction
+
+import (
+	"github.com/btcsuite/btcutil/base58"
+
+	"boscoin.io/sebak/lib/common"
+)
+
+// FeeBump is the envelope of the fee payer around the signed transaction. The
+// fee payer pays the `Body.Fee` instead of `Body.Source`. The source signs
+// only the `Body`, so any account can sponsor the already signed
+// transaction.
+type FeeBump struct {
+	FeePayer string `json:"fee_payer"`
+	// Signatures has the signatures of the signers of `FeePayer`; they sign
+	// the hash of the envelope, `MakeHashString`.
+	Signatures []Signature `json:"signatures"`
+}
+
+// MakeHashString returns the hash of the envelope around the transaction of
+// `bodyHash`; it is used as the hash of the transaction.
+func (f FeeBump) MakeHashString(bodyHash string) string {
+	return base58.Encode(common.MustMakeObjectHash([]string{bodyHash, f.FeePayer}))
+}
+
+// Signers returns the addresses, which signed the envelope.
+func (f FeeBump) Signers() []string {
+	var signers []string
+	for _, s := range f.Signatures {
+		signers = append(signers, s.Signer)
+	}
+
+	return signers
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeAccountMerge,
+		Name:           "account-merge",
+		NewBody:        func() Body { return &AccountMerge{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdHigh,
+	})
+}
+
+// AccountMerge transfers the whole balance of the source account to `Target`
+// and deletes the source account. The historical transactions and operations
+// of the source account are kept. It must be the last operation of
+// transaction.
+type AccountMerge struct {
+	Target string `json:"target"`
+}
+
+func NewAccountMerge(target string) AccountMerge {
+	return AccountMerge{
+		Target: target,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o AccountMerge) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	return
+}
+
+func (o AccountMerge) TargetAddress() string {
+	return o.Target
+}
+
+func (o AccountMerge) HasFee() bool {
+	return true
+}
@@ -0,0 +1,161 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeTrust,
+		Name:           "trust",
+		NewBody:        func() Body { return &Trust{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeIssueAsset,
+		Name:           "issue-asset",
+		NewBody:        func() Body { return &IssueAsset{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeAssetPayment,
+		Name:           "asset-payment",
+		NewBody:        func() Body { return &AssetPayment{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// Trust creates or updates the trustline of the source account for `Asset`,
+// so the source account can hold the asset up to `Limit`. If `Limit` is 0,
+// the trustline is removed; it's balance must be 0.
+type Trust struct {
+	Asset common.Asset  `json:"asset"`
+	Limit common.Amount `json:"limit"`
+}
+
+func NewTrust(asset common.Asset, limit common.Amount) Trust {
+	return Trust{
+		Asset: asset,
+		Limit: limit,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o Trust) IsWellFormed(common.Config) (err error) {
+	return isWellFormedAsset(o.Asset)
+}
+
+// IsRemove returns true if the operation removes the trustline.
+func (o Trust) IsRemove() bool {
+	return o.Limit < 1
+}
+
+// TargetAddress returns the issuer of asset, so the trust can be found by the
+// operations of the issuer.
+func (o Trust) TargetAddress() string {
+	return o.Asset.Issuer
+}
+
+func (o Trust) HasFee() bool {
+	return true
+}
+
+// IssueAsset issues the `Amount` of the asset of `Code` by the source account
+// to `Target`. `Target` must trust the asset.
+type IssueAsset struct {
+	Code   string        `json:"code"`
+	Target string        `json:"target"`
+	Amount common.Amount `json:"amount"`
+}
+
+func NewIssueAsset(code, target string, amount common.Amount) IssueAsset {
+	return IssueAsset{
+		Code:   code,
+		Target: target,
+		Amount: amount,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o IssueAsset) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		return errors.OperationAmountUnderflow
+	}
+
+	if !common.IsValidAssetCode(o.Code) {
+		return errors.InvalidAsset
+	}
+
+	return
+}
+
+// Asset returns the asset issued by the issuer.
+func (o IssueAsset) Asset(issuer string) common.Asset {
+	return common.NewAsset(o.Code, issuer)
+}
+
+func (o IssueAsset) TargetAddress() string {
+	return o.Target
+}
+
+func (o IssueAsset) HasFee() bool {
+	return true
+}
+
+// AssetPayment sends the `Amount` of `Asset` from the source account to
+// `Target`. Both of them must trust the asset, except the issuer; the asset
+// sent to the issuer is redeemed.
+type AssetPayment struct {
+	Target string        `json:"target"`
+	Asset  common.Asset  `json:"asset"`
+	Amount common.Amount `json:"amount"`
+}
+
+func NewAssetPayment(target string, asset common.Asset, amount common.Amount) AssetPayment {
+	return AssetPayment{
+		Target: target,
+		Asset:  asset,
+		Amount: amount,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o AssetPayment) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		return errors.OperationAmountUnderflow
+	}
+
+	return isWellFormedAsset(o.Asset)
+}
+
+func (o AssetPayment) TargetAddress() string {
+	return o.Target
+}
+
+func (o AssetPayment) HasFee() bool {
+	return true
+}
+
+func isWellFormedAsset(asset common.Asset) (err error) {
+	if err = asset.IsWellFormed(); err != nil {
+		return
+	}
+	if _, err = keypair.Parse(asset.Issuer); err != nil {
+		return errors.InvalidAsset
+	}
+
+	return
+}
@@ -0,0 +1,75 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestAssetOperations(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	issuer := keypair.Random().Address()
+	target := keypair.Random().Address()
+	asset := common.NewAsset("POINT", issuer)
+
+	{ // trust
+		o := NewTrust(asset, common.Amount(1000))
+		require.NoError(t, o.IsWellFormed(conf))
+		require.False(t, o.IsRemove())
+		require.True(t, NewTrust(asset, 0).IsRemove())
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeTrust, op.H.Type)
+		// the trust is found by the operations of issuer
+		require.Equal(t, issuer, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // issue
+		o := NewIssueAsset("POINT", target, common.Amount(100))
+		require.NoError(t, o.IsWellFormed(conf))
+		require.Equal(t, asset, o.Asset(issuer))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeIssueAsset, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // payment
+		o := NewAssetPayment(target, asset, common.Amount(100))
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeAssetPayment, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+
+		// the asset payment is not paid by the native coin
+		_, ok := op.B.(Payable)
+		require.False(t, ok)
+	}
+
+	invalids := []Body{
+		NewTrust(common.NewAsset("", issuer), common.Amount(1000)),
+		NewTrust(common.NewAsset("POINT!", issuer), common.Amount(1000)),
+		NewTrust(common.NewAsset("ABCDEFGHIJKLM", issuer), common.Amount(1000)),
+		NewTrust(common.NewAsset("POINT", "showmethemoney"), common.Amount(1000)),
+		NewIssueAsset("", target, common.Amount(100)),
+		NewAssetPayment(target, common.NewAsset("POINT", ""), common.Amount(100)),
+	}
+	for _, o := range invalids {
+		require.Equal(t, errors.InvalidAsset, o.IsWellFormed(conf), "operation: %v", o)
+	}
+
+	require.Equal(t, errors.OperationAmountUnderflow, NewIssueAsset("POINT", target, 0).IsWellFormed(conf))
+	require.Equal(t, errors.OperationAmountUnderflow, NewAssetPayment(target, asset, 0).IsWellFormed(conf))
+}
@@ -6,6 +6,16 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeCollectTxFee,
+		Name:           "collect-tx-fee",
+		NewBody:        func() Body { return &CollectTxFee{} },
+		Normal:         false,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
 // OperationBodyTransactionFee is the operation to send the collected transacton
 // fee to certain account. To prevent the hash duplication of transaction,
 // OperationBodyTransactionFee has block related data.
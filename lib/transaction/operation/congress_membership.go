@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeCongressMembership,
+		Name:           "congress-membership",
+		NewBody:        func() Body { return &CongressMembership{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// CongressMembership adds `Target` to the congress members or removes it from
+// them. Only the members can cast `CongressVote`.
+type CongressMembership struct {
+	Target string `json:"target"`
+	Remove bool   `json:"remove"`
+}
+
+func NewCongressMembership(target string, remove bool) CongressMembership {
+	return CongressMembership{
+		Target: target,
+		Remove: remove,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o CongressMembership) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	return
+}
+
+func (o CongressMembership) TargetAddress() string {
+	return o.Target
+}
+
+func (o CongressMembership) HasFee() bool {
+	return true
+}
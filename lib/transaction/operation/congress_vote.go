@@ -0,0 +1,65 @@
+package operation
+
+import (
+	"strconv"
+	"strings"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeCongressVote,
+		Name:           "congress-vote",
+		NewBody:        func() Body { return &CongressVote{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+type VoteAnswer string
+
+const (
+	VoteYes VoteAnswer = "yes"
+	VoteNo  VoteAnswer = "no"
+	VoteABS VoteAnswer = "abs"
+)
+
+// CongressVote casts the vote of congress member to the `CongressVoting`.
+// The vote is counted by the node and `CongressVotingResult` must be matched
+// with the counted.
+type CongressVote struct {
+	CongressVotingHash string     `json:"congress_voting_hash"`
+	Vote               VoteAnswer `json:"vote"`
+}
+
+func NewCongressVote(congressVotingHash string, vote VoteAnswer) CongressVote {
+	return CongressVote{
+		CongressVotingHash: congressVotingHash,
+		Vote:               vote,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o CongressVote) IsWellFormed(common.Config) (err error) {
+	switch o.Vote {
+	case VoteYes, VoteNo, VoteABS:
+	default:
+		return errors.InvalidOperation
+	}
+
+	parsedCongressVotingHash := strings.Split(o.CongressVotingHash, "-") //0:TxHash, 1:Index
+	if len(parsedCongressVotingHash) != 2 {
+		return errors.InvalidOperation
+	}
+	if _, err := strconv.Atoi(parsedCongressVotingHash[1]); err != nil {
+		return errors.InvalidOperation.Clone().SetData("error", err)
+	}
+
+	return
+}
+
+func (o CongressVote) HasFee() bool {
+	return true
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestCongressVote(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	for _, vote := range []VoteAnswer{VoteYes, VoteNo, VoteABS} {
+		o := NewCongressVote("txhash-0", vote)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeCongressVote, op.H.Type)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	require.Equal(t, errors.InvalidOperation, NewCongressVote("txhash-0", "maybe").IsWellFormed(conf))
+	require.Equal(t, errors.InvalidOperation, NewCongressVote("txhash", VoteYes).IsWellFormed(conf))
+	require.Error(t, NewCongressVote("txhash-a", VoteYes).IsWellFormed(conf))
+}
+
+func TestCongressMembership(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	target := keypair.Random().Address()
+	for _, remove := range []bool{false, true} {
+		o := NewCongressMembership(target, remove)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeCongressMembership, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	require.Error(t, NewCongressMembership("invalid", false).IsWellFormed(conf))
+}
@@ -5,6 +5,17 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeCongressVoting,
+		Name:           "congress-voting",
+		NewBody:        func() Body { return &CongressVoting{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+		Unchecked:      true,
+	})
+}
+
 type CongressVoting struct {
 	Contract string `json:"contract"`
 	Voting   struct {
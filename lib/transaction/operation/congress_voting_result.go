@@ -8,6 +8,17 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeCongressVotingResult,
+		Name:           "congress-voting-result",
+		NewBody:        func() Body { return &CongressVotingResult{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+		Unchecked:      true,
+	})
+}
+
 type CongressVotingResult struct {
 	BallotStamps struct {
 		Hash string   `json:"hash"`
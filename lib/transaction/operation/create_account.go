@@ -6,6 +6,20 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeCreateAccount,
+		Name:           "create-account",
+		NewBody:        func() Body { return &CreateAccount{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+		Index: func(opb Body) Index {
+			o := opb.(CreateAccount)
+			return Index{Target: o.Target, Linked: o.Linked}
+		},
+	})
+}
+
 type CreateAccount struct {
 	Target string        `json:"target"`
 	Amount common.Amount `json:"amount"`
@@ -0,0 +1,82 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeCreateVestingAccount,
+		Name:           "create-vesting-account",
+		NewBody:        func() Body { return &CreateVestingAccount{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+		Index: func(opb Body) Index {
+			o := opb.(CreateVestingAccount)
+			return Index{Target: o.Target, Linked: o.Linked}
+		},
+	})
+}
+
+// CreateVestingAccount creates the account, which can spend it's initial
+// `Amount` only as it is vested by the schedule of `Cliff` and `End` block
+// height. Like the frozen account, it is linked to `Linked` and it can be
+// found in the frozen accounts of `Linked`.
+type CreateVestingAccount struct {
+	Target string        `json:"target"`
+	Amount common.Amount `json:"amount"`
+	Linked string        `json:"linked"`
+	Cliff  uint64        `json:"cliff"`
+	End    uint64        `json:"end"`
+}
+
+func NewCreateVestingAccount(target string, amount common.Amount, linked string, cliff, end uint64) CreateVestingAccount {
+	return CreateVestingAccount{
+		Target: target,
+		Amount: amount,
+		Linked: linked,
+		Cliff:  cliff,
+		End:    end,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o CreateVestingAccount) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+	if _, err = keypair.Parse(o.Linked); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		err = errors.OperationAmountUnderflow
+		return
+	}
+
+	if o.Amount < common.BaseReserve {
+		err = errors.InsufficientAmountNewAccount
+		return
+	}
+
+	return o.Vesting().IsWellFormed()
+}
+
+// Vesting returns the vesting schedule of the new account.
+func (o CreateVestingAccount) Vesting() common.Vesting {
+	return common.NewVesting(o.Cliff, o.End, o.Amount)
+}
+
+func (o CreateVestingAccount) TargetAddress() string {
+	return o.Target
+}
+
+func (o CreateVestingAccount) GetAmount() common.Amount {
+	return o.Amount
+}
+
+func (o CreateVestingAccount) HasFee() bool {
+	return true
+}
@@ -0,0 +1,38 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestCreateVestingAccount(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	target := keypair.Random().Address()
+	linked := keypair.Random().Address()
+	amount := common.BaseReserve.MustAdd(100)
+
+	o := NewCreateVestingAccount(target, amount, linked, 10, 20)
+	require.NoError(t, o.IsWellFormed(conf))
+	require.Equal(t, common.NewVesting(10, 20, amount), o.Vesting())
+	require.Equal(t, amount, o.GetAmount())
+
+	op, err := NewOperation(o)
+	require.NoError(t, err)
+	require.Equal(t, TypeCreateVestingAccount, op.H.Type)
+
+	index := GetIndex(op.H.Type, op.B)
+	require.Equal(t, target, index.Target)
+	require.Equal(t, linked, index.Linked)
+	common.CheckRoundTripRLP(t, op)
+
+	require.Error(t, NewCreateVestingAccount(target, amount, "", 10, 20).IsWellFormed(conf))
+	require.Equal(t, errors.InsufficientAmountNewAccount, NewCreateVestingAccount(target, common.Amount(1), linked, 10, 20).IsWellFormed(conf))
+	require.Equal(t, errors.InvalidVesting, NewCreateVestingAccount(target, amount, linked, 20, 10).IsWellFormed(conf))
+	require.Equal(t, errors.InvalidVesting, NewCreateVestingAccount(target, amount, linked, 0, 0).IsWellFormed(conf))
+}
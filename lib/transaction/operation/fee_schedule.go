@@ -0,0 +1,131 @@
+package operation
+
+import (
+	"io"
+	"sort"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// FeeSchedule is the fee of each type of operation. It is set at genesis by
+// `NetworkParameters`, so every node charges the same fee.
+//
+// The operation, which does not have fee by `Body.HasFee`, like freezing and
+// unfreezing, costs `Frozen`. The other operation costs the fee of it's type
+// in `Operations`, or `Base` if it's type is not in `Operations`.
+type FeeSchedule struct {
+	Base       common.Amount                   `json:"base"`
+	Frozen     common.Amount                   `json:"frozen"`
+	Operations map[OperationType]common.Amount `json:"operations,omitempty"`
+}
+
+// NewFeeSchedule returns the default `FeeSchedule`, which charges
+// `common.BaseFee` for every operation.
+func NewFeeSchedule() FeeSchedule {
+	return FeeSchedule{
+		Base:   common.BaseFee,
+		Frozen: common.FrozenFee,
+	}
+}
+
+// Fee returns the fee of operation.
+func (fs FeeSchedule) Fee(op Operation) common.Amount {
+	if !op.HasFee() {
+		return fs.Frozen
+	}
+	if fee, found := fs.Operations[op.H.Type]; found {
+		return fee
+	}
+
+	return fs.Base
+}
+
+// TotalFee returns the sum of fees of operations.
+func (fs FeeSchedule) TotalFee(ops []Operation) common.Amount {
+	var fee common.Amount
+	for _, op := range ops {
+		fee = fee.MustAdd(fs.Fee(op))
+	}
+
+	return fee
+}
+
+// IsDefault returns true if the schedule is same with `NewFeeSchedule`.
+func (fs FeeSchedule) IsDefault() bool {
+	return fs.Base == common.BaseFee && fs.Frozen == common.FrozenFee && len(fs.Operations) < 1
+}
+
+// IsWellFormed checks the operation types are known and can be included in
+// the normal transaction. The fee of operation can not be lower than
+// `common.BaseFee`, which is checked by `transaction.CheckBaseFee` without
+// the schedule.
+func (fs FeeSchedule) IsWellFormed() error {
+	if fs.Base < common.BaseFee {
+		return errors.InvalidFee
+	}
+
+	for t, fee := range fs.Operations {
+		if !IsNormalOperation(t) {
+			return errors.InvalidOperation
+		}
+		if fee < common.BaseFee {
+			return errors.InvalidFee
+		}
+	}
+
+	return nil
+}
+
+// feeScheduleRLP is the RLP form of `FeeSchedule`; RLP can not encode map, so
+// `Operations` is encoded as list ordered by type.
+type feeScheduleRLP struct {
+	Base       common.Amount
+	Frozen     common.Amount
+	Operations []operationFeeRLP
+}
+
+type operationFeeRLP struct {
+	Type string
+	Fee  common.Amount
+}
+
+// Implement `common.Encoder`
+func (fs FeeSchedule) EncodeRLP(w io.Writer) error {
+	var types []OperationType
+	for t := range fs.Operations {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	e := feeScheduleRLP{Base: fs.Base, Frozen: fs.Frozen, Operations: []operationFeeRLP{}}
+	for _, t := range types {
+		e.Operations = append(e.Operations, operationFeeRLP{Type: t.String(), Fee: fs.Operations[t]})
+	}
+
+	return common.Encode(w, e)
+}
+
+// Implement `common.Decoder`
+func (fs *FeeSchedule) DecodeRLP(s *common.RLPStream) error {
+	var e feeScheduleRLP
+	if err := s.Decode(&e); err != nil {
+		return err
+	}
+
+	fs.Base = e.Base
+	fs.Frozen = e.Frozen
+	fs.Operations = nil
+	for _, o := range e.Operations {
+		var t OperationType
+		if err := t.UnmarshalText([]byte(o.Type)); err != nil {
+			return err
+		}
+		if fs.Operations == nil {
+			fs.Operations = map[OperationType]common.Amount{}
+		}
+		fs.Operations[t] = o.Fee
+	}
+
+	return nil
+}
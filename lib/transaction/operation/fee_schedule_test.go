@@ -0,0 +1,49 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestFeeSchedule(t *testing.T) {
+	payment, _ := NewOperation(NewPayment(keypair.Random().Address(), common.Amount(1)))
+	manageData, _ := NewOperation(NewManageData("key", "value"))
+	unfreeze, _ := NewOperation(NewUnfreezeRequest())
+
+	fs := NewFeeSchedule()
+	require.NoError(t, fs.IsWellFormed())
+	require.Equal(t, common.BaseFee, fs.Fee(payment))
+	require.Equal(t, common.FrozenFee, fs.Fee(unfreeze))
+
+	fs.Frozen = common.Amount(1)
+	fs.Operations = map[OperationType]common.Amount{TypePayment: common.BaseFee * 2}
+	require.NoError(t, fs.IsWellFormed())
+	require.Equal(t, common.BaseFee*2, fs.Fee(payment))
+	require.Equal(t, common.BaseFee, fs.Fee(manageData))
+	require.Equal(t, common.Amount(1), fs.Fee(unfreeze))
+	require.Equal(t, common.BaseFee*3+1, fs.TotalFee([]Operation{payment, manageData, unfreeze}))
+
+	var fetched FeeSchedule
+	common.MustUnmarshalJSON(common.MustMarshalJSON(fs), &fetched)
+	require.Equal(t, fs, fetched)
+
+	common.CheckRoundTripRLP(t, fs)
+	params := NewNetworkParameters()
+	params.FeeSchedule = fs
+	op, _ := NewOperation(params)
+	common.CheckRoundTripRLP(t, op)
+
+	// the fee can not be lower than `common.BaseFee`
+	fs.Operations[TypePayment] = common.BaseFee - 1
+	require.Equal(t, errors.InvalidFee, fs.IsWellFormed())
+	fs.Operations[TypePayment] = common.BaseFee
+
+	// the operation of proposer transaction can not be in the schedule
+	fs.Operations[TypeCollectTxFee] = common.Amount(0)
+	require.Equal(t, errors.InvalidOperation, fs.IsWellFormed())
+}
@@ -0,0 +1,172 @@
+package operation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeHashLockCreate,
+		Name:           "hash-lock-create",
+		NewBody:        func() Body { return &HashLockCreate{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeHashLockClaim,
+		Name:           "hash-lock-claim",
+		NewBody:        func() Body { return &HashLockClaim{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeHashLockRefund,
+		Name:           "hash-lock-refund",
+		NewBody:        func() Body { return &HashLockRefund{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// HashLockCreate locks the `Amount` of the source account for `Target`. The
+// locked amount can be claimed by `Target` with the preimage of `HashLock`
+// until the block height `Expiry`, after then it can be refunded to the source
+// account.
+type HashLockCreate struct {
+	Target   string        `json:"target"`
+	Amount   common.Amount `json:"amount"`
+	HashLock string        `json:"hash_lock"` // hex encoded SHA-256 hash of preimage
+	Expiry   uint64        `json:"expiry"`    // block height
+}
+
+func NewHashLockCreate(target string, amount common.Amount, hashLock string, expiry uint64) HashLockCreate {
+	return HashLockCreate{
+		Target:   target,
+		Amount:   amount,
+		HashLock: hashLock,
+		Expiry:   expiry,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o HashLockCreate) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		return errors.OperationAmountUnderflow
+	}
+
+	if b, err := hex.DecodeString(o.HashLock); err != nil || len(b) != sha256.Size {
+		return errors.InvalidHashLock
+	}
+
+	if o.Expiry < 1 {
+		return errors.InvalidHashLock
+	}
+
+	return
+}
+
+func (o HashLockCreate) TargetAddress() string {
+	return o.Target
+}
+
+func (o HashLockCreate) GetAmount() common.Amount {
+	return o.Amount
+}
+
+func (o HashLockCreate) HasFee() bool {
+	return true
+}
+
+// HashLockClaim claims the locked amount by `Locker` to the source account
+// with the preimage of hash lock. The source account must be the target of
+// lock.
+type HashLockClaim struct {
+	Locker   string `json:"locker"`
+	Preimage string `json:"preimage"` // hex encoded
+}
+
+func NewHashLockClaim(locker, preimage string) HashLockClaim {
+	return HashLockClaim{
+		Locker:   locker,
+		Preimage: preimage,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o HashLockClaim) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Locker); err != nil {
+		return
+	}
+
+	if b, err := hex.DecodeString(o.Preimage); err != nil || len(b) < 1 || len(b) > common.MaxHashLockPreimageLength {
+		return errors.InvalidHashLock
+	}
+
+	return
+}
+
+// TargetAddress returns the `Locker`, so the claim can be found by the
+// operations of the locker.
+func (o HashLockClaim) TargetAddress() string {
+	return o.Locker
+}
+
+// HashLock returns the hash lock of `Preimage`.
+func (o HashLockClaim) HashLock() string {
+	b, _ := hex.DecodeString(o.Preimage)
+	return MakeHashLock(b)
+}
+
+func (o HashLockClaim) HasFee() bool {
+	return true
+}
+
+// HashLockRefund refunds the expired lock for `Target` to the source account,
+// which created the lock.
+type HashLockRefund struct {
+	Target   string `json:"target"`
+	HashLock string `json:"hash_lock"`
+}
+
+func NewHashLockRefund(target, hashLock string) HashLockRefund {
+	return HashLockRefund{
+		Target:   target,
+		HashLock: hashLock,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o HashLockRefund) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if b, err := hex.DecodeString(o.HashLock); err != nil || len(b) != sha256.Size {
+		return errors.InvalidHashLock
+	}
+
+	return
+}
+
+func (o HashLockRefund) TargetAddress() string {
+	return o.Target
+}
+
+func (o HashLockRefund) HasFee() bool {
+	return true
+}
+
+// MakeHashLock returns the hex encoded SHA-256 hash of preimage.
+func MakeHashLock(preimage []byte) string {
+	h := sha256.Sum256(preimage)
+	return hex.EncodeToString(h[:])
+}
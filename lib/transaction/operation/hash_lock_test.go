@@ -0,0 +1,72 @@
+package operation
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestHashLockOperations(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	locker := keypair.Random().Address()
+	target := keypair.Random().Address()
+	preimage := []byte("showmethemoney")
+	hashLock := MakeHashLock(preimage)
+
+	{ // create
+		o := NewHashLockCreate(target, common.Amount(100), hashLock, 10)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeHashLockCreate, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // claim
+		o := NewHashLockClaim(locker, hex.EncodeToString(preimage))
+		require.NoError(t, o.IsWellFormed(conf))
+		require.Equal(t, hashLock, o.HashLock())
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeHashLockClaim, op.H.Type)
+		// the claim is found by the operations of locker
+		require.Equal(t, locker, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // refund
+		o := NewHashLockRefund(target, hashLock)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeHashLockRefund, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	invalids := []Body{
+		NewHashLockCreate(target, common.Amount(100), "showmethemoney", 10),
+		NewHashLockCreate(target, common.Amount(100), hashLock[:10], 10),
+		NewHashLockCreate(target, common.Amount(100), hashLock, 0),
+		NewHashLockClaim(locker, "not-hex"),
+		NewHashLockClaim(locker, ""),
+		NewHashLockClaim(locker, strings.Repeat("00", common.MaxHashLockPreimageLength+1)),
+		NewHashLockRefund(target, ""),
+	}
+	for _, o := range invalids {
+		require.Equal(t, errors.InvalidHashLock, o.IsWellFormed(conf), "operation: %v", o)
+	}
+
+	require.Equal(t, errors.OperationAmountUnderflow, NewHashLockCreate(target, common.Amount(0), hashLock, 10).IsWellFormed(conf))
+}
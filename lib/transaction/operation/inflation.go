@@ -8,6 +8,16 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeInflation,
+		Name:           "inflation",
+		NewBody:        func() Body { return &Inflation{} },
+		Normal:         false,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
 // Inflation is the operation to raise inflation in every block. To
 // prevent the hash duplication of transaction, Inflation has block
 // related data.
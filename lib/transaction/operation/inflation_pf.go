@@ -8,6 +8,16 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeInflationPF,
+		Name:           "inflation-pf",
+		NewBody:        func() Body { return &InflationPF{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
 type InflationPF struct {
 	FundingAddress string        `json:"funding_address"`
 	Amount         common.Amount `json:"amount"`
@@ -0,0 +1,56 @@
+package operation
+
+import (
+	"unicode/utf8"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeManageData,
+		Name:           "manage-data",
+		NewBody:        func() Body { return &ManageData{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// ManageData sets the data entry of the source account. If `Value` is empty,
+// the entry of `Key` is deleted.
+type ManageData struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func NewManageData(key, value string) ManageData {
+	return ManageData{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ManageData) IsWellFormed(common.Config) (err error) {
+	if len(o.Key) < 1 || len(o.Key) > common.MaxDataKeyLength {
+		return errors.InvalidDataEntry
+	}
+	if len(o.Value) > common.MaxDataValueLength {
+		return errors.InvalidDataEntry
+	}
+	if !utf8.ValidString(o.Key) || !utf8.ValidString(o.Value) {
+		return errors.InvalidDataEntry
+	}
+
+	return
+}
+
+// IsDelete returns true if the operation deletes the entry.
+func (o ManageData) IsDelete() bool {
+	return len(o.Value) < 1
+}
+
+func (o ManageData) HasFee() bool {
+	return true
+}
@@ -0,0 +1,42 @@
+package operation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestManageDataOperation(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	{ // set
+		o := NewManageData("home-domain", "boscoin.io")
+		require.NoError(t, o.IsWellFormed(conf))
+		require.False(t, o.IsDelete())
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeManageData, op.H.Type)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // delete
+		o := NewManageData("home-domain", "")
+		require.NoError(t, o.IsWellFormed(conf))
+		require.True(t, o.IsDelete())
+	}
+
+	invalids := []ManageData{
+		NewManageData("", "boscoin.io"),
+		NewManageData(strings.Repeat("k", common.MaxDataKeyLength+1), "boscoin.io"),
+		NewManageData("home-domain", strings.Repeat("v", common.MaxDataValueLength+1)),
+		NewManageData("home-domain", string([]byte{0xff, 0xfe})),
+	}
+	for _, o := range invalids {
+		require.Equal(t, errors.InvalidDataEntry, o.IsWellFormed(conf), "entry: %v", o)
+	}
+}
@@ -0,0 +1,74 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeManageSigners,
+		Name:           "manage-signers",
+		NewBody:        func() Body { return &ManageSigners{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdHigh,
+	})
+}
+
+// ManageSigners replaces the signers and thresholds of the source account. If
+// `Signers` is empty, the account goes back to be signed only by it's own
+// key.
+type ManageSigners struct {
+	Signers    []common.Signer   `json:"signers"`
+	Thresholds common.Thresholds `json:"thresholds"`
+}
+
+func NewManageSigners(signers []common.Signer, thresholds common.Thresholds) ManageSigners {
+	return ManageSigners{
+		Signers:    signers,
+		Thresholds: thresholds,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ManageSigners) IsWellFormed(common.Config) (err error) {
+	if len(o.Signers) > common.MaxSignersInAccount {
+		return errors.InvalidOperation
+	}
+
+	if len(o.Signers) < 1 {
+		if o.Thresholds != (common.Thresholds{}) {
+			return errors.InvalidThresholds
+		}
+		return
+	}
+
+	var total uint64
+	signers := map[string]bool{}
+	for _, signer := range o.Signers {
+		if _, err = keypair.Parse(signer.Address); err != nil {
+			return
+		}
+		if signer.Weight < 1 {
+			return errors.InvalidOperation
+		}
+		if _, found := signers[signer.Address]; found {
+			return errors.DuplicatedSigner
+		}
+		signers[signer.Address] = true
+		total += uint64(signer.Weight)
+	}
+
+	// the signers must be able to reach every threshold, otherwise the account
+	// will be locked forever.
+	if total < uint64(o.Thresholds.Max()) {
+		return errors.InvalidThresholds
+	}
+
+	return
+}
+
+func (o ManageSigners) HasFee() bool {
+	return true
+}
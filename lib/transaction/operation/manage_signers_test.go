@@ -0,0 +1,73 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestManageSignersOperation(t *testing.T) {
+	kp0 := keypair.Random()
+	kp1 := keypair.Random()
+
+	conf := common.NewTestConfig()
+	{ // reset signers
+		o := NewManageSigners(nil, common.Thresholds{})
+		require.NoError(t, o.IsWellFormed(conf))
+	}
+
+	{ // thresholds without signers
+		o := NewManageSigners(nil, common.Thresholds{Low: 1})
+		require.Equal(t, errors.InvalidThresholds, o.IsWellFormed(conf))
+	}
+
+	{ // 2 of 2
+		o := NewManageSigners(
+			[]common.Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp1.Address(), Weight: 1}},
+			common.Thresholds{Low: 1, Medium: 2, High: 2},
+		)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeManageSigners, op.H.Type)
+		require.Equal(t, common.ThresholdHigh, op.H.Type.ThresholdLevel())
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // thresholds can not be reached
+		o := NewManageSigners(
+			[]common.Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp1.Address(), Weight: 1}},
+			common.Thresholds{Low: 1, Medium: 2, High: 3},
+		)
+		require.Equal(t, errors.InvalidThresholds, o.IsWellFormed(conf))
+	}
+
+	{ // duplicated signer
+		o := NewManageSigners(
+			[]common.Signer{{Address: kp0.Address(), Weight: 1}, {Address: kp0.Address(), Weight: 1}},
+			common.Thresholds{Low: 1, Medium: 1, High: 1},
+		)
+		require.Equal(t, errors.DuplicatedSigner, o.IsWellFormed(conf))
+	}
+
+	{ // zero weight
+		o := NewManageSigners(
+			[]common.Signer{{Address: kp0.Address(), Weight: 0}},
+			common.Thresholds{},
+		)
+		require.Equal(t, errors.InvalidOperation, o.IsWellFormed(conf))
+	}
+
+	{ // invalid address
+		o := NewManageSigners(
+			[]common.Signer{{Address: "invalid", Weight: 1}},
+			common.Thresholds{},
+		)
+		require.Error(t, o.IsWellFormed(conf))
+	}
+}
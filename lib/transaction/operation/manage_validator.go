@@ -0,0 +1,55 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeManageValidator,
+		Name:           "manage-validator",
+		NewBody:        func() Body { return &ManageValidator{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// ManageValidator adds `Target` to the validators or removes it from them.
+// The change is activated from the block of `ActivationHeight`, so every node
+// can switch the validators at the same height.
+type ManageValidator struct {
+	Target           string `json:"target"`
+	Remove           bool   `json:"remove"`
+	ActivationHeight uint64 `json:"activation_height"`
+}
+
+func NewManageValidator(target string, remove bool, activationHeight uint64) ManageValidator {
+	return ManageValidator{
+		Target:           target,
+		Remove:           remove,
+		ActivationHeight: activationHeight,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ManageValidator) IsWellFormed(common.Config) (err error) {
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if o.ActivationHeight < common.FirstProposedBlockHeight {
+		return errors.InvalidOperation
+	}
+
+	return
+}
+
+func (o ManageValidator) TargetAddress() string {
+	return o.Target
+}
+
+func (o ManageValidator) HasFee() bool {
+	return true
+}
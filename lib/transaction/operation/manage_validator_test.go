@@ -0,0 +1,30 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestManageValidator(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	target := keypair.Random().Address()
+	for _, remove := range []bool{false, true} {
+		o := NewManageValidator(target, remove, 10)
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeManageValidator, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	require.Error(t, NewManageValidator("invalid", false, 10).IsWellFormed(conf))
+	require.Equal(t, errors.InvalidOperation, NewManageValidator(target, false, common.GenesisBlockHeight).IsWellFormed(conf))
+}
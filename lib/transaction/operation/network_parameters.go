@@ -0,0 +1,148 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeNetworkParameters,
+		Name:           "network-parameters",
+		NewBody:        func() Body { return &NetworkParameters{} },
+		Normal:         false,
+		ThresholdLevel: common.ThresholdHigh,
+	})
+}
+
+// NetworkParameters is the parameters, which every node of network must
+// agree on. It is included only in the transaction of genesis block, so the
+// parameters are the part of the hash of genesis block and the node, which
+// has the different parameters, can not join the network.
+//
+// The genesis block of network, which uses the default parameters, does not
+// have it.
+type NetworkParameters struct {
+	FeeSchedule FeeSchedule `json:"fee-schedule"`
+	// TxV2ActivationHeight is the height of block, from which
+	// `common.TransactionVersionV2` is accepted; 0 means it is not activated.
+	TxV2ActivationHeight uint64 `json:"tx-v2-activation-height"`
+	// CertificateActivationHeight is the height of block, from which the
+	// block must have the certificate, the ACCEPT ballots of validators. The
+	// blocks before it are validated only by the previous block; 0 means the
+	// certificate is not required.
+	CertificateActivationHeight uint64 `json:"certificate-activation-height"`
+	// ProposerMissLimit is the number of the last proposals; the validator,
+	// which missed them all, is not selected as proposer. 0 means no limit;
+	// see `consensus.LivenessSelector`.
+	ProposerMissLimit uint64 `json:"proposer-miss-limit"`
+	// ProposerMissLimitActivationHeight is the height of block, from which
+	// `ProposerMissLimit` is applied; the proposals of the blocks before it
+	// are not counted. 0 means it is applied from the first block.
+	ProposerMissLimitActivationHeight uint64 `json:"proposer-miss-limit-activation-height"`
+	// Validators is the addresses of validators of genesis; it is the first
+	// `block.BlockValidatorSet`, which is changed by `ManageValidator`. If it
+	// is empty, the validators of node are used as the first one.
+	Validators []string `json:"validators"`
+}
+
+// NewNetworkParameters returns the default `NetworkParameters`.
+func NewNetworkParameters() NetworkParameters {
+	return NetworkParameters{
+		FeeSchedule:                 NewFeeSchedule(),
+		TxV2ActivationHeight:        common.DefaultTxV2ActivationHeight,
+		CertificateActivationHeight: common.DefaultCertificateActivationHeight,
+		ProposerMissLimit:           common.DefaultProposerMissLimit,
+		Validators:                  []string{},
+	}
+}
+
+// IsDefault returns true if the parameters are same with
+// `NewNetworkParameters`.
+func (o NetworkParameters) IsDefault() bool {
+	return o.FeeSchedule.IsDefault() &&
+		o.TxV2ActivationHeight == common.DefaultTxV2ActivationHeight &&
+		o.CertificateActivationHeight == common.DefaultCertificateActivationHeight &&
+		o.ProposerMissLimit == common.DefaultProposerMissLimit &&
+		o.ProposerMissLimitActivationHeight == 0 &&
+		len(o.Validators) < 1
+}
+
+// Upgrade returns the parameters, which the activation heights of
+// `common.Config` are applied to. The genesis block of the running network
+// can not be changed, so the upgrade, which is not in the genesis, is
+// activated by the height, which the nodes agree on by the configuration.
+// The heights of genesis have priority.
+func (o NetworkParameters) Upgrade(conf common.Config) NetworkParameters {
+	if o.TxV2ActivationHeight == 0 {
+		o.TxV2ActivationHeight = conf.TxV2ActivationHeight
+	}
+	if o.CertificateActivationHeight == 0 {
+		o.CertificateActivationHeight = conf.CertificateActivationHeight
+	}
+	if o.ProposerMissLimit == 0 {
+		o.ProposerMissLimit = conf.ProposerMissLimit
+		o.ProposerMissLimitActivationHeight = conf.ProposerMissLimitActivationHeight
+	}
+
+	return o
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o NetworkParameters) IsWellFormed(common.Config) error {
+	if o.TxV2ActivationHeight != 0 && o.TxV2ActivationHeight < common.FirstProposedBlockHeight {
+		return errors.InvalidActivationHeight
+	}
+	if o.CertificateActivationHeight != 0 && o.CertificateActivationHeight < common.FirstProposedBlockHeight {
+		return errors.InvalidActivationHeight
+	}
+	if o.ProposerMissLimitActivationHeight != 0 && o.ProposerMissLimitActivationHeight < common.FirstProposedBlockHeight {
+		return errors.InvalidActivationHeight
+	}
+
+	validators := map[string]bool{}
+	for _, address := range o.Validators {
+		if _, err := keypair.Parse(address); err != nil {
+			return err
+		}
+		if validators[address] {
+			return errors.ValidatorAlreadyExists
+		}
+		validators[address] = true
+	}
+
+	return o.FeeSchedule.IsWellFormed()
+}
+
+// TransactionVersions returns the versions of transaction, which are accepted
+// in the block of given height.
+func (o NetworkParameters) TransactionVersions(height uint64) []string {
+	if o.TxV2ActivationHeight == 0 || height < o.TxV2ActivationHeight {
+		return []string{common.TransactionVersionV1}
+	}
+
+	return common.TransactionVersions
+}
+
+// IsAcceptedTransactionVersion checks the version of transaction is accepted
+// in the block of given height.
+func (o NetworkParameters) IsAcceptedTransactionVersion(version string, height uint64) bool {
+	for _, v := range o.TransactionVersions(height) {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsCertificateRequired checks the block of given height must have the
+// certificate.
+func (o NetworkParameters) IsCertificateRequired(height uint64) bool {
+	return o.CertificateActivationHeight != 0 && height >= o.CertificateActivationHeight
+}
+
+func (o NetworkParameters) HasFee() bool {
+	return false
+}
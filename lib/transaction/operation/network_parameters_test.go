@@ -0,0 +1,110 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestNetworkParametersTransactionVersions(t *testing.T) {
+	params := NewNetworkParameters()
+	require.True(t, params.IsDefault())
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+	require.Equal(t, []string{common.TransactionVersionV1}, params.TransactionVersions(common.FirstProposedBlockHeight))
+	require.False(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV2, 100))
+
+	params.TxV2ActivationHeight = 10
+	require.False(t, params.IsDefault())
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+
+	require.Equal(t, []string{common.TransactionVersionV1}, params.TransactionVersions(9))
+	require.False(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV2, 9))
+	require.True(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV1, 9))
+
+	require.Equal(t, common.TransactionVersions, params.TransactionVersions(10))
+	require.True(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV2, 10))
+	require.False(t, params.IsAcceptedTransactionVersion("3", 10))
+
+	params.TxV2ActivationHeight = common.GenesisBlockHeight
+	require.Equal(t, errors.InvalidActivationHeight, params.IsWellFormed(common.NewTestConfig()))
+}
+
+func TestNetworkParametersUpgrade(t *testing.T) {
+	conf := common.NewTestConfig()
+	conf.TxV2ActivationHeight = 10
+	conf.CertificateActivationHeight = 10
+	conf.ProposerMissLimit = 3
+	conf.ProposerMissLimitActivationHeight = 10
+
+	params := NewNetworkParameters().Upgrade(conf)
+	require.Equal(t, uint64(10), params.TxV2ActivationHeight)
+	require.False(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV2, 9))
+	require.True(t, params.IsAcceptedTransactionVersion(common.TransactionVersionV2, 10))
+	require.Equal(t, uint64(10), params.CertificateActivationHeight)
+	require.False(t, params.IsCertificateRequired(9))
+	require.True(t, params.IsCertificateRequired(10))
+	require.Equal(t, uint64(3), params.ProposerMissLimit)
+	require.Equal(t, uint64(10), params.ProposerMissLimitActivationHeight)
+
+	// the parameters of genesis have priority
+	params = NewNetworkParameters()
+	params.TxV2ActivationHeight = 20
+	params.CertificateActivationHeight = 20
+	params.ProposerMissLimit = 5
+	upgraded := params.Upgrade(conf)
+	require.Equal(t, uint64(20), upgraded.TxV2ActivationHeight)
+	require.Equal(t, uint64(20), upgraded.CertificateActivationHeight)
+	require.Equal(t, uint64(5), upgraded.ProposerMissLimit)
+	require.Equal(t, uint64(0), upgraded.ProposerMissLimitActivationHeight)
+}
+
+func TestNetworkParametersCertificateActivationHeight(t *testing.T) {
+	params := NewNetworkParameters()
+	require.False(t, params.IsCertificateRequired(common.FirstProposedBlockHeight))
+	require.False(t, params.IsCertificateRequired(100))
+
+	params.CertificateActivationHeight = 10
+	require.False(t, params.IsDefault())
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+	require.False(t, params.IsCertificateRequired(9))
+	require.True(t, params.IsCertificateRequired(10))
+
+	params.CertificateActivationHeight = common.GenesisBlockHeight
+	require.Equal(t, errors.InvalidActivationHeight, params.IsWellFormed(common.NewTestConfig()))
+}
+
+func TestNetworkParametersProposerMissLimit(t *testing.T) {
+	params := NewNetworkParameters()
+	require.Equal(t, common.DefaultProposerMissLimit, params.ProposerMissLimit)
+
+	params.ProposerMissLimit = 3
+	require.False(t, params.IsDefault())
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+
+	params.ProposerMissLimitActivationHeight = 10
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+
+	params.ProposerMissLimitActivationHeight = common.GenesisBlockHeight
+	require.Equal(t, errors.InvalidActivationHeight, params.IsWellFormed(common.NewTestConfig()))
+}
+
+func TestNetworkParametersValidators(t *testing.T) {
+	params := NewNetworkParameters()
+	params.Validators = []string{}
+	require.True(t, params.IsDefault())
+
+	address := keypair.Random().Address()
+	params.Validators = []string{address, keypair.Random().Address()}
+	require.False(t, params.IsDefault())
+	require.NoError(t, params.IsWellFormed(common.NewTestConfig()))
+
+	params.Validators = []string{address, address}
+	require.Equal(t, errors.ValidatorAlreadyExists, params.IsWellFormed(common.NewTestConfig()))
+
+	params.Validators = []string{"invalid"}
+	require.Error(t, params.IsWellFormed(common.NewTestConfig()))
+}
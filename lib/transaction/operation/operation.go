@@ -20,24 +20,32 @@ const (
 	TypeInflation
 	TypeUnfreezingRequest
 	TypeInflationPF
-)
-
-var (
-	typeName []string = []string{
-		"create-account",
-		"payment",
-		"congress-voting",
-		"congress-voting-result",
-		"collect-tx-fee",
-		"inflation",
-		"unfreezing-request",
-		"inflation-pf",
-	}
+	TypeManageSigners
+	TypeAccountMerge
+	TypeManageData
+	TypeHashLockCreate
+	TypeHashLockClaim
+	TypeHashLockRefund
+	TypeCreateVestingAccount
+	TypeCongressMembership
+	TypeCongressVote
+	TypeScheduledPaymentCreate
+	TypeScheduledPaymentCancel
+	TypeSetOptions
+	TypeTrust
+	TypeIssueAsset
+	TypeAssetPayment
+	TypeManageValidator
+	TypeNetworkParameters
+	TypeScheduledPaymentExecute
 )
 
 // Implement `fmt.Stringer`
 func (ot OperationType) String() string {
-	return typeName[ot]
+	if def, found := definitions[ot]; found {
+		return def.Name
+	}
+	return ""
 }
 
 // Implement encoding.TextMarshaler
@@ -48,23 +56,33 @@ func (ot OperationType) MarshalText() (text []byte, err error) {
 
 // Implement encoding.TextUnmarshaler
 func (ot *OperationType) UnmarshalText(text []byte) (err error) {
-	if idx, found := common.InStringArray(typeName, string(text)); !found {
+	if t, found := definitionsByName[string(text)]; !found {
 		return errors.InvalidOperation
 	} else {
-		*ot = OperationType(idx)
+		*ot = t
 		return nil
 	}
 }
 
 func IsNormalOperation(t OperationType) bool {
-	switch t {
-	case TypeCreateAccount, TypePayment,
-		TypeCongressVoting, TypeCongressVotingResult,
-		TypeUnfreezingRequest, TypeInflationPF:
-		return true
-	default:
-		return false
+	def, found := definitions[t]
+	return found && def.Normal
+}
+
+// IsCheckedOperation returns true if `Body.IsWellFormed` of the operation is
+// checked with the transaction; see `Definition.Unchecked`.
+func IsCheckedOperation(t OperationType) bool {
+	def, found := definitions[t]
+	return found && !def.Unchecked
+}
+
+// ThresholdLevel returns the `common.ThresholdLevel` which the signatures of
+// transaction must reach to include this type of operation.
+func (ot OperationType) ThresholdLevel() common.ThresholdLevel {
+	if def, found := definitions[ot]; found {
+		return def.ThresholdLevel
 	}
+	return common.ThresholdHigh
 }
 
 type Operation struct {
@@ -74,25 +92,7 @@ type Operation struct {
 
 func NewOperation(opb Body) (op Operation, err error) {
 	var t OperationType
-	switch opb.(type) {
-	case CreateAccount:
-		t = TypeCreateAccount
-	case Payment:
-		t = TypePayment
-	case CollectTxFee:
-		t = TypeCollectTxFee
-	case Inflation:
-		t = TypeInflation
-	case UnfreezeRequest:
-		t = TypeUnfreezingRequest
-	case CongressVoting:
-		t = TypeCongressVoting
-	case CongressVotingResult:
-		t = TypeCongressVotingResult
-	case InflationPF:
-		t = TypeInflationPF
-	default:
-		err = errors.UnknownOperationType
+	if t, err = getTypeFromBody(opb); err != nil {
 		return
 	}
 
@@ -243,27 +243,3 @@ func UnmarshalBodyJSON(t OperationType, b []byte) (Body, error) {
 		return reflect.ValueOf(bi).Elem().Interface().(Body), nil
 	}
 }
-
-// Returns: A pointer to a body with a type matching `ty`
-func newBodyFromType(ty OperationType) (interface{}, error) {
-	switch ty {
-	case TypeCreateAccount:
-		return &CreateAccount{}, nil
-	case TypePayment:
-		return &Payment{}, nil
-	case TypeCongressVoting:
-		return &CongressVoting{}, nil
-	case TypeCongressVotingResult:
-		return &CongressVotingResult{}, nil
-	case TypeCollectTxFee:
-		return &CollectTxFee{}, nil
-	case TypeInflation:
-		return &Inflation{}, nil
-	case TypeUnfreezingRequest:
-		return &UnfreezeRequest{}, nil
-	case TypeInflationPF:
-		return &InflationPF{}, nil
-	default:
-		return nil, errors.InvalidOperation
-	}
-}
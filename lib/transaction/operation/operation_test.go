@@ -2,6 +2,7 @@ package operation
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -158,3 +159,40 @@ func TestOperationBodyCongressVotingResultInvalidMembership(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestOperationRegistry(t *testing.T) {
+	for _, ty := range Types() {
+		def, found := GetDefinition(ty)
+		require.True(t, found)
+		require.Equal(t, def.Name, ty.String())
+
+		var parsed OperationType
+		require.NoError(t, parsed.UnmarshalText([]byte(def.Name)))
+		require.Equal(t, ty, parsed)
+
+		// the type of body is found by `NewOperation`
+		opb := reflect.ValueOf(def.NewBody()).Elem().Interface().(Body)
+		op, err := NewOperation(opb)
+		require.NoError(t, err)
+		require.Equal(t, ty, op.H.Type)
+	}
+
+	{ // already registered
+		def, _ := GetDefinition(TypePayment)
+		require.Panics(t, func() { Register(def) })
+	}
+
+	{ // unknown type
+		var parsed OperationType
+		require.Equal(t, errors.InvalidOperation, parsed.UnmarshalText([]byte("unknown")))
+		require.False(t, IsNormalOperation(OperationType(255)))
+		require.False(t, IsCheckedOperation(OperationType(255)))
+	}
+
+	{ // the operations, which are not checked with the transaction
+		require.True(t, IsCheckedOperation(TypePayment))
+		require.False(t, IsCheckedOperation(TypeCongressVoting))
+		require.False(t, IsCheckedOperation(TypeCongressVotingResult))
+		require.False(t, IsCheckedOperation(TypeUnfreezingRequest))
+	}
+}
@@ -6,6 +6,16 @@ import (
 	"boscoin.io/sebak/lib/errors"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypePayment,
+		Name:           "payment",
+		NewBody:        func() Body { return &Payment{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
 type Payment struct {
 	Target string        `json:"target"`
 	Amount common.Amount `json:"amount"`
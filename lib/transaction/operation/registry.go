@@ -0,0 +1,126 @@
+package operation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// Definition describes one type of operation. Every operation type must be
+// registered by `Register` before it is used, usually in the `init()` of the
+// file which has it's body.
+//
+// The state validation and apply hooks need the storage and the accounts, so
+// they are registered separately by `runner.RegisterOperationHandler`.
+type Definition struct {
+	Type OperationType
+	// Name is used to encode the operation type in RLP and JSON.
+	Name string
+	// NewBody returns the pointer of empty body. It is used to decode the
+	// body from RLP and JSON. The well-formedness of body is checked by
+	// `Body.IsWellFormed`.
+	NewBody func() Body
+	// Normal is true if the operation can be included in the normal
+	// transaction, not only in the proposer transaction.
+	Normal bool
+	// ThresholdLevel is the `common.ThresholdLevel` which the signatures of
+	// transaction must reach to include this type of operation.
+	ThresholdLevel common.ThresholdLevel
+	// Unchecked is true if `Body.IsWellFormed` is not checked with the
+	// transaction; the operations, which were accepted without the check,
+	// keep it, so the transactions of existing blocks are still valid.
+	Unchecked bool
+	// Index returns the addresses by which `block.BlockOperation` indexes
+	// the operation, besides the source. If it is nil, `Targetable` is used.
+	Index func(Body) Index
+}
+
+// Index is the addresses, which the operation is indexed by in
+// `block.BlockOperation`.
+type Index struct {
+	Target string
+	// Linked is the address, which the target is linked to.
+	Linked string
+}
+
+var (
+	definitions       = map[OperationType]Definition{}
+	definitionsByName = map[string]OperationType{}
+	definitionsByBody = map[reflect.Type]OperationType{}
+)
+
+// Register registers the new operation type. It panics if the type, the name
+// or the body is already registered.
+func Register(def Definition) {
+	if def.Name == "" || def.NewBody == nil {
+		panic(fmt.Sprintf("operation %d: name and body must be given", def.Type))
+	}
+
+	bodyType := reflect.TypeOf(def.NewBody()).Elem()
+	if _, found := definitions[def.Type]; found {
+		panic(fmt.Sprintf("operation %d: type already registered", def.Type))
+	}
+	if _, found := definitionsByName[def.Name]; found {
+		panic(fmt.Sprintf("operation %q: name already registered", def.Name))
+	}
+	if _, found := definitionsByBody[bodyType]; found {
+		panic(fmt.Sprintf("operation %q: body already registered", def.Name))
+	}
+
+	definitions[def.Type] = def
+	definitionsByName[def.Name] = def.Type
+	definitionsByBody[bodyType] = def.Type
+}
+
+// GetDefinition returns the registered `Definition` of the operation type.
+func GetDefinition(t OperationType) (Definition, bool) {
+	def, found := definitions[t]
+	return def, found
+}
+
+// Types returns all the registered operation types in order.
+func Types() []OperationType {
+	var types []OperationType
+	for t := range definitions {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
+
+func getTypeFromBody(opb Body) (OperationType, error) {
+	t, found := definitionsByBody[reflect.TypeOf(opb)]
+	if !found {
+		return 0, errors.UnknownOperationType
+	}
+
+	return t, nil
+}
+
+// Returns: A pointer to a body with a type matching `ty`
+func newBodyFromType(ty OperationType) (interface{}, error) {
+	def, found := definitions[ty]
+	if !found {
+		return nil, errors.InvalidOperation
+	}
+
+	return def.NewBody(), nil
+}
+
+// GetIndex returns the `Index` of the operation body.
+func GetIndex(t OperationType, opb Body) Index {
+	if def, found := definitions[t]; found && def.Index != nil {
+		return def.Index(opb)
+	}
+
+	var index Index
+	if pop, ok := opb.(Targetable); ok {
+		index.Target = pop.TargetAddress()
+	}
+
+	return index
+}
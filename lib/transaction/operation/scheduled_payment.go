@@ -0,0 +1,188 @@
+package operation
+
+import (
+	"unicode/utf8"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeScheduledPaymentCreate,
+		Name:           "scheduled-payment-create",
+		NewBody:        func() Body { return &ScheduledPaymentCreate{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeScheduledPaymentCancel,
+		Name:           "scheduled-payment-cancel",
+		NewBody:        func() Body { return &ScheduledPaymentCancel{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+	Register(Definition{
+		Type:           TypeScheduledPaymentExecute,
+		Name:           "scheduled-payment-execute",
+		NewBody:        func() Body { return &ScheduledPaymentExecute{} },
+		Normal:         false,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// ScheduledPaymentCreate registers the payment of `Amount` to `Target`, which
+// is executed at the block height `Start` and then every `Interval` blocks,
+// `Count` times in total. The amount of all the payments is reserved from the
+// source account when the operation is included in block; the remaining
+// amount is refunded when the schedule is cancelled.
+type ScheduledPaymentCreate struct {
+	ID       string        `json:"id"`
+	Target   string        `json:"target"`
+	Amount   common.Amount `json:"amount"`
+	Start    uint64        `json:"start"`    // block height
+	Interval uint64        `json:"interval"` // number of blocks
+	Count    uint64        `json:"count"`
+}
+
+func NewScheduledPaymentCreate(id, target string, amount common.Amount, start, interval, count uint64) ScheduledPaymentCreate {
+	return ScheduledPaymentCreate{
+		ID:       id,
+		Target:   target,
+		Amount:   amount,
+		Start:    start,
+		Interval: interval,
+		Count:    count,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ScheduledPaymentCreate) IsWellFormed(common.Config) (err error) {
+	if err = isValidScheduledPaymentID(o.ID); err != nil {
+		return
+	}
+
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		return errors.OperationAmountUnderflow
+	}
+
+	if o.Start < 1 || o.Count < 1 {
+		return errors.InvalidScheduledPayment
+	}
+	if o.Count > 1 && o.Interval < 1 {
+		return errors.InvalidScheduledPayment
+	}
+
+	if _, err = o.Amount.MultUint64(o.Count); err != nil {
+		return
+	}
+
+	return
+}
+
+func (o ScheduledPaymentCreate) TargetAddress() string {
+	return o.Target
+}
+
+// GetAmount returns the amount of all the payments, which is reserved from
+// the source account.
+func (o ScheduledPaymentCreate) GetAmount() common.Amount {
+	amount, _ := o.Amount.MultUint64(o.Count)
+	return amount
+}
+
+func (o ScheduledPaymentCreate) HasFee() bool {
+	return true
+}
+
+// ScheduledPaymentCancel cancels the scheduled payment of source account. The
+// payments, which are not executed yet, are refunded to the source account.
+type ScheduledPaymentCancel struct {
+	ID string `json:"id"`
+}
+
+func NewScheduledPaymentCancel(id string) ScheduledPaymentCancel {
+	return ScheduledPaymentCancel{
+		ID: id,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ScheduledPaymentCancel) IsWellFormed(common.Config) error {
+	return isValidScheduledPaymentID(o.ID)
+}
+
+func (o ScheduledPaymentCancel) HasFee() bool {
+	return true
+}
+
+// ScheduledPaymentExecute executes the scheduled payment of `Source`, which
+// is due at the block. It is included in the proposer transaction, so every
+// node executes the same payments. `Amount` is paid to `Target`; if the
+// payment can not be paid to `Target`, it is `Cancelled` and `Amount` is the
+// reserved amount, which is refunded to `Source`.
+type ScheduledPaymentExecute struct {
+	Source    string        `json:"source"`
+	ID        string        `json:"id"`
+	Target    string        `json:"target"`
+	Amount    common.Amount `json:"amount"`
+	Cancelled bool          `json:"cancelled"`
+}
+
+func NewScheduledPaymentExecute(source, id, target string, amount common.Amount, cancelled bool) ScheduledPaymentExecute {
+	return ScheduledPaymentExecute{
+		Source:    source,
+		ID:        id,
+		Target:    target,
+		Amount:    amount,
+		Cancelled: cancelled,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o ScheduledPaymentExecute) IsWellFormed(common.Config) (err error) {
+	if err = isValidScheduledPaymentID(o.ID); err != nil {
+		return
+	}
+
+	if _, err = keypair.Parse(o.Source); err != nil {
+		return
+	}
+	if _, err = keypair.Parse(o.Target); err != nil {
+		return
+	}
+
+	if int64(o.Amount) < 1 {
+		return errors.OperationAmountUnderflow
+	}
+
+	return
+}
+
+func (o ScheduledPaymentExecute) TargetAddress() string {
+	return o.Target
+}
+
+func (o ScheduledPaymentExecute) GetAmount() common.Amount {
+	return o.Amount
+}
+
+func (o ScheduledPaymentExecute) HasFee() bool {
+	return false
+}
+
+func isValidScheduledPaymentID(id string) error {
+	if len(id) < 1 || len(id) > common.MaxScheduledPaymentIDLength {
+		return errors.InvalidScheduledPayment
+	}
+	if !utf8.ValidString(id) {
+		return errors.InvalidScheduledPayment
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package operation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestScheduledPaymentOperations(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	target := keypair.Random().Address()
+
+	{ // create
+		o := NewScheduledPaymentCreate("salary", target, common.Amount(100), 10, 5, 3)
+		require.NoError(t, o.IsWellFormed(conf))
+		// the amount of all the payments is reserved
+		require.Equal(t, common.Amount(300), o.GetAmount())
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeScheduledPaymentCreate, op.H.Type)
+		require.Equal(t, target, GetIndex(op.H.Type, op.B).Target)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // create once without interval
+		o := NewScheduledPaymentCreate("once", target, common.Amount(100), 10, 0, 1)
+		require.NoError(t, o.IsWellFormed(conf))
+		require.Equal(t, common.Amount(100), o.GetAmount())
+	}
+
+	{ // cancel
+		o := NewScheduledPaymentCancel("salary")
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeScheduledPaymentCancel, op.H.Type)
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	invalids := []Body{
+		NewScheduledPaymentCreate("", target, common.Amount(100), 10, 5, 3),
+		NewScheduledPaymentCreate(strings.Repeat("a", common.MaxScheduledPaymentIDLength+1), target, common.Amount(100), 10, 5, 3),
+		NewScheduledPaymentCreate("salary", target, common.Amount(100), 0, 5, 3),
+		NewScheduledPaymentCreate("salary", target, common.Amount(100), 10, 5, 0),
+		NewScheduledPaymentCreate("salary", target, common.Amount(100), 10, 0, 3),
+		NewScheduledPaymentCancel(""),
+	}
+	for _, o := range invalids {
+		require.Equal(t, errors.InvalidScheduledPayment, o.IsWellFormed(conf), "operation: %v", o)
+	}
+
+	require.Equal(t, errors.OperationAmountUnderflow, NewScheduledPaymentCreate("salary", target, common.Amount(0), 10, 5, 3).IsWellFormed(conf))
+	require.Equal(t, errors.MaximumBalanceReached, NewScheduledPaymentCreate("salary", target, common.MaximumBalance/2, 10, 5, 3).IsWellFormed(conf))
+}
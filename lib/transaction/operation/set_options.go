@@ -0,0 +1,60 @@
+package operation
+
+import (
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func init() {
+	Register(Definition{
+		Type:           TypeSetOptions,
+		Name:           "set-options",
+		NewBody:        func() Body { return &SetOptions{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+	})
+}
+
+// SetOptions replaces the flags and allow list of the source account. The
+// flags decide which payments and account creations the account accepts, see
+// `common.AccountFlags`.
+type SetOptions struct {
+	Flags     common.AccountFlags `json:"flags"`
+	AllowList []string            `json:"allow_list"`
+}
+
+func NewSetOptions(flags common.AccountFlags, allowList []string) SetOptions {
+	return SetOptions{
+		Flags:     flags,
+		AllowList: allowList,
+	}
+}
+
+// Implement transaction/operation : IsWellFormed
+func (o SetOptions) IsWellFormed(common.Config) (err error) {
+	if !o.Flags.IsValid() {
+		return errors.InvalidAccountOptions
+	}
+
+	if len(o.AllowList) > common.MaxAllowListInAccount {
+		return errors.InvalidAccountOptions
+	}
+
+	allowed := map[string]bool{}
+	for _, address := range o.AllowList {
+		if _, err = keypair.Parse(address); err != nil {
+			return
+		}
+		if _, found := allowed[address]; found {
+			return errors.InvalidAccountOptions
+		}
+		allowed[address] = true
+	}
+
+	return
+}
+
+func (o SetOptions) HasFee() bool {
+	return true
+}
@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/keypair"
+	"boscoin.io/sebak/lib/errors"
+)
+
+func TestSetOptionsOperation(t *testing.T) {
+	conf := common.NewTestConfig()
+
+	allowed := keypair.Random().Address()
+
+	{ // reset options
+		o := NewSetOptions(0, nil)
+		require.NoError(t, o.IsWellFormed(conf))
+	}
+
+	{
+		o := NewSetOptions(common.AccountFlagRequireMemo|common.AccountFlagBlockIncoming, []string{allowed})
+		require.NoError(t, o.IsWellFormed(conf))
+
+		op, err := NewOperation(o)
+		require.NoError(t, err)
+		require.Equal(t, TypeSetOptions, op.H.Type)
+		require.Equal(t, common.ThresholdMedium, op.H.Type.ThresholdLevel())
+		common.CheckRoundTripRLP(t, op)
+	}
+
+	{ // unknown flag
+		o := NewSetOptions(common.AllAccountFlags+1, nil)
+		require.Equal(t, errors.InvalidAccountOptions, o.IsWellFormed(conf))
+	}
+
+	{ // duplicated address
+		o := NewSetOptions(common.AccountFlagBlockIncoming, []string{allowed, allowed})
+		require.Equal(t, errors.InvalidAccountOptions, o.IsWellFormed(conf))
+	}
+
+	{ // too many addresses
+		var allowList []string
+		for i := 0; i < common.MaxAllowListInAccount+1; i++ {
+			allowList = append(allowList, keypair.Random().Address())
+		}
+		o := NewSetOptions(common.AccountFlagBlockIncoming, allowList)
+		require.Equal(t, errors.InvalidAccountOptions, o.IsWellFormed(conf))
+	}
+
+	{ // invalid address
+		o := NewSetOptions(common.AccountFlagBlockIncoming, []string{"showmethemoney"})
+		require.Error(t, o.IsWellFormed(conf))
+	}
+}
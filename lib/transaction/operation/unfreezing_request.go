@@ -4,6 +4,17 @@ import (
 	"boscoin.io/sebak/lib/common"
 )
 
+func init() {
+	Register(Definition{
+		Type:           TypeUnfreezingRequest,
+		Name:           "unfreezing-request",
+		NewBody:        func() Body { return &UnfreezeRequest{} },
+		Normal:         true,
+		ThresholdLevel: common.ThresholdMedium,
+		Unchecked:      true,
+	})
+}
+
 type UnfreezeRequest struct{}
 
 func NewUnfreezeRequest() UnfreezeRequest {
@@ -1,10 +1,14 @@
 package transaction
 
 import (
+	"container/heap"
 	"container/list"
+	"sort"
 	"sync"
+	"time"
 
 	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/common/observer"
 	"boscoin.io/sebak/lib/errors"
 	"boscoin.io/sebak/lib/metrics"
 )
@@ -12,11 +16,14 @@ import (
 type Pool struct {
 	sync.RWMutex
 
-	Pool    map[ /* Transaction.GetHash() */ string]Transaction
-	sources map[ /* Transaction.Source() */ string] /* Transaction.GetHash() */ string
+	Pool map[ /* Transaction.GetHash() */ string]Transaction
+	// sources keeps the transactions of each source ordered by
+	// `Transaction.B.SequenceID`
+	sources map[ /* Transaction.Source() */ string][] /* Transaction.GetHash() */ string
 
 	hashList *list.List // Transaction.GetHash()
 	hashMap  map[ /* Transaction.GetHash() */ string]*list.Element
+	received map[ /* Transaction.GetHash() */ string]time.Time
 
 	cfg common.Config
 }
@@ -24,9 +31,10 @@ type Pool struct {
 func NewPool(cfg common.Config) *Pool {
 	return &Pool{
 		Pool:     map[string]Transaction{},
-		sources:  map[string]string{},
+		sources:  map[string][]string{},
 		hashList: list.New(),
 		hashMap:  make(map[string]*list.Element),
+		received: map[string]time.Time{},
 		cfg:      cfg,
 	}
 }
@@ -54,50 +62,169 @@ func (tp *Pool) Get(hash string) (Transaction, bool) {
 	return tx, found
 }
 
+// GetFromSource returns the transaction of source, which has the lowest
+// sequence id.
 func (tp *Pool) GetFromSource(source string) (Transaction, bool) {
 	tp.RLock()
 	defer tp.RUnlock()
-	hash, found := tp.sources[source]
+	hashes, found := tp.sources[source]
 	if !found {
 		return Transaction{}, false
 	}
-	return tp.Get(hash)
+	tx, found := tp.Pool[hashes[0]]
+	return tx, found
 }
 
-func (tp *Pool) add(tx Transaction, limit int) error {
+// GetAllFromSource returns the transactions of source ordered by sequence
+// id.
+func (tp *Pool) GetAllFromSource(source string) (txs []Transaction) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	for _, hash := range tp.sources[source] {
+		txs = append(txs, tp.Pool[hash])
+	}
+
+	return
+}
+
+// Received returns the time when the transaction was added into `Pool`.
+func (tp *Pool) Received(hash string) (time.Time, bool) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	t, found := tp.received[hash]
+	return t, found
+}
+
+// Hashes returns the hashes of the transactions in order of arrival. If
+// source is not empty, only the transactions of source are returned.
+func (tp *Pool) Hashes(source string) (hashes []string) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	for e := tp.hashList.Front(); e != nil; e = e.Next() {
+		hash, ok := e.Value.(string)
+		if !ok {
+			continue
+		}
+		if len(source) > 0 && tp.Pool[hash].Source() != source {
+			continue
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return
+}
+
+// add adds the transaction into `Pool`. `limit` is the maximum size of `Pool`
+// and `sourceLimit` is the maximum number of transactions of source; 0 means
+// no limit. The `observer.TxPool` events of all and source are triggered for
+// the added transaction.
+func (tp *Pool) add(tx Transaction, limit, sourceLimit int) error {
 	txHash := tx.GetHash()
-	if tp.Has(txHash) {
+
+	tp.Lock()
+	defer tp.Unlock()
+
+	if _, found := tp.Pool[txHash]; found {
 		return errors.TransactionAlreadyExistsInPool
 	}
 
-	if limit > 0 && tp.Len() >= limit {
+	// the pending transaction of same source and sequence id can be replaced
+	// by the transaction, which pays higher fee.
+	var replaced string
+	for _, hash := range tp.sources[tx.Source()] {
+		if tp.Pool[hash].B.SequenceID == tx.B.SequenceID {
+			replaced = hash
+			break
+		}
+	}
+
+	if len(replaced) > 0 {
+		if !tx.CanReplace(tp.Pool[replaced]) {
+			return errors.TransactionReplaceFeeTooLow
+		}
+		tp.remove(replaced)
+		metrics.TxPool.AddSize(-1)
+	} else if limit > 0 && len(tp.Pool) >= limit {
 		return errors.TransactionPoolFull
+	} else if sourceLimit > 0 && len(tp.sources[tx.Source()]) >= sourceLimit {
+		metrics.TxPool.AddRejected(metrics.TxPoolRejectSourceLimit, 1)
+		return errors.TransactionPoolSourceLimit
 	}
 
 	metrics.TxPool.AddSize(1)
 
-	tp.Lock()
-	defer tp.Unlock()
+	// keep the transactions of source ordered by sequence id
+	hashes := tp.sources[tx.Source()]
+	i := sort.Search(len(hashes), func(i int) bool {
+		return tp.Pool[hashes[i]].B.SequenceID > tx.B.SequenceID
+	})
 
 	tp.Pool[txHash] = tx
-	tp.sources[tx.Source()] = txHash
+	hashes = append(hashes, "")
+	copy(hashes[i+1:], hashes[i:])
+	hashes[i] = txHash
+	tp.sources[tx.Source()] = hashes
 
 	e := tp.hashList.PushBack(txHash)
 	tp.hashMap[txHash] = e
+	tp.received[txHash] = time.Now()
+
+	go func(tx Transaction) {
+		observer.ResourceObserver.Trigger(observer.NewCondition(observer.TxPool, observer.All).Event(), &tx)
+		observer.ResourceObserver.Trigger(
+			observer.NewCondition(observer.TxPool, observer.Source, tx.Source()).Event(),
+			&tx,
+		)
+	}(tx)
 
 	return nil
 }
 
+// remove removes the transaction from `Pool`; it must be called under the
+// lock.
+func (tp *Pool) remove(hash string) bool {
+	tx, found := tp.Pool[hash]
+	if !found {
+		return false
+	}
+
+	source := tx.Source()
+	hashes := tp.sources[source]
+	for i, h := range hashes {
+		if h == hash {
+			hashes = append(hashes[:i], hashes[i+1:]...)
+			break
+		}
+	}
+	if len(hashes) < 1 {
+		delete(tp.sources, source)
+	} else {
+		tp.sources[source] = hashes
+	}
+
+	delete(tp.Pool, hash)
+	delete(tp.received, hash)
+	if e, ok := tp.hashMap[hash]; ok {
+		tp.hashList.Remove(e)
+		delete(tp.hashMap, hash)
+	}
+
+	return true
+}
+
 func (tp *Pool) AddFromClient(tx Transaction) error {
-	return tp.add(tx, tp.cfg.TxPoolClientLimit)
+	return tp.add(tx, tp.cfg.TxPoolClientLimit, tp.cfg.TxPoolSourceLimit)
 }
 
 func (tp *Pool) AddFromNode(tx Transaction) error {
-	return tp.add(tx, tp.cfg.TxPoolNodeLimit)
+	return tp.add(tx, tp.cfg.TxPoolNodeLimit, tp.cfg.TxPoolSourceLimit)
 }
 
 func (tp *Pool) Add(tx Transaction) error {
-	return tp.add(tx, 0)
+	return tp.add(tx, 0, 0)
 }
 
 func (tp *Pool) Remove(hashes ...string) {
@@ -110,13 +237,7 @@ func (tp *Pool) Remove(hashes ...string) {
 
 	var num int
 	for _, hash := range hashes {
-		if tx, found := tp.Pool[hash]; found {
-			delete(tp.sources, tx.Source())
-			delete(tp.Pool, hash)
-			if e, ok := tp.hashMap[hash]; ok {
-				tp.hashList.Remove(e)
-				delete(tp.hashMap, hash)
-			}
+		if tp.remove(hash) {
 			num++
 		}
 	}
@@ -125,7 +246,9 @@ func (tp *Pool) Remove(hashes ...string) {
 
 }
 
-func (tp *Pool) RemoveFromSources(sources ...string) {
+// RemoveFromSources removes all the transactions of sources and returns the
+// removed ones.
+func (tp *Pool) RemoveFromSources(sources ...string) (removed []string) {
 	if len(sources) < 1 {
 		return
 	}
@@ -133,24 +256,130 @@ func (tp *Pool) RemoveFromSources(sources ...string) {
 	tp.Lock()
 	defer tp.Unlock()
 
-	var num int
 	for _, source := range sources {
-		if hash, found := tp.sources[source]; found {
-			if _, found := tp.Pool[hash]; found {
-				delete(tp.sources, source)
-				delete(tp.Pool, hash)
-				if e, ok := tp.hashMap[hash]; ok {
-					tp.hashList.Remove(e)
-					delete(tp.hashMap, hash)
-				}
-				num++
+		hashes := append([]string{}, tp.sources[source]...)
+		for _, hash := range hashes {
+			if tp.remove(hash) {
+				removed = append(removed, hash)
 			}
 		}
 	}
 
-	metrics.TxPool.AddSize(-num)
+	metrics.TxPool.AddSize(-len(removed))
+
+	return
 }
 
+// RemoveStaleFromSource removes the transactions of source, which sequence id
+// is lower than the given sequence id of source account; they can not be
+// included in block any more.
+func (tp *Pool) RemoveStaleFromSource(source string, sequenceID uint64) (removed []string) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	for _, hash := range tp.sources[source] {
+		if tp.Pool[hash].B.SequenceID >= sequenceID {
+			break
+		}
+		removed = append(removed, hash)
+	}
+
+	for _, hash := range removed {
+		tp.remove(hash)
+	}
+
+	metrics.TxPool.AddSize(-len(removed))
+
+	return
+}
+
+// RemoveExpired removes the transactions, which can not be included after the
+// block of given height and time by it's `TimeBounds`.
+func (tp *Pool) RemoveExpired(height uint64, t time.Time) (removed []string) {
+	tp.RLock()
+	for hash, tx := range tp.Pool {
+		if tx.IsExpired(height, t) {
+			removed = append(removed, hash)
+		}
+	}
+	tp.RUnlock()
+
+	tp.Remove(removed...)
+	metrics.TxPool.AddEvicted(metrics.TxPoolEvictExpired, len(removed))
+
+	return
+}
+
+// RemoveOutdated removes the transactions, which stay in `Pool` longer than
+// `TxPoolTTL` at the given time.
+func (tp *Pool) RemoveOutdated(t time.Time) (removed []string) {
+	if tp.cfg.TxPoolTTL < 1 {
+		return
+	}
+
+	tp.RLock()
+	for hash, received := range tp.received {
+		if t.Sub(received) > tp.cfg.TxPoolTTL {
+			removed = append(removed, hash)
+		}
+	}
+	tp.RUnlock()
+
+	tp.Remove(removed...)
+	metrics.TxPool.AddEvicted(metrics.TxPoolEvictTTL, len(removed))
+
+	return
+}
+
+// Sources returns the sources of the transactions in `Pool`.
+func (tp *Pool) Sources() (sources []string) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	for source := range tp.sources {
+		sources = append(sources, source)
+	}
+
+	return
+}
+
+// sourceHead is the first transaction, which is not yet returned, of source
+// in `Pool.AvailableTransactions`.
+type sourceHead struct {
+	hashes []string // the remaining transactions of source
+	fee    common.Amount
+	order  int // the order of arrival of source
+}
+
+// sourceHeads is the max heap of `sourceHead` by the `FeePerOperation` of
+// head transaction; the heads of same fee follow the order of arrival.
+type sourceHeads []*sourceHead
+
+func (h sourceHeads) Len() int { return len(h) }
+
+func (h sourceHeads) Less(i, j int) bool {
+	if h[i].fee != h[j].fee {
+		return h[i].fee > h[j].fee
+	}
+	return h[i].order < h[j].order
+}
+
+func (h sourceHeads) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *sourceHeads) Push(x interface{}) { *h = append(*h, x.(*sourceHead)) }
+
+func (h *sourceHeads) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// AvailableTransactions returns the transactions, which can be included in
+// the next ballot. The transactions are merged by their `FeePerOperation`
+// from the queues of sources, so the transactions of same source follow by
+// order of sequence id; the sources of same fee keep the order of arrival.
 func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
 	if transactionLimit < 1 {
 		return nil
@@ -159,18 +388,41 @@ func (tp *Pool) AvailableTransactions(transactionLimit int) []string {
 	tp.RLock()
 	defer tp.RUnlock()
 
-	var ret []string
-	var cnt int
-	// first ouput by order older hash
+	var heads sourceHeads
+	found := map[string]bool{}
 	for e := tp.hashList.Front(); e != nil; e = e.Next() {
-		if cnt >= transactionLimit {
-			return ret
-		}
 		hash, ok := e.Value.(string)
-		if ok {
-			ret = append(ret, hash)
-			cnt++
+		if !ok {
+			continue
+		}
+
+		source := tp.Pool[hash].Source()
+		if _, ok := found[source]; ok {
+			continue
+		}
+		found[source] = true
+
+		hashes := tp.sources[source]
+		heads = append(heads, &sourceHead{
+			hashes: hashes,
+			fee:    tp.Pool[hashes[0]].FeePerOperation(),
+			order:  len(heads),
+		})
+	}
+	heap.Init(&heads)
+
+	var ret []string
+	for heads.Len() > 0 && len(ret) < transactionLimit {
+		head := heads[0]
+		ret = append(ret, head.hashes[0])
+
+		head.hashes = head.hashes[1:]
+		if len(head.hashes) < 1 {
+			heap.Pop(&heads)
+			continue
 		}
+		head.fee = tp.Pool[head.hashes[0]].FeePerOperation()
+		heap.Fix(&heads, 0)
 	}
 
 	return ret
@@ -16,7 +16,17 @@ func TestMakeTransaction(networkID []byte, n int) (kp *keypair.Full, tx Transact
 		ops = append(ops, operation.MakeTestPayment(-1))
 	}
 
-	tx, _ = NewTransaction(kp.Address(), 0, ops...)
+	tx, _ = NewTransaction(kp.Address(), 0, operation.NewFeeSchedule(), ops...)
+	tx.Sign(kp, networkID)
+
+	return
+}
+
+// TestMakeTransactionV2 makes the `common.TransactionVersionV2` transaction,
+// which can have the fields of V2.
+func TestMakeTransactionV2(networkID []byte, n int) (kp *keypair.Full, tx Transaction) {
+	kp, tx = TestMakeTransaction(networkID, n)
+	tx.H.Version = common.TransactionVersionV2
 	tx.Sign(kp, networkID)
 
 	return
@@ -40,6 +50,7 @@ func TestMakeTransactionWithKeypair(networkID []byte, n int, srcKp *keypair.Full
 	tx, _ = NewTransaction(
 		srcKp.Address(),
 		0,
+		operation.NewFeeSchedule(),
 		ops...,
 	)
 	tx.Sign(srcKp, networkID)
@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"time"
+
+	"boscoin.io/sebak/lib/common"
+	"boscoin.io/sebak/lib/errors"
+)
+
+// TimeBounds limits the block height and the time, in which the transaction
+// can be included. Every bound is optional; zero height and empty time mean
+// no bound. The time bounds are checked with the height of the block, which
+// will include the transaction and the proposed time of the previous block,
+// so every node gets the same result.
+type TimeBounds struct {
+	MinHeight uint64 `json:"min_height,omitempty"`
+	MaxHeight uint64 `json:"max_height,omitempty"`
+	MinTime   string `json:"min_time,omitempty"` // ISO8601
+	MaxTime   string `json:"max_time,omitempty"` // ISO8601
+}
+
+func (tb TimeBounds) IsEmpty() bool {
+	return tb == TimeBounds{}
+}
+
+func (tb TimeBounds) IsWellFormed() (err error) {
+	if tb.IsEmpty() {
+		return errors.InvalidTimeBounds
+	}
+
+	if tb.MaxHeight > 0 && tb.MinHeight > tb.MaxHeight {
+		return errors.InvalidTimeBounds
+	}
+
+	var minTime, maxTime time.Time
+	if len(tb.MinTime) > 0 {
+		if minTime, err = common.ParseISO8601(tb.MinTime); err != nil {
+			return errors.InvalidTimeBounds
+		}
+	}
+	if len(tb.MaxTime) > 0 {
+		if maxTime, err = common.ParseISO8601(tb.MaxTime); err != nil {
+			return errors.InvalidTimeBounds
+		}
+		if minTime.After(maxTime) {
+			return errors.InvalidTimeBounds
+		}
+	}
+
+	return nil
+}
+
+// Check checks the given block height and time are in the time bounds.
+func (tb TimeBounds) Check(height uint64, t time.Time) (err error) {
+	if tb.IsExpired(height, t) {
+		return errors.TransactionExpired
+	}
+
+	if tb.MinHeight > 0 && height < tb.MinHeight {
+		return errors.TransactionNotYetValid
+	}
+	if len(tb.MinTime) > 0 {
+		var minTime time.Time
+		if minTime, err = common.ParseISO8601(tb.MinTime); err != nil {
+			return errors.InvalidTimeBounds
+		}
+		if t.Before(minTime) {
+			return errors.TransactionNotYetValid
+		}
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the given block height or time passed over the
+// upper bounds; the transaction can not be included anymore.
+func (tb TimeBounds) IsExpired(height uint64, t time.Time) bool {
+	if tb.MaxHeight > 0 && height > tb.MaxHeight {
+		return true
+	}
+	if len(tb.MaxTime) > 0 {
+		maxTime, err := common.ParseISO8601(tb.MaxTime)
+		if err != nil || t.After(maxTime) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,8 @@ package transaction
 
 import (
 	"encoding/json"
+	"io"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 
@@ -11,17 +13,24 @@ import (
 	"boscoin.io/sebak/lib/transaction/operation"
 )
 
-// TODO versioning
-
+// Transaction is `common.TransactionVersionV1` or
+// `common.TransactionVersionV2`. Both versions share the same `Body` and
+// `Header`, but the optional fields of them, like `Body.Memo` and
+// `Header.Signatures`, can be set only in `common.TransactionVersionV2`. The
+// version of V2 transaction is hashed with `Body`, but the V1 transaction is
+// hashed as before the versions were added.
 type Transaction struct {
 	H Header
 	B Body
+	// F is set if the fee is paid by the other account, see `FeeBump`.
+	F *FeeBump `json:"F,omitempty"`
 }
 
 type envelop struct {
 	T string
 	H Header
 	B Body
+	F *FeeBump
 }
 
 type Header struct {
@@ -32,6 +41,15 @@ type Header struct {
 	// has to validate it anyway.
 	Hash      string `json:"-"`
 	Signature string `json:"signature"`
+	// Signatures has the signatures of the other signers of `Body.Source`.
+	// Like `Hash`, these are not the part of `Body`, so adding signatures
+	// does not change the hash of transaction.
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+type Signature struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
 }
 
 type Body struct {
@@ -39,6 +57,81 @@ type Body struct {
 	Fee        common.Amount         `json:"fee"`
 	SequenceID uint64                `json:"sequence_id"`
 	Operations []operation.Operation `json:"operations"`
+	TimeBounds *TimeBounds           `json:"time_bounds,omitempty"`
+	Memo       *Memo                 `json:"memo,omitempty"`
+}
+
+// Implement `common.Encoder`. The optional fields are encoded only when they
+// are set, so the hash of the transaction without them is not changed. The
+// empty optional field is encoded only when the next optional field is set.
+func (tb Body) EncodeRLP(w io.Writer) error {
+	var timeBounds TimeBounds
+	if tb.TimeBounds != nil {
+		timeBounds = *tb.TimeBounds
+	}
+	var memo Memo
+	if tb.Memo != nil {
+		memo = *tb.Memo
+	}
+
+	optionals := []interface{ IsEmpty() bool }{timeBounds, memo}
+	for len(optionals) > 0 && optionals[len(optionals)-1].IsEmpty() {
+		optionals = optionals[:len(optionals)-1]
+	}
+
+	fields := []interface{}{tb.Source, tb.Fee, tb.SequenceID, tb.Operations}
+	for _, o := range optionals {
+		fields = append(fields, o)
+	}
+
+	return common.Encode(w, fields)
+}
+
+// Implement `common.Decoder`
+func (tb *Body) DecodeRLP(s *common.RLPStream) (err error) {
+	if _, err = s.List(); err != nil {
+		return
+	}
+
+	if err = s.Decode(&tb.Source); err != nil {
+		return
+	} else if err = s.Decode(&tb.Fee); err != nil {
+		return
+	} else if err = s.Decode(&tb.SequenceID); err != nil {
+		return
+	} else if err = s.Decode(&tb.Operations); err != nil {
+		return
+	}
+
+	// optional fields
+	tb.TimeBounds = nil
+	tb.Memo = nil
+
+	if _, _, err = s.Kind(); err == common.RLPEOL {
+		return s.ListEnd()
+	} else if err != nil {
+		return
+	}
+	var timeBounds TimeBounds
+	if err = s.Decode(&timeBounds); err != nil {
+		return
+	} else if !timeBounds.IsEmpty() {
+		tb.TimeBounds = &timeBounds
+	}
+
+	if _, _, err = s.Kind(); err == common.RLPEOL {
+		return s.ListEnd()
+	} else if err != nil {
+		return
+	}
+	var memo Memo
+	if err = s.Decode(&memo); err != nil {
+		return
+	} else if !memo.IsEmpty() {
+		tb.Memo = &memo
+	}
+
+	return s.ListEnd()
 }
 
 func (tb Body) MakeHash() []byte {
@@ -57,30 +150,54 @@ func (t *Transaction) UnmarshalJSON(b []byte) (err error) {
 
 	t.H = tj.H
 	t.B = tj.B
-	t.H.Hash = t.B.MakeHashString()
+	t.F = tj.F
+	t.H.Hash = t.MakeHashString()
 	return
 }
 
-func NewTransaction(source string, sequenceID uint64, ops ...operation.Operation) (tx Transaction, err error) {
-	if len(ops) < 1 {
-		err = errors.TransactionEmptyOperations
-		return
+// MakeHashString returns the hash of transaction. It is the hash of `Body`,
+// or the hash of `FeeBump` envelope if the transaction has the fee payer.
+func (tx Transaction) MakeHashString() string {
+	if tx.F == nil {
+		return tx.makeBodyHashString()
 	}
 
-	var opsHaveFee int
-	for _, op := range ops {
-		if op.HasFee() {
-			opsHaveFee++
-		}
+	return tx.F.MakeHashString(tx.makeBodyHashString())
+}
+
+// makeBodyHashString returns the hash of `Body` with the version of
+// transaction, so the signed version can not be changed. The version of
+// `common.TransactionVersionV1` is not hashed.
+func (tx Transaction) makeBodyHashString() string {
+	if tx.IsValidVersion(common.TransactionVersionV1) {
+		return tx.B.MakeHashString()
 	}
-	fee := common.Amount(0)
-	if opsHaveFee > 0 {
-		fee = common.BaseFee.MustMult(opsHaveFee)
+
+	return common.MustMakeObjectHashString([]interface{}{tx.H.Version, tx.B})
+}
+
+// BodyHash returns the hash of `Body`, which is signed by the signers of
+// source. Unlike `GetHash`, it is not changed by the `FeeBump` envelope.
+func (tx Transaction) BodyHash() string {
+	if tx.F == nil {
+		return tx.H.Hash
+	}
+
+	return tx.makeBodyHashString()
+}
+
+// NewTransaction makes new `common.TransactionVersionV1` transaction, which
+// pays the minimum fee of `operation.FeeSchedule`. To set the fields of
+// `common.TransactionVersionV2`, the version must be set before it is signed.
+func NewTransaction(source string, sequenceID uint64, fs operation.FeeSchedule, ops ...operation.Operation) (tx Transaction, err error) {
+	if len(ops) < 1 {
+		err = errors.TransactionEmptyOperations
+		return
 	}
 
 	txBody := Body{
 		Source:     source,
-		Fee:        fee,
+		Fee:        fs.TotalFee(ops),
 		SequenceID: sequenceID,
 		Operations: ops,
 	}
@@ -98,17 +215,19 @@ func NewTransaction(source string, sequenceID uint64, ops ...operation.Operation
 }
 
 var TransactionWellFormedCheckerFuncs = []common.CheckerFunc{
+	CheckVersion,
 	CheckOverOperationsLimit,
 	CheckSource,
 	CheckBaseFee,
+	CheckTimeBounds,
+	CheckMemo,
+	CheckFeePayer,
 	CheckOperationTypes,
 	CheckOperations,
 	CheckVerifySignature,
 }
 
 func (tx Transaction) IsWellFormed(conf common.Config) (err error) {
-	// TODO check `Version` format with SemVer
-
 	checker := &Checker{
 		DefaultChecker: common.DefaultChecker{Funcs: TransactionWellFormedCheckerFuncs},
 		NetworkID:      conf.NetworkID,
@@ -145,6 +264,77 @@ func (tx Transaction) Source() string {
 	return tx.B.Source
 }
 
+// Signers returns the addresses, which signed this transaction.
+func (tx Transaction) Signers() []string {
+	var signers []string
+	if len(tx.H.Signature) > 0 {
+		signers = append(signers, tx.B.Source)
+	}
+	for _, s := range tx.H.Signatures {
+		signers = append(signers, s.Signer)
+	}
+
+	return signers
+}
+
+// HasFeePayer returns true if the fee is paid by the fee payer of `FeeBump`
+// envelope.
+func (tx Transaction) HasFeePayer() bool {
+	return tx.F != nil
+}
+
+// FeePayer returns the fee payer of `FeeBump` envelope. It is empty if the
+// transaction does not have the fee payer.
+func (tx Transaction) FeePayer() string {
+	if tx.F == nil {
+		return ""
+	}
+
+	return tx.F.FeePayer
+}
+
+// FeeSource returns the account, which pays the fee of transaction.
+func (tx Transaction) FeeSource() string {
+	if tx.HasFeePayer() {
+		return tx.F.FeePayer
+	}
+
+	return tx.B.Source
+}
+
+// ThresholdLevel returns the highest `common.ThresholdLevel` of the
+// operations.
+func (tx Transaction) ThresholdLevel() common.ThresholdLevel {
+	level := common.ThresholdLow
+	for _, op := range tx.B.Operations {
+		if l := op.H.Type.ThresholdLevel(); l > level {
+			level = l
+		}
+	}
+
+	return level
+}
+
+// CheckTimeBounds checks the transaction can be included in the block of
+// given height and time.
+func (tx Transaction) CheckTimeBounds(height uint64, t time.Time) error {
+	if tx.B.TimeBounds == nil {
+		return nil
+	}
+
+	return tx.B.TimeBounds.Check(height, t)
+}
+
+// IsExpired returns true if the transaction can not be included after the
+// block of given height and time.
+func (tx Transaction) IsExpired(height uint64, t time.Time) bool {
+	if tx.B.TimeBounds == nil {
+		return false
+	}
+
+	return tx.B.TimeBounds.IsExpired(height, t)
+}
+
 func (tx Transaction) Version() string {
 	return tx.H.Version
 }
@@ -176,7 +366,14 @@ func (tx Transaction) TotalAmount(withFee bool) common.Amount {
 	return amount
 }
 
-// TotalBaseFee returns the minimum fee of transaction.
+// SourceAmount returns the amount, which is withdrawn from the source. The
+// fee is not included if it is paid by the fee payer.
+func (tx Transaction) SourceAmount() common.Amount {
+	return tx.TotalAmount(!tx.HasFeePayer())
+}
+
+// TotalBaseFee returns the minimum fee of transaction, which does not depend
+// on the fee schedule of network.
 func (tx Transaction) TotalBaseFee() common.Amount {
 	var opsHaveFee int
 	for _, op := range tx.B.Operations {
@@ -191,6 +388,44 @@ func (tx Transaction) TotalBaseFee() common.Amount {
 	return common.BaseFee.MustMult(opsHaveFee)
 }
 
+// MinimumFee returns the minimum fee of transaction by the fee schedule.
+func (tx Transaction) MinimumFee(fs operation.FeeSchedule) common.Amount {
+	return fs.TotalFee(tx.B.Operations)
+}
+
+// IsValidFee checks the fee of transaction is not lower than the
+// `MinimumFee`.
+func (tx Transaction) IsValidFee(fs operation.FeeSchedule) bool {
+	return tx.B.Fee >= tx.MinimumFee(fs)
+}
+
+// FeePerOperation returns the fee of transaction divided by the number of
+// it's operations; the transactions in the `Pool` are prioritized by it.
+func (tx Transaction) FeePerOperation() common.Amount {
+	if len(tx.B.Operations) < 1 {
+		return tx.B.Fee
+	}
+
+	return tx.B.Fee / common.Amount(len(tx.B.Operations))
+}
+
+// CanReplace checks the transaction can replace the other transaction, which
+// has same source and sequence id, by fee. The `FeePerOperation` must be
+// higher than the other by `common.ReplaceByFeeRate` percent at least.
+func (tx Transaction) CanReplace(other Transaction) bool {
+	if tx.Source() != other.Source() || tx.B.SequenceID != other.B.SequenceID {
+		return false
+	}
+
+	fee := tx.FeePerOperation()
+	otherFee := other.FeePerOperation()
+	if fee <= otherFee {
+		return false
+	}
+
+	return uint64(fee-otherFee) >= uint64(otherFee)*common.ReplaceByFeeRate/100
+}
+
 func (tx Transaction) Serialize() (encoded []byte, err error) {
 	encoded, err = json.Marshal(tx)
 	return
@@ -203,14 +438,65 @@ func (tx Transaction) String() string {
 
 func (tx *Transaction) Sign(kp keypair.KP, networkID []byte) {
 	tx.B.Source = kp.Address()
-	tx.H.Hash = tx.B.MakeHashString()
-	signature, _ := keypair.MakeSignature(kp, networkID, tx.H.Hash)
+	tx.H.Hash = tx.MakeHashString()
+	signature, _ := keypair.MakeSignature(kp, networkID, tx.BodyHash())
 
 	tx.H.Signature = base58.Encode(signature)
 
 	return
 }
 
+// AddSignature adds the signature of the other signer of `Body.Source`. If
+// the signer already signed, the signature is replaced.
+func (tx *Transaction) AddSignature(kp keypair.KP, networkID []byte) {
+	tx.H.Hash = tx.MakeHashString()
+	signature, _ := keypair.MakeSignature(kp, networkID, tx.BodyHash())
+
+	s := Signature{Signer: kp.Address(), Signature: base58.Encode(signature)}
+	for i, o := range tx.H.Signatures {
+		if o.Signer == kp.Address() {
+			tx.H.Signatures[i] = s
+			return
+		}
+	}
+	tx.H.Signatures = append(tx.H.Signatures, s)
+
+	return
+}
+
+// SetFeePayer wraps the transaction with the `FeeBump` envelope of the fee
+// payer. The signatures of source are kept, but the signatures of the
+// previous fee payer are removed.
+func (tx *Transaction) SetFeePayer(feePayer string) {
+	tx.F = &FeeBump{FeePayer: feePayer}
+	tx.H.Hash = tx.MakeHashString()
+
+	return
+}
+
+// SignFeePayer adds the signature of the signer of fee payer to the `FeeBump`
+// envelope. If the transaction does not have the fee payer yet, the signer
+// becomes the fee payer. If the signer already signed, the signature is
+// replaced.
+func (tx *Transaction) SignFeePayer(kp keypair.KP, networkID []byte) {
+	if tx.F == nil {
+		tx.SetFeePayer(kp.Address())
+	}
+	tx.H.Hash = tx.MakeHashString()
+	signature, _ := keypair.MakeSignature(kp, networkID, tx.H.Hash)
+
+	s := Signature{Signer: kp.Address(), Signature: base58.Encode(signature)}
+	for i, o := range tx.F.Signatures {
+		if o.Signer == kp.Address() {
+			tx.F.Signatures[i] = s
+			return
+		}
+	}
+	tx.F.Signatures = append(tx.F.Signatures, s)
+
+	return
+}
+
 func (tx Transaction) IsEmpty() bool {
 	return len(tx.GetHash()) < 1
 }
@@ -218,3 +504,12 @@ func (tx Transaction) IsEmpty() bool {
 func (tx Transaction) IsValidVersion(version string) bool {
 	return tx.H.Version == version
 }
+
+// HasV2Fields returns true if the transaction has the fields, which are
+// allowed only in `common.TransactionVersionV2`.
+func (tx Transaction) HasV2Fields() bool {
+	return tx.B.TimeBounds != nil ||
+		tx.B.Memo != nil ||
+		tx.HasFeePayer() ||
+		len(tx.H.Signatures) > 0
+}
@@ -1,7 +1,9 @@
 package transaction
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"boscoin.io/sebak/lib/common"
 	"boscoin.io/sebak/lib/common/keypair"
@@ -194,6 +196,611 @@ func (suite *TestSuite) TestIsWellFormedTransactionMaxOperationsInTransactionSui
 	}
 }
 
+func (suite *TestSuite) TestIsWellFormedTransactionWithMultipleSignaturesSuite() {
+	var err error
+
+	kpSigner := keypair.Random()
+
+	{ // signed by source and the other signer
+		_, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []string{tx.B.Source, kpSigner.Address()}, tx.Signers())
+	}
+
+	{ // signed only by the other signer
+		_, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.H.Signature = ""
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []string{kpSigner.Address()}, tx.Signers())
+	}
+
+	{ // without any signature
+		_, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.H.Signature = ""
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.SignatureVerificationFailed, err)
+	}
+
+	{ // invalid signature of the other signer
+		_, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		tx.H.Signatures[0].Signature = tx.H.Signature
+		err = tx.IsWellFormed(suite.conf)
+		require.NotNil(suite.T(), err)
+	}
+
+	{ // source signs twice
+		kp, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.AddSignature(kp, suite.conf.NetworkID)
+		err = tx.IsWellFormed(suite.conf)
+		require.Equal(suite.T(), errors.DuplicatedSigner, err)
+	}
+
+	{ // adding signatures does not change the hash
+		_, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		hash := tx.GetHash()
+		tx.AddSignature(kpSigner, suite.conf.NetworkID)
+		require.Equal(suite.T(), hash, tx.GetHash())
+
+		var tx2 Transaction
+		common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &tx2)
+		require.Equal(suite.T(), hash, tx2.GetHash())
+		require.Equal(suite.T(), tx.H.Signatures, tx2.H.Signatures)
+	}
+}
+
+func (suite *TestSuite) TestIsWellFormedTransactionWithTimeBoundsSuite() {
+	now := time.Now()
+
+	valids := []TimeBounds{
+		{MinHeight: 10},
+		{MaxHeight: 10},
+		{MinHeight: 10, MaxHeight: 10},
+		{MinTime: common.FormatISO8601(now)},
+		{MinTime: common.FormatISO8601(now), MaxTime: common.FormatISO8601(now.Add(time.Minute))},
+	}
+	for _, tb := range valids {
+		kp, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.B.TimeBounds = &TimeBounds{}
+		*tx.B.TimeBounds = tb
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Nil(suite.T(), tx.IsWellFormed(suite.conf))
+	}
+
+	invalids := []TimeBounds{
+		{},
+		{MinHeight: 11, MaxHeight: 10},
+		{MinTime: "2018-04-17"},
+		{MinTime: common.FormatISO8601(now), MaxTime: common.FormatISO8601(now.Add(-time.Minute))},
+	}
+	for _, tb := range invalids {
+		kp, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		tx.B.TimeBounds = &TimeBounds{}
+		*tx.B.TimeBounds = tb
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Equal(suite.T(), errors.InvalidTimeBounds, tx.IsWellFormed(suite.conf))
+	}
+}
+
+func TestTransactionTimeBoundsHash(t *testing.T) {
+	_, tx := TestMakeTransaction(common.NewTestConfig().NetworkID, 1)
+
+	// without time bounds, the hash is same with the old `Body`
+	old := struct {
+		Source     string
+		Fee        common.Amount
+		SequenceID uint64
+		Operations []operation.Operation
+	}{tx.B.Source, tx.B.Fee, tx.B.SequenceID, tx.B.Operations}
+	require.Equal(t, common.MustMakeObjectHashString(old), tx.B.MakeHashString())
+	common.CheckRoundTripRLP(t, tx.B)
+
+	hash := tx.B.MakeHashString()
+	tx.B.TimeBounds = &TimeBounds{MaxHeight: 10}
+	require.NotEqual(t, hash, tx.B.MakeHashString())
+	common.CheckRoundTripRLP(t, tx.B)
+
+	var tx2 Transaction
+	common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &tx2)
+	require.Equal(t, tx.B.TimeBounds, tx2.B.TimeBounds)
+	require.Equal(t, tx.B.MakeHashString(), tx2.GetHash())
+}
+
+func (suite *TestSuite) TestIsWellFormedTransactionWithMemoSuite() {
+	hash := base58.Encode(common.MakeHash([]byte("deposit")))
+
+	valids := []Memo{
+		NewMemo(MemoText, "customer-1"),
+		NewMemo(MemoText, ""),
+		NewMemo(MemoID, "18446744073709551615"),
+		NewMemo(MemoHash, hash),
+	}
+	for _, memo := range valids {
+		kp, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		m := memo
+		tx.B.Memo = &m
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Nil(suite.T(), tx.IsWellFormed(suite.conf), "memo: %v", memo)
+	}
+
+	invalids := []Memo{
+		{},
+		NewMemo("unknown", "customer-1"),
+		NewMemo(MemoText, strings.Repeat("a", common.MaxMemoTextLength+1)),
+		NewMemo(MemoID, "-1"),
+		NewMemo(MemoID, "18446744073709551616"),
+		NewMemo(MemoHash, "customer-1"),
+	}
+	for _, memo := range invalids {
+		kp, tx := TestMakeTransactionV2(suite.conf.NetworkID, 1)
+		m := memo
+		tx.B.Memo = &m
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Equal(suite.T(), errors.InvalidMemo, tx.IsWellFormed(suite.conf), "memo: %v", memo)
+	}
+}
+
+func (suite *TestSuite) TestIsWellFormedTransactionWithAccountMergeSuite() {
+	kpTarget := keypair.Random()
+	opMerge, _ := operation.NewOperation(operation.NewAccountMerge(kpTarget.Address()))
+
+	{ // account merge is the last operation
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.B.Operations = append(tx.B.Operations, opMerge)
+		tx.B.Fee = common.BaseFee * 2
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.NoError(suite.T(), tx.IsWellFormed(suite.conf))
+	}
+
+	{ // account merge is not the last operation
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		tx.B.Operations = append([]operation.Operation{opMerge}, tx.B.Operations...)
+		tx.B.Fee = common.BaseFee * 2
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Equal(suite.T(), errors.InvalidOperation, tx.IsWellFormed(suite.conf))
+	}
+
+	{ // merge into itself
+		kp, tx := TestMakeTransaction(suite.conf.NetworkID, 1)
+		op, _ := operation.NewOperation(operation.NewAccountMerge(kp.Address()))
+		tx.B.Operations = []operation.Operation{op}
+		tx.Sign(kp, suite.conf.NetworkID)
+		require.Equal(suite.T(), errors.InvalidOperation, tx.IsWellFormed(suite.conf))
+	}
+}
+
+func TestTransactionMemoHash(t *testing.T) {
+	_, tx := TestMakeTransaction(common.NewTestConfig().NetworkID, 1)
+	hash := tx.B.MakeHashString()
+
+	memo := NewMemo(MemoText, "customer-1")
+	tx.B.Memo = &memo
+	hashWithMemo := tx.B.MakeHashString()
+	require.NotEqual(t, hash, hashWithMemo)
+	common.CheckRoundTripRLP(t, tx.B)
+
+	// the memo is a part of hash
+	memo2 := NewMemo(MemoText, "customer-2")
+	tx.B.Memo = &memo2
+	require.NotEqual(t, hashWithMemo, tx.B.MakeHashString())
+
+	// with time bounds
+	tx.B.TimeBounds = &TimeBounds{MaxHeight: 10}
+	common.CheckRoundTripRLP(t, tx.B)
+
+	var tx2 Transaction
+	common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &tx2)
+	require.Equal(t, tx.B.Memo, tx2.B.Memo)
+	require.Equal(t, tx.B.MakeHashString(), tx2.GetHash())
+}
+
+func TestTransactionVersion(t *testing.T) {
+	conf := common.NewTestConfig()
+	kp, tx := TestMakeTransaction(conf.NetworkID, 1)
+	require.Equal(t, common.TransactionVersionV1, tx.Version())
+	require.NoError(t, tx.IsWellFormed(conf))
+
+	// the version of V1 is not a part of hash
+	hash := tx.GetHash()
+	require.Equal(t, tx.B.MakeHashString(), hash)
+
+	// the version of V2 is a part of hash
+	tx.H.Version = common.TransactionVersionV2
+	tx.Sign(kp, conf.NetworkID)
+	require.NotEqual(t, hash, tx.GetHash())
+	require.NoError(t, tx.IsWellFormed(conf))
+	hashV2 := tx.GetHash()
+
+	for version, expected := range map[string]string{common.TransactionVersionV1: hash, common.TransactionVersionV2: hashV2} {
+		tx.H.Version = version
+
+		var decoded Transaction
+		common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &decoded)
+		require.Equal(t, version, decoded.Version())
+		require.Equal(t, expected, decoded.GetHash())
+		common.CheckRoundTripRLP(t, tx.B)
+	}
+
+	{ // the signed version can not be changed
+		tx.H.Version = common.TransactionVersionV1
+		tx.H.Hash = tx.MakeHashString()
+		require.NotNil(t, tx.IsWellFormed(conf))
+	}
+
+	// the fields of V2 can not be in V1
+	memo := NewMemo(MemoText, "customer-1")
+	tx.B.Memo = &memo
+	tx.H.Version = common.TransactionVersionV1
+	tx.Sign(kp, conf.NetworkID)
+	require.True(t, tx.HasV2Fields())
+	require.Equal(t, errors.TransactionRequiresV2, tx.IsWellFormed(conf))
+
+	tx.H.Version = common.TransactionVersionV2
+	tx.Sign(kp, conf.NetworkID)
+	require.NoError(t, tx.IsWellFormed(conf))
+
+	tx.H.Version = "3"
+	require.Equal(t, errors.InvalidMessageVersion, tx.IsWellFormed(conf))
+}
+
+func TestTransactionFeePayer(t *testing.T) {
+	conf := common.NewTestConfig()
+	kp, tx := TestMakeTransactionV2(conf.NetworkID, 1)
+	hash := tx.GetHash()
+	signature := tx.H.Signature
+	require.False(t, tx.HasFeePayer())
+	require.Equal(t, tx.B.Source, tx.FeeSource())
+	require.Equal(t, tx.TotalAmount(true), tx.SourceAmount())
+
+	// the fee payer wraps the transaction, which is already signed by source
+	payer := keypair.Random()
+	tx.SetFeePayer(payer.Address())
+	require.NotEqual(t, hash, tx.GetHash())
+	require.Equal(t, hash, tx.BodyHash())
+	require.Equal(t, signature, tx.H.Signature)
+
+	// without the signature of fee payer
+	require.Equal(t, errors.SignatureVerificationFailed, tx.IsWellFormed(conf))
+
+	tx.SignFeePayer(payer, conf.NetworkID)
+	require.NoError(t, tx.IsWellFormed(conf))
+	require.True(t, tx.HasFeePayer())
+	require.Equal(t, payer.Address(), tx.FeePayer())
+	require.Equal(t, payer.Address(), tx.FeeSource())
+	require.Equal(t, tx.TotalAmount(false), tx.SourceAmount())
+	require.Equal(t, []string{payer.Address()}, tx.F.Signers())
+
+	var tx2 Transaction
+	common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &tx2)
+	require.Equal(t, tx.F, tx2.F)
+	require.Equal(t, tx.GetHash(), tx2.GetHash())
+	require.NoError(t, tx2.IsWellFormed(conf))
+
+	{ // the fee payer can not be changed after signed
+		invalid := tx
+		invalid.F = &FeeBump{FeePayer: keypair.Random().Address(), Signatures: tx.F.Signatures}
+		invalid.H.Hash = invalid.MakeHashString()
+		require.Error(t, invalid.IsWellFormed(conf))
+	}
+
+	{ // the body can not be changed after signed by the fee payer
+		var invalid Transaction
+		common.MustUnmarshalJSON(common.MustMarshalJSON(tx), &invalid)
+		invalid.B.Fee = invalid.B.Fee * 2
+		invalid.Sign(kp, conf.NetworkID)
+		require.Error(t, invalid.IsWellFormed(conf))
+	}
+
+	{ // signed by the other keypair
+		invalid := tx
+		invalid.F = &FeeBump{
+			FeePayer:   payer.Address(),
+			Signatures: []Signature{{Signer: payer.Address(), Signature: tx.H.Signature}},
+		}
+		require.Error(t, invalid.IsWellFormed(conf))
+	}
+
+	{ // source can not be the fee payer
+		invalid := tx
+		invalid.F = nil
+		invalid.SignFeePayer(kp, conf.NetworkID)
+		require.Equal(t, errors.InvalidFeePayer, invalid.IsWellFormed(conf))
+	}
+
+	{ // the fee payer is allowed only in V2
+		invalid := tx
+		invalid.H.Version = common.TransactionVersionV1
+		require.Equal(t, errors.TransactionRequiresV2, invalid.IsWellFormed(conf))
+	}
+}
+
+func TestTransactionCheckTimeBounds(t *testing.T) {
+	now := time.Now()
+	tb := TimeBounds{
+		MinHeight: 10,
+		MaxHeight: 20,
+		MinTime:   common.FormatISO8601(now),
+		MaxTime:   common.FormatISO8601(now.Add(time.Minute)),
+	}
+
+	require.NoError(t, tb.Check(10, now))
+	require.NoError(t, tb.Check(20, now.Add(time.Minute)))
+	require.Equal(t, errors.TransactionNotYetValid, tb.Check(9, now))
+	require.Equal(t, errors.TransactionNotYetValid, tb.Check(10, now.Add(-time.Second)))
+	require.Equal(t, errors.TransactionExpired, tb.Check(21, now))
+	require.Equal(t, errors.TransactionExpired, tb.Check(10, now.Add(time.Minute+time.Second)))
+
+	require.False(t, tb.IsExpired(9, now.Add(-time.Second)))
+	require.True(t, tb.IsExpired(21, now))
+}
+
+func TestPoolRemoveExpired(t *testing.T) {
+	pool := NewPool(common.NewTestConfig())
+
+	_, tx := TestMakeTransaction(common.NewTestConfig().NetworkID, 1)
+	require.NoError(t, pool.Add(tx))
+
+	_, txBounded := TestMakeTransactionV2(common.NewTestConfig().NetworkID, 1)
+	txBounded.B.TimeBounds = &TimeBounds{MaxHeight: 10}
+	txBounded.H.Hash = txBounded.MakeHashString()
+	require.NoError(t, pool.Add(txBounded))
+
+	require.Empty(t, pool.RemoveExpired(10, time.Now()))
+	require.Equal(t, 2, pool.Len())
+
+	require.Equal(t, []string{txBounded.GetHash()}, pool.RemoveExpired(11, time.Now()))
+	require.Equal(t, 1, pool.Len())
+	require.True(t, pool.Has(tx.GetHash()))
+}
+
+// The transactions of same source are kept in order of sequence id.
+func TestPoolTransactionsFromSameSource(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	pool := NewPool(common.NewTestConfig())
+
+	kp, tx0 := TestMakeTransaction(networkID, 1)
+	makeTx := func(sequenceID uint64) Transaction {
+		tx := tx0
+		tx.B.SequenceID = sequenceID
+		tx.Sign(kp, networkID)
+		return tx
+	}
+	tx2 := makeTx(2)
+	tx1 := makeTx(1)
+
+	_, other := TestMakeTransaction(networkID, 1)
+
+	require.NoError(t, pool.Add(tx2))
+	require.NoError(t, pool.Add(other))
+	require.NoError(t, pool.Add(tx1))
+	require.NoError(t, pool.Add(tx0))
+
+	var hashes []string
+	for _, tx := range pool.GetAllFromSource(kp.Address()) {
+		hashes = append(hashes, tx.GetHash())
+	}
+	require.Equal(t, []string{tx0.GetHash(), tx1.GetHash(), tx2.GetHash()}, hashes)
+
+	head, found := pool.GetFromSource(kp.Address())
+	require.True(t, found)
+	require.Equal(t, tx0.GetHash(), head.GetHash())
+
+	// the transactions of source follow the oldest one
+	require.Equal(
+		t,
+		[]string{tx0.GetHash(), tx1.GetHash(), tx2.GetHash(), other.GetHash()},
+		pool.AvailableTransactions(10),
+	)
+	require.Equal(t, []string{tx0.GetHash(), tx1.GetHash()}, pool.AvailableTransactions(2))
+
+	// remove the transactions included in block
+	require.Equal(t, []string{tx0.GetHash(), tx1.GetHash()}, pool.RemoveStaleFromSource(kp.Address(), 2))
+	require.Equal(t, 2, pool.Len())
+
+	pool.Remove(tx2.GetHash())
+	require.False(t, pool.IsSameSource(kp.Address()))
+	require.True(t, pool.IsSameSource(other.Source()))
+
+	pool.RemoveFromSources(other.Source())
+	require.Equal(t, 0, pool.Len())
+}
+
+func TestPoolAvailableTransactionsByFee(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	pool := NewPool(common.NewTestConfig())
+
+	makeTx := func(n int, fee common.Amount) Transaction {
+		kp, tx := TestMakeTransaction(networkID, n)
+		tx.B.Fee = fee
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	low := makeTx(1, common.BaseFee)
+	high := makeTx(1, common.BaseFee.MustMult(3))
+	// the fee per operation of `many` is same with `low`
+	many := makeTx(3, common.BaseFee.MustMult(3))
+	middle := makeTx(1, common.BaseFee.MustMult(2))
+
+	for _, tx := range []Transaction{low, high, many, middle} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	require.Equal(
+		t,
+		[]string{high.GetHash(), middle.GetHash(), low.GetHash(), many.GetHash()},
+		pool.AvailableTransactions(10),
+	)
+	require.Equal(t, []string{high.GetHash(), middle.GetHash()}, pool.AvailableTransactions(2))
+}
+
+// The transactions of sources are merged by their fee; the low fee
+// transaction of source does not take the following high fee ones ahead of
+// the other sources.
+func TestPoolAvailableTransactionsMixedFee(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	pool := NewPool(common.NewTestConfig())
+
+	kpa, kpb := keypair.Random(), keypair.Random()
+	makeTx := func(kp *keypair.Full, sequenceID uint64, feeRate int) Transaction {
+		tx := TestMakeTransactionWithKeypair(networkID, 1, kp)
+		tx.B.SequenceID = sequenceID
+		tx.B.Fee = common.BaseFee.MustMult(feeRate)
+		tx.Sign(kp, networkID)
+		return tx
+	}
+
+	a0 := makeTx(kpa, 0, 5)
+	a1 := makeTx(kpa, 1, 1)
+	a2 := makeTx(kpa, 2, 4)
+	b0 := makeTx(kpb, 0, 3)
+	b1 := makeTx(kpb, 1, 2)
+	b2 := makeTx(kpb, 2, 6)
+
+	for _, tx := range []Transaction{a0, a1, a2, b0, b1, b2} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	require.Equal(
+		t,
+		[]string{a0.GetHash(), b0.GetHash(), b1.GetHash(), b2.GetHash(), a1.GetHash(), a2.GetHash()},
+		pool.AvailableTransactions(10),
+	)
+	require.Equal(t, []string{a0.GetHash(), b0.GetHash(), b1.GetHash()}, pool.AvailableTransactions(3))
+}
+
+func TestPoolReplaceByFee(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	conf := common.NewTestConfig()
+	conf.TxPoolClientLimit = 1
+	pool := NewPool(conf)
+
+	kp, tx := TestMakeTransaction(networkID, 1)
+	makeTx := func(fee common.Amount) Transaction {
+		replacing := tx
+		replacing.B.Fee = fee
+		replacing.Sign(kp, networkID)
+		return replacing
+	}
+
+	require.NoError(t, pool.AddFromClient(tx))
+
+	{ // same fee
+		replacing := TestMakeTransactionWithKeypair(networkID, 1, kp)
+		replacing.B.SequenceID = tx.B.SequenceID
+		replacing.Sign(kp, networkID)
+		require.Equal(t, errors.TransactionReplaceFeeTooLow, pool.AddFromClient(replacing))
+	}
+
+	{ // fee is higher, but not enough
+		replacing := makeTx(tx.B.Fee.MustAdd(common.Amount(1)))
+		require.Equal(t, errors.TransactionReplaceFeeTooLow, pool.AddFromClient(replacing))
+		require.True(t, pool.Has(tx.GetHash()))
+	}
+
+	{ // replaced; the pool is full, but replacing does not increase the size
+		fee := tx.B.Fee.MustAdd(tx.B.Fee / common.Amount(100/common.ReplaceByFeeRate))
+		replacing := makeTx(fee)
+		require.NoError(t, pool.AddFromClient(replacing))
+		require.False(t, pool.Has(tx.GetHash()))
+		require.True(t, pool.Has(replacing.GetHash()))
+		require.Equal(t, 1, pool.Len())
+		require.Equal(t, []string{replacing.GetHash()}, pool.AvailableTransactions(10))
+	}
+
+	{ // the other sequence id is not replacing
+		other := tx
+		other.B.SequenceID++
+		other.B.Fee = tx.B.Fee.MustMult(10)
+		other.Sign(kp, networkID)
+		require.Equal(t, errors.TransactionPoolFull, pool.AddFromClient(other))
+	}
+}
+
+func TestPoolHashes(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	pool := NewPool(common.NewTestConfig())
+
+	kp, tx0 := TestMakeTransaction(networkID, 1)
+	tx1 := tx0
+	tx1.B.SequenceID++
+	tx1.Sign(kp, networkID)
+	_, other := TestMakeTransaction(networkID, 1)
+
+	before := time.Now()
+	for _, tx := range []Transaction{tx1, other, tx0} {
+		require.NoError(t, pool.Add(tx))
+	}
+
+	// in order of arrival
+	require.Equal(t, []string{tx1.GetHash(), other.GetHash(), tx0.GetHash()}, pool.Hashes(""))
+	require.Equal(t, []string{tx1.GetHash(), tx0.GetHash()}, pool.Hashes(kp.Address()))
+
+	received, found := pool.Received(tx0.GetHash())
+	require.True(t, found)
+	require.False(t, received.Before(before))
+
+	pool.Remove(tx0.GetHash())
+	_, found = pool.Received(tx0.GetHash())
+	require.False(t, found)
+	require.Equal(t, []string{tx1.GetHash()}, pool.Hashes(kp.Address()))
+}
+
+func TestPoolSourceLimit(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	conf := common.NewTestConfig()
+	conf.TxPoolSourceLimit = 2
+	pool := NewPool(conf)
+
+	kp, tx := TestMakeTransaction(networkID, 1)
+	makeTx := func(sequenceID uint64, fee common.Amount) Transaction {
+		n := tx
+		n.B.SequenceID = sequenceID
+		n.B.Fee = fee
+		n.Sign(kp, networkID)
+		return n
+	}
+
+	require.NoError(t, pool.AddFromClient(makeTx(0, tx.B.Fee)))
+	require.NoError(t, pool.AddFromNode(makeTx(1, tx.B.Fee)))
+	require.Equal(t, errors.TransactionPoolSourceLimit, pool.AddFromClient(makeTx(2, tx.B.Fee)))
+	require.Equal(t, errors.TransactionPoolSourceLimit, pool.AddFromNode(makeTx(2, tx.B.Fee)))
+
+	// replacing does not increase the transactions of source
+	require.NoError(t, pool.AddFromClient(makeTx(1, tx.B.Fee.MustMult(2))))
+
+	// the other source is not limited
+	_, other := TestMakeTransaction(networkID, 1)
+	require.NoError(t, pool.AddFromClient(other))
+	require.Equal(t, 3, pool.Len())
+}
+
+func TestPoolRemoveOutdated(t *testing.T) {
+	networkID := common.NewTestConfig().NetworkID
+	conf := common.NewTestConfig()
+	conf.TxPoolTTL = time.Minute
+	pool := NewPool(conf)
+
+	_, tx := TestMakeTransaction(networkID, 1)
+	require.NoError(t, pool.Add(tx))
+
+	require.Empty(t, pool.RemoveOutdated(time.Now()))
+	require.Equal(t, []string{tx.GetHash()}, pool.RemoveOutdated(time.Now().Add(time.Minute*2)))
+	require.Equal(t, 0, pool.Len())
+
+	{ // no TTL
+		conf.TxPoolTTL = 0
+		pool := NewPool(conf)
+		require.NoError(t, pool.Add(tx))
+		require.Empty(t, pool.RemoveOutdated(time.Now().Add(time.Hour*24*365)))
+		require.Equal(t, 1, pool.Len())
+	}
+}
+
 func TestTransaction(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
@@ -46,7 +46,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(genesisSecret)
@@ -88,7 +88,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
@@ -131,7 +131,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
@@ -174,7 +174,7 @@ func TestAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.Nil(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(senderAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.Nil(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
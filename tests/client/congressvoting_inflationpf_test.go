@@ -52,19 +52,17 @@ func TestInflationPF(t *testing.T) {
 		createAccount(t, genesisAddr, genesisSecret, account1Addr, payAmount)
 	}
 
-	// Congress Voting
-	{
-		congressAccount, err := c.LoadAccount(CongressAddr)
+	submit := func(addr, secret string, ob operation.Body) (transaction.Transaction, error) {
+		account, err := c.LoadAccount(addr)
 		require.NoError(t, err)
 
-		ob := operation.NewCongressVoting("dummy", 10, 20, common.Amount(fundingAmount), account1Addr)
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(CongressAddr, uint64(congressAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(addr, uint64(account.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
-		sender, err := keypair.Parse(CongressSecret)
+		sender, err := keypair.Parse(secret)
 		require.NoError(t, err)
 		tx.Sign(sender, []byte(NETWORK_ID))
 
@@ -72,6 +70,44 @@ func TestInflationPF(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = c.SubmitTransactionAndWait(tx.H.Hash, body)
+		return tx, err
+	}
+
+	// Congress Membership; account1 is the member of congress
+	var height uint64
+	{
+		mPage, err := c.LoadCongressMembers()
+		require.NoError(t, err)
+
+		var found bool
+		for _, m := range mPage.Embedded.Records {
+			if m.Address == account1Addr {
+				found = true
+			}
+		}
+		if !found {
+			_, err := submit(CongressAddr, CongressSecret, operation.NewCongressMembership(account1Addr, false))
+			require.NoError(t, err)
+		}
+
+		// the voting starts from the block of the latest operation of account1
+		var opage client.OperationsPage
+		for try := 0; try < 5; try++ {
+			opage, err = c.LoadOperationsByAccount(account1Addr, client.Q{Key: client.QueryOrder, Value: "true"})
+			require.NoError(t, err)
+			if len(opage.Embedded.Records) > 0 {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		height = opage.Embedded.Records[0].BlockHeight
+	}
+
+	// Congress Voting
+	var congressVotingHash string
+	{
+		ob := operation.NewCongressVoting("dummy", height, height+5, common.Amount(fundingAmount), account1Addr)
+		tx, err := submit(CongressAddr, CongressSecret, ob)
 		require.NoError(t, err)
 
 		_, err = c.LoadTransaction(tx.H.Hash)
@@ -87,7 +123,7 @@ func TestInflationPF(t *testing.T) {
 			time.Sleep(time.Second)
 		}
 
-		obody := opage.Embedded.Records[0]
+		obody := opage.Embedded.Records[len(opage.Embedded.Records)-1]
 		b, err := json.Marshal(obody.Body)
 		require.NoError(t, err)
 		var cv client.CongressVoting
@@ -97,16 +133,23 @@ func TestInflationPF(t *testing.T) {
 		require.Equal(t, ob.Voting.End, cv.Voting.End)
 		require.Equal(t, ob.FundingAddress, cv.FundingAddress)
 		require.Equal(t, ob.Amount, cv.Amount)
+
+		congressVotingHash = strings.Join([]string{tx.H.Hash, "0"}, "-")
 	}
 
-	// Congress Voting Result
+	// Congress Vote
 	{
-		congressAccount, err := c.LoadAccount(CongressAddr)
+		_, err := submit(account1Addr, account1Secret, operation.NewCongressVote(congressVotingHash, operation.VoteYes))
 		require.NoError(t, err)
 
-		oPage, err := c.LoadOperationsByAccount(CongressAddr, client.Q{Key: client.QueryType, Value: operation.TypeCongressVoting.String()})
+		vPage, err := c.LoadCongressVotes(congressVotingHash)
 		require.NoError(t, err)
+		require.Equal(t, 1, len(vPage.Embedded.Records))
+		require.Equal(t, account1Addr, vPage.Embedded.Records[0].Voter)
+	}
 
+	// Congress Voting Result
+	{
 		ob := operation.NewCongressVotingResult(
 			"dummy1",
 			[]string{"http://1.1.1.1/a", "http://1.1.1.1/b"},
@@ -114,26 +157,21 @@ func TestInflationPF(t *testing.T) {
 			[]string{"http://1.1.1.1/c", "http://1.1.1.1/d"},
 			"dummy3",
 			[]string{"http://1.1.1.1/e", "http://1.1.1.1/f"},
-			100,
-			70,
-			20,
-			10,
-			strings.Join([]string{oPage.Embedded.Records[0].TxHash, "0"}, "-"),
+			1,
+			1,
+			0,
+			0,
+			congressVotingHash,
 		)
-		o, err := operation.NewOperation(ob)
-		require.NoError(t, err)
-
-		tx, err := transaction.NewTransaction(CongressAddr, congressAccount.SequenceID, o)
-		require.NoError(t, err)
 
-		sender, err := keypair.Parse(CongressSecret)
-		require.NoError(t, err)
-		tx.Sign(sender, []byte(NETWORK_ID))
-
-		body, err := tx.Serialize()
-		require.NoError(t, err)
-
-		_, err = c.SubmitTransactionAndWait(tx.H.Hash, body)
+		// the result is accepted after the voting is closed
+		var err error
+		for try := 0; try < 30; try++ {
+			if _, err = submit(CongressAddr, CongressSecret, ob); err == nil {
+				break
+			}
+			time.Sleep(time.Second)
+		}
 		require.NoError(t, err)
 
 		var opage client.OperationsPage
@@ -146,7 +184,7 @@ func TestInflationPF(t *testing.T) {
 			time.Sleep(time.Second)
 		}
 
-		obody := opage.Embedded.Records[0]
+		obody := opage.Embedded.Records[len(opage.Embedded.Records)-1]
 		b, err := json.Marshal(obody.Body)
 		require.NoError(t, err)
 		var cvr client.CongressVotingResult
@@ -181,12 +219,12 @@ func TestInflationPF(t *testing.T) {
 		ob := operation.NewInflationPF(
 			account1Addr,
 			common.Amount(fundingAmount),
-			strings.Join([]string{oPage.Embedded.Records[0].TxHash, "0"}, "-"),
+			strings.Join([]string{oPage.Embedded.Records[len(oPage.Embedded.Records)-1].TxHash, "0"}, "-"),
 		)
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(commonAddr, uint64(commonAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(commonAddr, uint64(commonAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(commonSecret)
@@ -45,7 +45,7 @@ func TestFreezingAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account1Addr, uint64(account1Account.SequenceID), o)
+		tx, err := transaction.NewTransaction(account1Addr, uint64(account1Account.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account1Secret)
@@ -75,7 +75,7 @@ func TestFreezingAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account2Addr, uint64(account2Account.SequenceID), o)
+		tx, err := transaction.NewTransaction(account2Addr, uint64(account2Account.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account2Secret)
@@ -106,7 +106,7 @@ func TestFreezingAccount(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(account2Addr, uint64(account2Account.SequenceID), o)
+		tx, err := transaction.NewTransaction(account2Addr, uint64(account2Account.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(account2Secret)
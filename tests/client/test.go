@@ -29,7 +29,7 @@ func createAccount(t *testing.T, fromAddr, fromSecret, toAddr string, balance ui
 	o, err := operation.NewOperation(ob)
 	require.NoError(t, err)
 
-	tx, err := transaction.NewTransaction(fromAddr, uint64(fromAccount.SequenceID), o)
+	tx, err := transaction.NewTransaction(fromAddr, uint64(fromAccount.SequenceID), operation.NewFeeSchedule(), o)
 	require.NoError(t, err)
 
 	sender, err := keypair.Parse(fromSecret)
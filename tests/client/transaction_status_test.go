@@ -43,7 +43,7 @@ func TestTransactionStatus(t *testing.T) {
 		o, err := operation.NewOperation(ob)
 		require.NoError(t, err)
 
-		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), o)
+		tx, err := transaction.NewTransaction(genesisAddr, uint64(genesisAccount.SequenceID), operation.NewFeeSchedule(), o)
 		require.NoError(t, err)
 
 		sender, err := keypair.Parse(genesisSecret)